@@ -22,6 +22,369 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// TrailingSlash is the trailing slash matching behavior of the router.
+// The explicitly registered path always takes precedence,
+// e.g. if both /users and /users/ are registered, they are routed separately in all modes.
+// The path prefixes are never toggled, e.g. /api isn't routed to /api/*.
+type TrailingSlash int32
+
+const (
+	// redirect to the registered form, it's the default as the redirects of the previous versions,
+	// but with 308 rather than 301 so that the method and body are kept.
+	TrailingSlash_REDIRECT TrailingSlash = 0
+	// /users and /users/ are distinct routes, the other form is responded 404.
+	TrailingSlash_STRICT TrailingSlash = 1
+	// route to the registered form as the same route.
+	TrailingSlash_MERGE TrailingSlash = 2
+)
+
+// Enum value maps for TrailingSlash.
+var (
+	TrailingSlash_name = map[int32]string{
+		0: "REDIRECT",
+		1: "STRICT",
+		2: "MERGE",
+	}
+	TrailingSlash_value = map[string]int32{
+		"REDIRECT": 0,
+		"STRICT":   1,
+		"MERGE":    2,
+	}
+)
+
+func (x TrailingSlash) Enum() *TrailingSlash {
+	p := new(TrailingSlash)
+	*p = x
+	return p
+}
+
+func (x TrailingSlash) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrailingSlash) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[0].Descriptor()
+}
+
+func (TrailingSlash) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[0]
+}
+
+func (x TrailingSlash) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TrailingSlash.Descriptor instead.
+func (TrailingSlash) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{0}
+}
+
+type PathCase int32
+
+const (
+	// /users and /Users are distinct routes.
+	PathCase_SENSITIVE PathCase = 0
+	// route to the lower case registered route, the original path is forwarded.
+	PathCase_INSENSITIVE PathCase = 1
+	// route to the lower case registered route, the path is forwarded in lower case.
+	PathCase_CANONICAL PathCase = 2
+)
+
+// Enum value maps for PathCase.
+var (
+	PathCase_name = map[int32]string{
+		0: "SENSITIVE",
+		1: "INSENSITIVE",
+		2: "CANONICAL",
+	}
+	PathCase_value = map[string]int32{
+		"SENSITIVE":   0,
+		"INSENSITIVE": 1,
+		"CANONICAL":   2,
+	}
+)
+
+func (x PathCase) Enum() *PathCase {
+	p := new(PathCase)
+	*p = x
+	return p
+}
+
+func (x PathCase) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PathCase) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[1].Descriptor()
+}
+
+func (PathCase) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[1]
+}
+
+func (x PathCase) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PathCase.Descriptor instead.
+func (PathCase) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{1}
+}
+
+type FailoverMode int32
+
+const (
+	// the attempts start from the primary pool, they spill over once it's unhealthy.
+	FailoverMode_UNHEALTHY FailoverMode = 0
+	// the nth attempt starts from the nth pool, the last pool is used by the attempts beyond the pools.
+	FailoverMode_PER_ATTEMPT FailoverMode = 1
+)
+
+// Enum value maps for FailoverMode.
+var (
+	FailoverMode_name = map[int32]string{
+		0: "UNHEALTHY",
+		1: "PER_ATTEMPT",
+	}
+	FailoverMode_value = map[string]int32{
+		"UNHEALTHY":   0,
+		"PER_ATTEMPT": 1,
+	}
+)
+
+func (x FailoverMode) Enum() *FailoverMode {
+	p := new(FailoverMode)
+	*p = x
+	return p
+}
+
+func (x FailoverMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FailoverMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[2].Descriptor()
+}
+
+func (FailoverMode) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[2]
+}
+
+func (x FailoverMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FailoverMode.Descriptor instead.
+func (FailoverMode) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{2}
+}
+
+type BalancerPolicy int32
+
+const (
+	// the power of two choices by the latency.
+	BalancerPolicy_P2C BalancerPolicy = 0
+	// the requests of the same hash key are sent to the same backend unless the backends change.
+	BalancerPolicy_CONSISTENT_HASH BalancerPolicy = 1
+)
+
+// Enum value maps for BalancerPolicy.
+var (
+	BalancerPolicy_name = map[int32]string{
+		0: "P2C",
+		1: "CONSISTENT_HASH",
+	}
+	BalancerPolicy_value = map[string]int32{
+		"P2C":             0,
+		"CONSISTENT_HASH": 1,
+	}
+)
+
+func (x BalancerPolicy) Enum() *BalancerPolicy {
+	p := new(BalancerPolicy)
+	*p = x
+	return p
+}
+
+func (x BalancerPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalancerPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[3].Descriptor()
+}
+
+func (BalancerPolicy) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[3]
+}
+
+func (x BalancerPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalancerPolicy.Descriptor instead.
+func (BalancerPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{3}
+}
+
+type MetricsMode int32
+
+const (
+	// the metrics are labeled by the request path.
+	MetricsMode_FULL MetricsMode = 0
+	// the metrics are labeled by the shared path "other".
+	MetricsMode_AGGREGATE MetricsMode = 1
+	// the path-labeled metrics are not recorded.
+	MetricsMode_OFF MetricsMode = 2
+)
+
+// Enum value maps for MetricsMode.
+var (
+	MetricsMode_name = map[int32]string{
+		0: "FULL",
+		1: "AGGREGATE",
+		2: "OFF",
+	}
+	MetricsMode_value = map[string]int32{
+		"FULL":      0,
+		"AGGREGATE": 1,
+		"OFF":       2,
+	}
+)
+
+func (x MetricsMode) Enum() *MetricsMode {
+	p := new(MetricsMode)
+	*p = x
+	return p
+}
+
+func (x MetricsMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MetricsMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[4].Descriptor()
+}
+
+func (MetricsMode) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[4]
+}
+
+func (x MetricsMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MetricsMode.Descriptor instead.
+func (MetricsMode) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{4}
+}
+
+// PartialFailure is the behavior of the aggregate if some of the sub requests failed.
+type PartialFailure int32
+
+const (
+	// respond 502 if any of the sub requests failed
+	PartialFailure_FAIL_ALL PartialFailure = 0
+	// respond 200 with {"error": {"status": 502, "message": "..."}} for the failed ones
+	PartialFailure_INCLUDE_ERRORS PartialFailure = 1
+)
+
+// Enum value maps for PartialFailure.
+var (
+	PartialFailure_name = map[int32]string{
+		0: "FAIL_ALL",
+		1: "INCLUDE_ERRORS",
+	}
+	PartialFailure_value = map[string]int32{
+		"FAIL_ALL":       0,
+		"INCLUDE_ERRORS": 1,
+	}
+)
+
+func (x PartialFailure) Enum() *PartialFailure {
+	p := new(PartialFailure)
+	*p = x
+	return p
+}
+
+func (x PartialFailure) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PartialFailure) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[5].Descriptor()
+}
+
+func (PartialFailure) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[5]
+}
+
+func (x PartialFailure) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PartialFailure.Descriptor instead.
+func (PartialFailure) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{5}
+}
+
+// XForwardedFor is the X-Forwarded-For header behavior to the upstream.
+type XForwardedFor int32
+
+const (
+	// append the client ip to the prior values.
+	XForwardedFor_APPEND XForwardedFor = 0
+	// replace the prior values with the client ip.
+	XForwardedFor_OVERWRITE XForwardedFor = 1
+	// remove the header.
+	XForwardedFor_CLEAR XForwardedFor = 2
+	// keep the header as nil, which means don't populate the header.
+	XForwardedFor_OMIT XForwardedFor = 3
+)
+
+// Enum value maps for XForwardedFor.
+var (
+	XForwardedFor_name = map[int32]string{
+		0: "APPEND",
+		1: "OVERWRITE",
+		2: "CLEAR",
+		3: "OMIT",
+	}
+	XForwardedFor_value = map[string]int32{
+		"APPEND":    0,
+		"OVERWRITE": 1,
+		"CLEAR":     2,
+		"OMIT":      3,
+	}
+)
+
+func (x XForwardedFor) Enum() *XForwardedFor {
+	p := new(XForwardedFor)
+	*p = x
+	return p
+}
+
+func (x XForwardedFor) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (XForwardedFor) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[6].Descriptor()
+}
+
+func (XForwardedFor) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[6]
+}
+
+func (x XForwardedFor) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use XForwardedFor.Descriptor instead.
+func (XForwardedFor) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{6}
+}
+
 type Protocol int32
 
 const (
@@ -55,11 +418,11 @@ func (x Protocol) String() string {
 }
 
 func (Protocol) Descriptor() protoreflect.EnumDescriptor {
-	return file_gateway_config_v1_gateway_proto_enumTypes[0].Descriptor()
+	return file_gateway_config_v1_gateway_proto_enumTypes[7].Descriptor()
 }
 
 func (Protocol) Type() protoreflect.EnumType {
-	return &file_gateway_config_v1_gateway_proto_enumTypes[0]
+	return &file_gateway_config_v1_gateway_proto_enumTypes[7]
 }
 
 func (x Protocol) Number() protoreflect.EnumNumber {
@@ -68,7 +431,55 @@ func (x Protocol) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Protocol.Descriptor instead.
 func (Protocol) EnumDescriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{0}
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{7}
+}
+
+type BackoffPolicy int32
+
+const (
+	// delay = min(max, base * 2^(retries-1))
+	BackoffPolicy_EXPONENTIAL BackoffPolicy = 0
+	// delay = min(max, random(base, prev * 3)), see https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+	BackoffPolicy_DECORRELATED_JITTER BackoffPolicy = 1
+)
+
+// Enum value maps for BackoffPolicy.
+var (
+	BackoffPolicy_name = map[int32]string{
+		0: "EXPONENTIAL",
+		1: "DECORRELATED_JITTER",
+	}
+	BackoffPolicy_value = map[string]int32{
+		"EXPONENTIAL":         0,
+		"DECORRELATED_JITTER": 1,
+	}
+)
+
+func (x BackoffPolicy) Enum() *BackoffPolicy {
+	p := new(BackoffPolicy)
+	*p = x
+	return p
+}
+
+func (x BackoffPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BackoffPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_config_v1_gateway_proto_enumTypes[8].Descriptor()
+}
+
+func (BackoffPolicy) Type() protoreflect.EnumType {
+	return &file_gateway_config_v1_gateway_proto_enumTypes[8]
+}
+
+func (x BackoffPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BackoffPolicy.Descriptor instead.
+func (BackoffPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{8}
 }
 
 type Gateway struct {
@@ -76,11 +487,47 @@ type Gateway struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Version     string        `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	Hosts       []string      `protobuf:"bytes,3,rep,name=hosts,proto3" json:"hosts,omitempty"`
-	Endpoints   []*Endpoint   `protobuf:"bytes,4,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
-	Middlewares []*Middleware `protobuf:"bytes,5,rep,name=middlewares,proto3" json:"middlewares,omitempty"`
+	Name          string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string        `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Hosts         []string      `protobuf:"bytes,3,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	Endpoints     []*Endpoint   `protobuf:"bytes,4,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	Middlewares   []*Middleware `protobuf:"bytes,5,rep,name=middlewares,proto3" json:"middlewares,omitempty"`
+	TrailingSlash TrailingSlash `protobuf:"varint,6,opt,name=trailing_slash,json=trailingSlash,proto3,enum=gateway.config.v1.TrailingSlash" json:"trailing_slash,omitempty"`
+	// redact the sensitive parts of path and query in logs and metrics
+	Sanitize *Sanitize `protobuf:"bytes,7,opt,name=sanitize,proto3" json:"sanitize,omitempty"`
+	// record the self latency of each middleware
+	MiddlewareMetrics bool `protobuf:"varint,8,opt,name=middleware_metrics,json=middlewareMetrics,proto3" json:"middleware_metrics,omitempty"`
+	// the max in-flight retry attempts across the gateway, the first attempts are not counted.
+	// the retries beyond the limit are skipped, default is unlimited.
+	MaxConcurrentRetries int64 `protobuf:"varint,9,opt,name=max_concurrent_retries,json=maxConcurrentRetries,proto3" json:"max_concurrent_retries,omitempty"`
+	// route the HEAD requests to the GET endpoints, the response bodies are discarded.
+	HeadAsGet bool `protobuf:"varint,10,opt,name=head_as_get,json=headAsGet,proto3" json:"head_as_get,omitempty"`
+	// record the requests by the inbound scheme and listener port, it increases the cardinality.
+	InboundMetrics bool `protobuf:"varint,11,opt,name=inbound_metrics,json=inboundMetrics,proto3" json:"inbound_metrics,omitempty"`
+	// limit the rate of new client connections by client ip, the connections beyond are closed.
+	ConnectionLimit *ConnectionLimit `protobuf:"bytes,12,opt,name=connection_limit,json=connectionLimit,proto3" json:"connection_limit,omitempty"`
+	// the update with more endpoints than the limit is rejected, default is unlimited.
+	MaxEndpoints uint32 `protobuf:"varint,13,opt,name=max_endpoints,json=maxEndpoints,proto3" json:"max_endpoints,omitempty"`
+	// handle the requests matched no route instead of 404, the path and method are ignored.
+	// the requests are labeled with the path /*catchall in the metrics.
+	DefaultEndpoint *Endpoint `protobuf:"bytes,14,opt,name=default_endpoint,json=defaultEndpoint,proto3" json:"default_endpoint,omitempty"`
+	// match the request paths case-insensitively, the case-sensitive matches take precedence, default is SENSITIVE.
+	PathCase PathCase `protobuf:"varint,15,opt,name=path_case,json=pathCase,proto3,enum=gateway.config.v1.PathCase" json:"path_case,omitempty"`
+	// the cap of the request timeouts of all the endpoints, the larger ones are capped with a warning, default is uncapped.
+	MaxRequestTimeout *durationpb.Duration `protobuf:"bytes,16,opt,name=max_request_timeout,json=maxRequestTimeout,proto3" json:"max_request_timeout,omitempty"`
+	// respond 503 for all the routes while it's enabled, it's also switched by the debug handler /debug/proxy/maintenance.
+	Maintenance *Maintenance `protobuf:"bytes,17,opt,name=maintenance,proto3" json:"maintenance,omitempty"`
+	// respond the decision path of the trusted requests asking for it, e.g. X-Gateway-Trace: true.
+	DecisionTrace *DecisionTrace `protobuf:"bytes,18,opt,name=decision_trace,json=decisionTrace,proto3" json:"decision_trace,omitempty"`
+	// the defaults of the endpoints by the service metadata, e.g. {"helloworld": {"timeout": "3s"}}.
+	// every service must be of some endpoints.
+	ServiceDefaults map[string]*ServiceDefaults `protobuf:"bytes,19,rep,name=service_defaults,json=serviceDefaults,proto3" json:"service_defaults,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the number of the recent errors kept for each endpoint, they're listed by the debug handler /debug/proxy/errors.
+	// the error messages are truncated to 512 bytes, default is 0 which keeps none.
+	RecentErrors uint32 `protobuf:"varint,20,opt,name=recent_errors,json=recentErrors,proto3" json:"recent_errors,omitempty"`
+	// respond 414 for the requests of the longer URI in bytes, default is 8192. the requests with the control
+	// characters in the decoded path, e.g. %00, are responded 400 regardless.
+	MaxUrlLength uint32 `protobuf:"varint,21,opt,name=max_url_length,json=maxUrlLength,proto3" json:"max_url_length,omitempty"`
 }
 
 func (x *Gateway) Reset() {
@@ -150,143 +597,148 @@ func (x *Gateway) GetMiddlewares() []*Middleware {
 	return nil
 }
 
-type Endpoint struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Gateway) GetTrailingSlash() TrailingSlash {
+	if x != nil {
+		return x.TrailingSlash
+	}
+	return TrailingSlash_REDIRECT
+}
 
-	Path        string               `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	Method      string               `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
-	Description string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Protocol    Protocol             `protobuf:"varint,4,opt,name=protocol,proto3,enum=gateway.config.v1.Protocol" json:"protocol,omitempty"`
-	Timeout     *durationpb.Duration `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	Middlewares []*Middleware        `protobuf:"bytes,6,rep,name=middlewares,proto3" json:"middlewares,omitempty"`
-	Backends    []*Backend           `protobuf:"bytes,7,rep,name=backends,proto3" json:"backends,omitempty"`
-	Retry       *Retry               `protobuf:"bytes,8,opt,name=retry,proto3" json:"retry,omitempty"`
-	Metadata    map[string]string    `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+func (x *Gateway) GetSanitize() *Sanitize {
+	if x != nil {
+		return x.Sanitize
+	}
+	return nil
 }
 
-func (x *Endpoint) Reset() {
-	*x = Endpoint{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Gateway) GetMiddlewareMetrics() bool {
+	if x != nil {
+		return x.MiddlewareMetrics
 	}
+	return false
 }
 
-func (x *Endpoint) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Gateway) GetMaxConcurrentRetries() int64 {
+	if x != nil {
+		return x.MaxConcurrentRetries
+	}
+	return 0
 }
 
-func (*Endpoint) ProtoMessage() {}
+func (x *Gateway) GetHeadAsGet() bool {
+	if x != nil {
+		return x.HeadAsGet
+	}
+	return false
+}
 
-func (x *Endpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Gateway) GetInboundMetrics() bool {
+	if x != nil {
+		return x.InboundMetrics
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
-func (*Endpoint) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{1}
+func (x *Gateway) GetConnectionLimit() *ConnectionLimit {
+	if x != nil {
+		return x.ConnectionLimit
+	}
+	return nil
 }
 
-func (x *Endpoint) GetPath() string {
+func (x *Gateway) GetMaxEndpoints() uint32 {
 	if x != nil {
-		return x.Path
+		return x.MaxEndpoints
 	}
-	return ""
+	return 0
 }
 
-func (x *Endpoint) GetMethod() string {
+func (x *Gateway) GetDefaultEndpoint() *Endpoint {
 	if x != nil {
-		return x.Method
+		return x.DefaultEndpoint
 	}
-	return ""
+	return nil
 }
 
-func (x *Endpoint) GetDescription() string {
+func (x *Gateway) GetPathCase() PathCase {
 	if x != nil {
-		return x.Description
+		return x.PathCase
 	}
-	return ""
+	return PathCase_SENSITIVE
 }
 
-func (x *Endpoint) GetProtocol() Protocol {
+func (x *Gateway) GetMaxRequestTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.Protocol
+		return x.MaxRequestTimeout
 	}
-	return Protocol_UNSPECIFIED
+	return nil
 }
 
-func (x *Endpoint) GetTimeout() *durationpb.Duration {
+func (x *Gateway) GetMaintenance() *Maintenance {
 	if x != nil {
-		return x.Timeout
+		return x.Maintenance
 	}
 	return nil
 }
 
-func (x *Endpoint) GetMiddlewares() []*Middleware {
+func (x *Gateway) GetDecisionTrace() *DecisionTrace {
 	if x != nil {
-		return x.Middlewares
+		return x.DecisionTrace
 	}
 	return nil
 }
 
-func (x *Endpoint) GetBackends() []*Backend {
+func (x *Gateway) GetServiceDefaults() map[string]*ServiceDefaults {
 	if x != nil {
-		return x.Backends
+		return x.ServiceDefaults
 	}
 	return nil
 }
 
-func (x *Endpoint) GetRetry() *Retry {
+func (x *Gateway) GetRecentErrors() uint32 {
 	if x != nil {
-		return x.Retry
+		return x.RecentErrors
 	}
-	return nil
+	return 0
 }
 
-func (x *Endpoint) GetMetadata() map[string]string {
+func (x *Gateway) GetMaxUrlLength() uint32 {
 	if x != nil {
-		return x.Metadata
+		return x.MaxUrlLength
 	}
-	return nil
+	return 0
 }
 
-type Middleware struct {
+// ServiceDefaults are inherited by the endpoints of the service unless they specify their own.
+type ServiceDefaults struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Options *anypb.Any `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	// the timeout of the endpoints without the timeout field or metadata.
+	Timeout *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// the retry merged with the retry of the endpoints, the fields of the endpoints take precedence,
+	// and their conditions replace the ones of the defaults.
+	Retry *Retry `protobuf:"bytes,2,opt,name=retry,proto3" json:"retry,omitempty"`
 }
 
-func (x *Middleware) Reset() {
-	*x = Middleware{}
+func (x *ServiceDefaults) Reset() {
+	*x = ServiceDefaults{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[2]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Middleware) String() string {
+func (x *ServiceDefaults) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Middleware) ProtoMessage() {}
+func (*ServiceDefaults) ProtoMessage() {}
 
-func (x *Middleware) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[2]
+func (x *ServiceDefaults) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -297,55 +749,60 @@ func (x *Middleware) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Middleware.ProtoReflect.Descriptor instead.
-func (*Middleware) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ServiceDefaults.ProtoReflect.Descriptor instead.
+func (*ServiceDefaults) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Middleware) GetName() string {
+func (x *ServiceDefaults) GetTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.Name
+		return x.Timeout
 	}
-	return ""
+	return nil
 }
 
-func (x *Middleware) GetOptions() *anypb.Any {
+func (x *ServiceDefaults) GetRetry() *Retry {
 	if x != nil {
-		return x.Options
+		return x.Retry
 	}
 	return nil
 }
 
-type Backend struct {
+// DecisionTrace describes the matched route, the middlewares ran, the attempts and the duration
+// of a request in the response header, e.g.
+// route=[HTTP] GET /users/*; middleware=cors; attempt={backend=10.0.0.1:8000 status=200 duration=2ms}; duration=3ms
+type DecisionTrace struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// localhost
-	// 127.0.0.1:8000
-	// discovery:///service_name
-	Target      string       `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
-	Weight      *int64       `protobuf:"varint,2,opt,name=weight,proto3,oneof" json:"weight,omitempty"`
-	HealthCheck *HealthCheck `protobuf:"bytes,3,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	// the request header asking for the decision path, default is X-Gateway-Trace. it's not sent to the upstreams.
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// the value of the header, it's compared to "true" if not specified.
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// only the requests from the trusted CIDRs are traced, either the token or the trusted CIDRs must be specified.
+	TrustedCidrs []string `protobuf:"bytes,3,rep,name=trusted_cidrs,json=trustedCidrs,proto3" json:"trusted_cidrs,omitempty"`
+	// the response header of the decision path, default is X-Gateway-Decision.
+	ResponseHeader string `protobuf:"bytes,4,opt,name=response_header,json=responseHeader,proto3" json:"response_header,omitempty"`
 }
 
-func (x *Backend) Reset() {
-	*x = Backend{}
+func (x *DecisionTrace) Reset() {
+	*x = DecisionTrace{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[3]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Backend) String() string {
+func (x *DecisionTrace) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Backend) ProtoMessage() {}
+func (*DecisionTrace) ProtoMessage() {}
 
-func (x *Backend) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[3]
+func (x *DecisionTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -356,55 +813,70 @@ func (x *Backend) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Backend.ProtoReflect.Descriptor instead.
-func (*Backend) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DecisionTrace.ProtoReflect.Descriptor instead.
+func (*DecisionTrace) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Backend) GetTarget() string {
+func (x *DecisionTrace) GetHeader() string {
 	if x != nil {
-		return x.Target
+		return x.Header
 	}
 	return ""
 }
 
-func (x *Backend) GetWeight() int64 {
-	if x != nil && x.Weight != nil {
-		return *x.Weight
+func (x *DecisionTrace) GetToken() string {
+	if x != nil {
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
-func (x *Backend) GetHealthCheck() *HealthCheck {
+func (x *DecisionTrace) GetTrustedCidrs() []string {
 	if x != nil {
-		return x.HealthCheck
+		return x.TrustedCidrs
 	}
 	return nil
 }
 
-type HealthCheck struct {
+func (x *DecisionTrace) GetResponseHeader() string {
+	if x != nil {
+		return x.ResponseHeader
+	}
+	return ""
+}
+
+type Maintenance struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// the response body, default is "503 maintenance".
+	Body string `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	// the response headers, e.g. Content-Type and Retry-After.
+	Headers map[string]string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the paths served as usual, the ones ending with * are prefixes, e.g. /healthz.
+	ExemptPaths []string `protobuf:"bytes,4,rep,name=exempt_paths,json=exemptPaths,proto3" json:"exempt_paths,omitempty"`
 }
 
-func (x *HealthCheck) Reset() {
-	*x = HealthCheck{}
+func (x *Maintenance) Reset() {
+	*x = Maintenance{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[4]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HealthCheck) String() string {
+func (x *Maintenance) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthCheck) ProtoMessage() {}
+func (*Maintenance) ProtoMessage() {}
 
-func (x *HealthCheck) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[4]
+func (x *Maintenance) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -415,41 +887,67 @@ func (x *HealthCheck) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
-func (*HealthCheck) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Maintenance.ProtoReflect.Descriptor instead.
+func (*Maintenance) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{3}
 }
 
-type Retry struct {
+func (x *Maintenance) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Maintenance) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Maintenance) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Maintenance) GetExemptPaths() []string {
+	if x != nil {
+		return x.ExemptPaths
+	}
+	return nil
+}
+
+type ConnectionLimit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// default attempts is 1
-	Attempts      uint32               `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
-	PerTryTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=per_try_timeout,json=perTryTimeout,proto3" json:"per_try_timeout,omitempty"`
-	Conditions    []*Condition         `protobuf:"bytes,3,rep,name=conditions,proto3" json:"conditions,omitempty"`
-	// primary,secondary
-	Priorities []string `protobuf:"bytes,4,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	// new connections per second of a client ip
+	Rate float64 `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	// default is the rate, at least 1
+	Burst int64 `protobuf:"varint,2,opt,name=burst,proto3" json:"burst,omitempty"`
 }
 
-func (x *Retry) Reset() {
-	*x = Retry{}
+func (x *ConnectionLimit) Reset() {
+	*x = ConnectionLimit{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[5]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Retry) String() string {
+func (x *ConnectionLimit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Retry) ProtoMessage() {}
+func (*ConnectionLimit) ProtoMessage() {}
 
-func (x *Retry) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[5]
+func (x *ConnectionLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -460,67 +958,55 @@ func (x *Retry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Retry.ProtoReflect.Descriptor instead.
-func (*Retry) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ConnectionLimit.ProtoReflect.Descriptor instead.
+func (*ConnectionLimit) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Retry) GetAttempts() uint32 {
+func (x *ConnectionLimit) GetRate() float64 {
 	if x != nil {
-		return x.Attempts
+		return x.Rate
 	}
 	return 0
 }
 
-func (x *Retry) GetPerTryTimeout() *durationpb.Duration {
-	if x != nil {
-		return x.PerTryTimeout
-	}
-	return nil
-}
-
-func (x *Retry) GetConditions() []*Condition {
-	if x != nil {
-		return x.Conditions
-	}
-	return nil
-}
-
-func (x *Retry) GetPriorities() []string {
+func (x *ConnectionLimit) GetBurst() int64 {
 	if x != nil {
-		return x.Priorities
+		return x.Burst
 	}
-	return nil
+	return 0
 }
 
-type Condition struct {
+type Sanitize struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Condition:
-	//	*Condition_ByStatusCode
-	//	*Condition_ByHeader
-	Condition isCondition_Condition `protobuf_oneof:"condition"`
+	// names of the query params to redact
+	QueryParams []string `protobuf:"bytes,1,rep,name=query_params,json=queryParams,proto3" json:"query_params,omitempty"`
+	// regexps of the path parts to redact, e.g. [0-9a-f]{32}
+	PathPatterns []string `protobuf:"bytes,2,rep,name=path_patterns,json=pathPatterns,proto3" json:"path_patterns,omitempty"`
+	// default is REDACTED
+	Replacement string `protobuf:"bytes,3,opt,name=replacement,proto3" json:"replacement,omitempty"`
 }
 
-func (x *Condition) Reset() {
-	*x = Condition{}
+func (x *Sanitize) Reset() {
+	*x = Sanitize{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[6]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Condition) String() string {
+func (x *Sanitize) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Condition) ProtoMessage() {}
+func (*Sanitize) ProtoMessage() {}
 
-func (x *Condition) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[6]
+func (x *Sanitize) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -531,76 +1017,167 @@ func (x *Condition) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
-func (*Condition) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Sanitize.ProtoReflect.Descriptor instead.
+func (*Sanitize) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{5}
 }
 
-func (m *Condition) GetCondition() isCondition_Condition {
-	if m != nil {
-		return m.Condition
+func (x *Sanitize) GetQueryParams() []string {
+	if x != nil {
+		return x.QueryParams
 	}
 	return nil
 }
 
-func (x *Condition) GetByStatusCode() string {
-	if x, ok := x.GetCondition().(*Condition_ByStatusCode); ok {
-		return x.ByStatusCode
-	}
-	return ""
-}
-
-func (x *Condition) GetByHeader() *ConditionHeader {
-	if x, ok := x.GetCondition().(*Condition_ByHeader); ok {
-		return x.ByHeader
+func (x *Sanitize) GetPathPatterns() []string {
+	if x != nil {
+		return x.PathPatterns
 	}
 	return nil
 }
 
-type isCondition_Condition interface {
-	isCondition_Condition()
-}
-
-type Condition_ByStatusCode struct {
-	// "500-599", "429"
-	ByStatusCode string `protobuf:"bytes,1,opt,name=by_status_code,json=byStatusCode,proto3,oneof"`
-}
-
-type Condition_ByHeader struct {
-	// {"name": "grpc-status", "value": "14"}
-	ByHeader *ConditionHeader `protobuf:"bytes,2,opt,name=by_header,json=byHeader,proto3,oneof"`
+func (x *Sanitize) GetReplacement() string {
+	if x != nil {
+		return x.Replacement
+	}
+	return ""
 }
 
-func (*Condition_ByStatusCode) isCondition_Condition() {}
-
-func (*Condition_ByHeader) isCondition_Condition() {}
-
-type ConditionHeader struct {
+type Endpoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Path        string               `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Method      string               `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Description string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Protocol    Protocol             `protobuf:"varint,4,opt,name=protocol,proto3,enum=gateway.config.v1.Protocol" json:"protocol,omitempty"`
+	Timeout     *durationpb.Duration `protobuf:"bytes,5,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Middlewares []*Middleware        `protobuf:"bytes,6,rep,name=middlewares,proto3" json:"middlewares,omitempty"`
+	Backends    []*Backend           `protobuf:"bytes,7,rep,name=backends,proto3" json:"backends,omitempty"`
+	Retry       *Retry               `protobuf:"bytes,8,opt,name=retry,proto3" json:"retry,omitempty"`
+	// the well-known keys are "service" and "basePath" of the metrics labels,
+	// and "timeout" e.g. "500ms", which is used only if the timeout field is not set.
+	Metadata         map[string]string `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	UpstreamOverride *UpstreamOverride `protobuf:"bytes,10,opt,name=upstream_override,json=upstreamOverride,proto3" json:"upstream_override,omitempty"`
+	// echo X-Gateway-Deadline-Remaining-Ms response header
+	EchoDeadlineRemaining bool          `protobuf:"varint,11,opt,name=echo_deadline_remaining,json=echoDeadlineRemaining,proto3" json:"echo_deadline_remaining,omitempty"`
+	XForwardedFor         XForwardedFor `protobuf:"varint,12,opt,name=x_forwarded_for,json=xForwardedFor,proto3,enum=gateway.config.v1.XForwardedFor" json:"x_forwarded_for,omitempty"`
+	// route only the requests matched all of the query matchers.
+	Queries []*Query `protobuf:"bytes,13,rep,name=queries,proto3" json:"queries,omitempty"`
+	// serve the static files instead of proxying to the backends
+	Static *Static `protobuf:"bytes,14,opt,name=static,proto3" json:"static,omitempty"`
+	// the number of idle connections pre-established to each backend node once it's added,
+	// it's at most 1 for the gRPC endpoints whose requests are multiplexed over a connection.
+	WarmupConnections uint32 `protobuf:"varint,15,opt,name=warmup_connections,json=warmupConnections,proto3" json:"warmup_connections,omitempty"`
+	// the extra routes served by the same handler of the endpoint.
+	Aliases []*Alias `protobuf:"bytes,16,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// the dialer of the connections to the backends, the shared transport is used if not set.
+	Dialer *Dialer `protobuf:"bytes,17,opt,name=dialer,proto3" json:"dialer,omitempty"`
+	// the User-Agent to the backends, the client one is passed through if not set.
+	UserAgent *UserAgent `protobuf:"bytes,18,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	// the response headers forwarded to the clients, all of them are forwarded if not set.
+	ResponseHeaders *HeaderFilter `protobuf:"bytes,19,opt,name=response_headers,json=responseHeaders,proto3" json:"response_headers,omitempty"`
+	// map the Grpc-Status to the HTTP status of the responses to the grpc-web and HTTP inbound requests,
+	// the native gRPC responses are passed through with 200.
+	GrpcHttpStatus bool `protobuf:"varint,20,opt,name=grpc_http_status,json=grpcHttpStatus,proto3" json:"grpc_http_status,omitempty"`
+	// spill the request bodies larger than the threshold in bytes to a temp file instead of the memory,
+	// the file is replayed for each attempt and removed when the request is done, default is never.
+	// the bodies are only buffered if they may be retried, otherwise they're streamed to the upstream.
+	BodyFileThreshold int64 `protobuf:"varint,21,opt,name=body_file_threshold,json=bodyFileThreshold,proto3" json:"body_file_threshold,omitempty"`
+	// match the gRPC service and method instead of the path, the method-level routes take precedence
+	// over the service-level ones. only for the gRPC protocol.
+	Grpc *GRPCRoute `protobuf:"bytes,22,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	// append the Server-Timing response header of the gateway, upstream and retry durations.
+	ServerTiming bool `protobuf:"varint,23,opt,name=server_timing,json=serverTiming,proto3" json:"server_timing,omitempty"`
+	// connect to the backends with TLS
+	Tls bool `protobuf:"varint,24,opt,name=tls,proto3" json:"tls,omitempty"`
+	// the SNI and the verified name of the TLS backends instead of the backend host, only with tls.
+	TlsServerName string `protobuf:"bytes,25,opt,name=tls_server_name,json=tlsServerName,proto3" json:"tls_server_name,omitempty"`
+	// log and count the responses without body other than 1xx, 204 and 304.
+	ReportEmptyResponse bool `protobuf:"varint,26,opt,name=report_empty_response,json=reportEmptyResponse,proto3" json:"report_empty_response,omitempty"`
+	// abort the responses if the upstream body is transferred slower than the floor,
+	// the response is truncated since the status has been sent.
+	MinResponseRate *ResponseRate `protobuf:"bytes,27,opt,name=min_response_rate,json=minResponseRate,proto3" json:"min_response_rate,omitempty"`
+	// read the body of the Expect: 100-continue requests after the middlewares instead of ahead of them,
+	// so that the requests rejected by the middlewares, e.g. 401 or 429, are responded before the body is sent.
+	DeferExpectContinue bool `protobuf:"varint,28,opt,name=defer_expect_continue,json=deferExpectContinue,proto3" json:"defer_expect_continue,omitempty"`
+	// close the upstream connection after the responses matched any of the conditions instead of reusing it,
+	// e.g. {"by_status_code": "500-599"}. only for the HTTP protocol.
+	CloseConnectionOn []*Condition `protobuf:"bytes,29,rep,name=close_connection_on,json=closeConnectionOn,proto3" json:"close_connection_on,omitempty"`
+	// fan out the request to the sub requests concurrently and aggregate their JSON responses,
+	// instead of proxying to the backends. it can't be set with backends, split or failover.
+	Aggregate *Aggregate `protobuf:"bytes,30,opt,name=aggregate,proto3" json:"aggregate,omitempty"`
+	// the request header of the verbatim request URI before any rewriting, e.g. X-Original-URI.
+	OriginalUriHeader string `protobuf:"bytes,31,opt,name=original_uri_header,json=originalUriHeader,proto3" json:"original_uri_header,omitempty"`
+	// the HTTP/2 keepalive pings of the gRPC upstream connections, disabled if not specified.
+	Keepalive *Keepalive `protobuf:"bytes,32,opt,name=keepalive,proto3" json:"keepalive,omitempty"`
+	// the path-labeled metrics of the endpoint, default is FULL.
+	MetricsMode MetricsMode `protobuf:"varint,33,opt,name=metrics_mode,json=metricsMode,proto3,enum=gateway.config.v1.MetricsMode" json:"metrics_mode,omitempty"`
+	// honor the absolute deadline of the client, the timeout is the earlier of it and the endpoint timeout.
+	ClientDeadline *ClientDeadline `protobuf:"bytes,34,opt,name=client_deadline,json=clientDeadline,proto3" json:"client_deadline,omitempty"`
+	// the load balancing of the backends, default is P2C.
+	Balancer *Balancer `protobuf:"bytes,35,opt,name=balancer,proto3" json:"balancer,omitempty"`
+	// the request header of the gateway-internal id correlating the attempts of a request,
+	// the value is the id and the attempt number, e.g. "<id>.2" for the first retry, e.g. X-Gateway-Correlation-Id.
+	CorrelationHeader string `protobuf:"bytes,36,opt,name=correlation_header,json=correlationHeader,proto3" json:"correlation_header,omitempty"`
+	// the limit of the upstream response header bytes, default is 1MB of HTTP/1 or 10MB of HTTP/2 as Go does.
+	MaxResponseHeaderBytes int64 `protobuf:"varint,37,opt,name=max_response_header_bytes,json=maxResponseHeaderBytes,proto3" json:"max_response_header_bytes,omitempty"`
+	// the minimum TLS version of the TLS backends, one of 1.0, 1.1, 1.2 and 1.3, default is the Go's, only with tls.
+	TlsMinVersion string `protobuf:"bytes,38,opt,name=tls_min_version,json=tlsMinVersion,proto3" json:"tls_min_version,omitempty"`
+	// the allowlist of the cipher suites of the TLS backends by the Go names, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	// default is the Go's. the TLS 1.3 cipher suites are not configurable, so the TLS version is pinned to 1.2
+	// if set, and it can't be set with the min version 1.3. only with tls.
+	TlsCipherSuites []string `protobuf:"bytes,39,rep,name=tls_cipher_suites,json=tlsCipherSuites,proto3" json:"tls_cipher_suites,omitempty"`
+	// reject the requests not speaking the protocol with 400 or 415, the gRPC endpoints accept the native gRPC
+	// over HTTP/2 and the grpc-web, the HTTP endpoints reject the gRPC. it's not for the transcoded endpoints.
+	StrictProtocol bool `protobuf:"varint,40,opt,name=strict_protocol,json=strictProtocol,proto3" json:"strict_protocol,omitempty"`
+	// close the upgraded connections, e.g. WebSocket, if no bytes are copied in either direction within the timeout,
+	// default is unlimited.
+	TunnelIdleTimeout *durationpb.Duration `protobuf:"bytes,41,opt,name=tunnel_idle_timeout,json=tunnelIdleTimeout,proto3" json:"tunnel_idle_timeout,omitempty"`
+	// split the requests to the variants of the backends by the weights, instead of proxying to the backends.
+	Split *Split `protobuf:"bytes,42,opt,name=split,proto3" json:"split,omitempty"`
+	// follow the redirects of the upstream to the same host and respond the final response,
+	// instead of passing them through to the clients. the redirects to the other hosts are passed through.
+	FollowRedirects *FollowRedirects `protobuf:"bytes,43,opt,name=follow_redirects,json=followRedirects,proto3" json:"follow_redirects,omitempty"`
+	// append the Via of the gateway to the forwarded requests and the responses, e.g. "1.1 gateway",
+	// see https://www.rfc-editor.org/rfc/rfc7230#section-5.7.1
+	Via *Via `protobuf:"bytes,44,opt,name=via,proto3" json:"via,omitempty"`
+	// the client certificate and key files in PEM of the TLS backends requiring the client authentication,
+	// the changed files are reloaded for the new connections, only with tls.
+	TlsClientCert string `protobuf:"bytes,45,opt,name=tls_client_cert,json=tlsClientCert,proto3" json:"tls_client_cert,omitempty"`
+	TlsClientKey  string `protobuf:"bytes,46,opt,name=tls_client_key,json=tlsClientKey,proto3" json:"tls_client_key,omitempty"`
+	// skip writing the 499 to the clients canceled the requests, since the clients are gone,
+	// the 499 is recorded by the metrics and the access logs either way.
+	SkipClientCancel bool `protobuf:"varint,47,opt,name=skip_client_cancel,json=skipClientCancel,proto3" json:"skip_client_cancel,omitempty"`
+	// fail over the attempts from the backends to the fallback pools in order, only without split.
+	Failover *Failover `protobuf:"bytes,48,opt,name=failover,proto3" json:"failover,omitempty"`
+	// the regular expressions of the request paths bypassing the middlewares of the endpoint, e.g. "^/api/health$",
+	// they're still proxied to the upstream. the global middlewares run for them as well, and the paths are matched
+	// after the global middlewares, e.g. rewrite.
+	MiddlewareBypassPaths []string `protobuf:"bytes,49,rep,name=middleware_bypass_paths,json=middlewareBypassPaths,proto3" json:"middleware_bypass_paths,omitempty"`
+	// pass the redirects of the upstream through to the clients instead of following them,
+	// the redirects are followed up to 10 to any host by default. it can't be set with follow_redirects.
+	PassRedirects bool `protobuf:"varint,50,opt,name=pass_redirects,json=passRedirects,proto3" json:"pass_redirects,omitempty"`
 }
 
-func (x *ConditionHeader) Reset() {
-	*x = ConditionHeader{}
+func (x *Endpoint) Reset() {
+	*x = Endpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_gateway_config_v1_gateway_proto_msgTypes[8]
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ConditionHeader) String() string {
+func (x *Endpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConditionHeader) ProtoMessage() {}
+func (*Endpoint) ProtoMessage() {}
 
-func (x *ConditionHeader) ProtoReflect() protoreflect.Message {
-	mi := &file_gateway_config_v1_gateway_proto_msgTypes[8]
+func (x *Endpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,25 +1188,2381 @@ func (x *ConditionHeader) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConditionHeader.ProtoReflect.Descriptor instead.
-func (*ConditionHeader) Descriptor() ([]byte, []int) {
-	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{6, 0}
+// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ConditionHeader) GetName() string {
+func (x *Endpoint) GetPath() string {
 	if x != nil {
-		return x.Name
+		return x.Path
 	}
 	return ""
 }
 
-func (x *ConditionHeader) GetValue() string {
+func (x *Endpoint) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Endpoint) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Endpoint) GetProtocol() Protocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return Protocol_UNSPECIFIED
+}
+
+func (x *Endpoint) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Endpoint) GetMiddlewares() []*Middleware {
+	if x != nil {
+		return x.Middlewares
+	}
+	return nil
+}
+
+func (x *Endpoint) GetBackends() []*Backend {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+func (x *Endpoint) GetRetry() *Retry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *Endpoint) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Endpoint) GetUpstreamOverride() *UpstreamOverride {
+	if x != nil {
+		return x.UpstreamOverride
+	}
+	return nil
+}
+
+func (x *Endpoint) GetEchoDeadlineRemaining() bool {
+	if x != nil {
+		return x.EchoDeadlineRemaining
+	}
+	return false
+}
+
+func (x *Endpoint) GetXForwardedFor() XForwardedFor {
+	if x != nil {
+		return x.XForwardedFor
+	}
+	return XForwardedFor_APPEND
+}
+
+func (x *Endpoint) GetQueries() []*Query {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *Endpoint) GetStatic() *Static {
+	if x != nil {
+		return x.Static
+	}
+	return nil
+}
+
+func (x *Endpoint) GetWarmupConnections() uint32 {
+	if x != nil {
+		return x.WarmupConnections
+	}
+	return 0
+}
+
+func (x *Endpoint) GetAliases() []*Alias {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Endpoint) GetDialer() *Dialer {
+	if x != nil {
+		return x.Dialer
+	}
+	return nil
+}
+
+func (x *Endpoint) GetUserAgent() *UserAgent {
+	if x != nil {
+		return x.UserAgent
+	}
+	return nil
+}
+
+func (x *Endpoint) GetResponseHeaders() *HeaderFilter {
+	if x != nil {
+		return x.ResponseHeaders
+	}
+	return nil
+}
+
+func (x *Endpoint) GetGrpcHttpStatus() bool {
+	if x != nil {
+		return x.GrpcHttpStatus
+	}
+	return false
+}
+
+func (x *Endpoint) GetBodyFileThreshold() int64 {
+	if x != nil {
+		return x.BodyFileThreshold
+	}
+	return 0
+}
+
+func (x *Endpoint) GetGrpc() *GRPCRoute {
+	if x != nil {
+		return x.Grpc
+	}
+	return nil
+}
+
+func (x *Endpoint) GetServerTiming() bool {
+	if x != nil {
+		return x.ServerTiming
+	}
+	return false
+}
+
+func (x *Endpoint) GetTls() bool {
+	if x != nil {
+		return x.Tls
+	}
+	return false
+}
+
+func (x *Endpoint) GetTlsServerName() string {
+	if x != nil {
+		return x.TlsServerName
+	}
+	return ""
+}
+
+func (x *Endpoint) GetReportEmptyResponse() bool {
+	if x != nil {
+		return x.ReportEmptyResponse
+	}
+	return false
+}
+
+func (x *Endpoint) GetMinResponseRate() *ResponseRate {
+	if x != nil {
+		return x.MinResponseRate
+	}
+	return nil
+}
+
+func (x *Endpoint) GetDeferExpectContinue() bool {
+	if x != nil {
+		return x.DeferExpectContinue
+	}
+	return false
+}
+
+func (x *Endpoint) GetCloseConnectionOn() []*Condition {
+	if x != nil {
+		return x.CloseConnectionOn
+	}
+	return nil
+}
+
+func (x *Endpoint) GetAggregate() *Aggregate {
+	if x != nil {
+		return x.Aggregate
+	}
+	return nil
+}
+
+func (x *Endpoint) GetOriginalUriHeader() string {
+	if x != nil {
+		return x.OriginalUriHeader
+	}
+	return ""
+}
+
+func (x *Endpoint) GetKeepalive() *Keepalive {
+	if x != nil {
+		return x.Keepalive
+	}
+	return nil
+}
+
+func (x *Endpoint) GetMetricsMode() MetricsMode {
+	if x != nil {
+		return x.MetricsMode
+	}
+	return MetricsMode_FULL
+}
+
+func (x *Endpoint) GetClientDeadline() *ClientDeadline {
+	if x != nil {
+		return x.ClientDeadline
+	}
+	return nil
+}
+
+func (x *Endpoint) GetBalancer() *Balancer {
+	if x != nil {
+		return x.Balancer
+	}
+	return nil
+}
+
+func (x *Endpoint) GetCorrelationHeader() string {
+	if x != nil {
+		return x.CorrelationHeader
+	}
+	return ""
+}
+
+func (x *Endpoint) GetMaxResponseHeaderBytes() int64 {
+	if x != nil {
+		return x.MaxResponseHeaderBytes
+	}
+	return 0
+}
+
+func (x *Endpoint) GetTlsMinVersion() string {
+	if x != nil {
+		return x.TlsMinVersion
+	}
+	return ""
+}
+
+func (x *Endpoint) GetTlsCipherSuites() []string {
+	if x != nil {
+		return x.TlsCipherSuites
+	}
+	return nil
+}
+
+func (x *Endpoint) GetStrictProtocol() bool {
+	if x != nil {
+		return x.StrictProtocol
+	}
+	return false
+}
+
+func (x *Endpoint) GetTunnelIdleTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.TunnelIdleTimeout
+	}
+	return nil
+}
+
+func (x *Endpoint) GetSplit() *Split {
+	if x != nil {
+		return x.Split
+	}
+	return nil
+}
+
+func (x *Endpoint) GetFollowRedirects() *FollowRedirects {
+	if x != nil {
+		return x.FollowRedirects
+	}
+	return nil
+}
+
+func (x *Endpoint) GetVia() *Via {
+	if x != nil {
+		return x.Via
+	}
+	return nil
+}
+
+func (x *Endpoint) GetTlsClientCert() string {
+	if x != nil {
+		return x.TlsClientCert
+	}
+	return ""
+}
+
+func (x *Endpoint) GetTlsClientKey() string {
+	if x != nil {
+		return x.TlsClientKey
+	}
+	return ""
+}
+
+func (x *Endpoint) GetSkipClientCancel() bool {
+	if x != nil {
+		return x.SkipClientCancel
+	}
+	return false
+}
+
+func (x *Endpoint) GetFailover() *Failover {
+	if x != nil {
+		return x.Failover
+	}
+	return nil
+}
+
+func (x *Endpoint) GetMiddlewareBypassPaths() []string {
+	if x != nil {
+		return x.MiddlewareBypassPaths
+	}
+	return nil
+}
+
+func (x *Endpoint) GetPassRedirects() bool {
+	if x != nil {
+		return x.PassRedirects
+	}
+	return false
+}
+
+type Via struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// the received-by of the gateway, default is "gateway".
+	Pseudonym string `protobuf:"bytes,2,opt,name=pseudonym,proto3" json:"pseudonym,omitempty"`
+}
+
+func (x *Via) Reset() {
+	*x = Via{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Via) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Via) ProtoMessage() {}
+
+func (x *Via) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Via.ProtoReflect.Descriptor instead.
+func (*Via) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Via) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Via) GetPseudonym() string {
+	if x != nil {
+		return x.Pseudonym
+	}
+	return ""
+}
+
+type FollowRedirects struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the max redirects followed of a request, the last redirect is passed through beyond it, default is 10.
+	MaxRedirects uint32 `protobuf:"varint,1,opt,name=max_redirects,json=maxRedirects,proto3" json:"max_redirects,omitempty"`
+}
+
+func (x *FollowRedirects) Reset() {
+	*x = FollowRedirects{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FollowRedirects) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRedirects) ProtoMessage() {}
+
+func (x *FollowRedirects) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRedirects.ProtoReflect.Descriptor instead.
+func (*FollowRedirects) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FollowRedirects) GetMaxRedirects() uint32 {
+	if x != nil {
+		return x.MaxRedirects
+	}
+	return 0
+}
+
+// Split assigns each request a variant by the weights, the requests of the same hash key, or with the cookie of
+// a variant, are assigned the same variant. the others are assigned randomly.
+type Split struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Variants []*Split_Variant `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	// the request header hashed for the sticky assignment, e.g. X-User-Id.
+	HashHeader string `protobuf:"bytes,2,opt,name=hash_header,json=hashHeader,proto3" json:"hash_header,omitempty"`
+	// the cookie persisting the assigned variant, it's set on the responses of the requests without it, e.g. gateway-variant.
+	// the requests with the cookie of a variant of zero weight are reassigned.
+	Cookie string `protobuf:"bytes,3,opt,name=cookie,proto3" json:"cookie,omitempty"`
+	// the max age of the cookie, default is the session cookie.
+	CookieMaxAge *durationpb.Duration `protobuf:"bytes,4,opt,name=cookie_max_age,json=cookieMaxAge,proto3" json:"cookie_max_age,omitempty"`
+	// the response header of the assigned variant, default is X-Variant.
+	VariantHeader string `protobuf:"bytes,5,opt,name=variant_header,json=variantHeader,proto3" json:"variant_header,omitempty"`
+}
+
+func (x *Split) Reset() {
+	*x = Split{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Split) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Split) ProtoMessage() {}
+
+func (x *Split) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Split.ProtoReflect.Descriptor instead.
+func (*Split) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Split) GetVariants() []*Split_Variant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *Split) GetHashHeader() string {
+	if x != nil {
+		return x.HashHeader
+	}
+	return ""
+}
+
+func (x *Split) GetCookie() string {
+	if x != nil {
+		return x.Cookie
+	}
+	return ""
+}
+
+func (x *Split) GetCookieMaxAge() *durationpb.Duration {
+	if x != nil {
+		return x.CookieMaxAge
+	}
+	return nil
+}
+
+func (x *Split) GetVariantHeader() string {
+	if x != nil {
+		return x.VariantHeader
+	}
+	return ""
+}
+
+// Failover sends each attempt to the first healthy pool from the starting one of the mode, the backends of the
+// endpoint are the primary pool. a pool is unhealthy for the duration once the consecutive attempts failed with
+// errors reach the threshold, the primary is used if all are unhealthy. the pool without available backends,
+// e.g. all of them are tried by the retries, is skipped by the attempt.
+type Failover struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pools []*Failover_Pool `protobuf:"bytes,1,rep,name=pools,proto3" json:"pools,omitempty"`
+	Mode  FailoverMode     `protobuf:"varint,2,opt,name=mode,proto3,enum=gateway.config.v1.FailoverMode" json:"mode,omitempty"`
+	// the name of the primary pool, default is primary.
+	PrimaryName string `protobuf:"bytes,3,opt,name=primary_name,json=primaryName,proto3" json:"primary_name,omitempty"`
+	// default is 3.
+	UnhealthyThreshold uint32 `protobuf:"varint,4,opt,name=unhealthy_threshold,json=unhealthyThreshold,proto3" json:"unhealthy_threshold,omitempty"`
+	// default is 10s.
+	UnhealthyDuration *durationpb.Duration `protobuf:"bytes,5,opt,name=unhealthy_duration,json=unhealthyDuration,proto3" json:"unhealthy_duration,omitempty"`
+}
+
+func (x *Failover) Reset() {
+	*x = Failover{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Failover) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Failover) ProtoMessage() {}
+
+func (x *Failover) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Failover.ProtoReflect.Descriptor instead.
+func (*Failover) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Failover) GetPools() []*Failover_Pool {
+	if x != nil {
+		return x.Pools
+	}
+	return nil
+}
+
+func (x *Failover) GetMode() FailoverMode {
+	if x != nil {
+		return x.Mode
+	}
+	return FailoverMode_UNHEALTHY
+}
+
+func (x *Failover) GetPrimaryName() string {
+	if x != nil {
+		return x.PrimaryName
+	}
+	return ""
+}
+
+func (x *Failover) GetUnhealthyThreshold() uint32 {
+	if x != nil {
+		return x.UnhealthyThreshold
+	}
+	return 0
+}
+
+func (x *Failover) GetUnhealthyDuration() *durationpb.Duration {
+	if x != nil {
+		return x.UnhealthyDuration
+	}
+	return nil
+}
+
+type Balancer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policy BalancerPolicy `protobuf:"varint,1,opt,name=policy,proto3,enum=gateway.config.v1.BalancerPolicy" json:"policy,omitempty"`
+	// the hash key of CONSISTENT_HASH.
+	HashKey *HashKey `protobuf:"bytes,2,opt,name=hash_key,json=hashKey,proto3" json:"hash_key,omitempty"`
+}
+
+func (x *Balancer) Reset() {
+	*x = Balancer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Balancer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balancer) ProtoMessage() {}
+
+func (x *Balancer) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balancer.ProtoReflect.Descriptor instead.
+func (*Balancer) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Balancer) GetPolicy() BalancerPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return BalancerPolicy_P2C
+}
+
+func (x *Balancer) GetHashKey() *HashKey {
+	if x != nil {
+		return x.HashKey
+	}
+	return nil
+}
+
+// HashKey is the concatenation of the request headers in order, and the client IP if enabled,
+// the requests without any of them are balanced randomly.
+type HashKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Headers  []string `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+	ClientIp bool     `protobuf:"varint,2,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+}
+
+func (x *HashKey) Reset() {
+	*x = HashKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HashKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashKey) ProtoMessage() {}
+
+func (x *HashKey) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashKey.ProtoReflect.Descriptor instead.
+func (*HashKey) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *HashKey) GetHeaders() []string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HashKey) GetClientIp() bool {
+	if x != nil {
+		return x.ClientIp
+	}
+	return false
+}
+
+// Aggregate responds a JSON object of the sub responses keyed by their names.
+type Aggregate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Requests []*SubRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// the timeout of all of the sub requests, default is the endpoint timeout.
+	Timeout        *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	PartialFailure PartialFailure       `protobuf:"varint,3,opt,name=partial_failure,json=partialFailure,proto3,enum=gateway.config.v1.PartialFailure" json:"partial_failure,omitempty"`
+}
+
+func (x *Aggregate) Reset() {
+	*x = Aggregate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Aggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregate) ProtoMessage() {}
+
+func (x *Aggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregate.ProtoReflect.Descriptor instead.
+func (*Aggregate) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Aggregate) GetRequests() []*SubRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *Aggregate) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Aggregate) GetPartialFailure() PartialFailure {
+	if x != nil {
+		return x.PartialFailure
+	}
+	return PartialFailure_FAIL_ALL
+}
+
+type SubRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// key of the response in the aggregated object
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// default is the method of the request
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// path of the sub request, the query of the request is passed through. default is the path of the request.
+	Path     string     `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	Backends []*Backend `protobuf:"bytes,4,rep,name=backends,proto3" json:"backends,omitempty"`
+}
+
+func (x *SubRequest) Reset() {
+	*x = SubRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubRequest) ProtoMessage() {}
+
+func (x *SubRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubRequest.ProtoReflect.Descriptor instead.
+func (*SubRequest) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SubRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *SubRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SubRequest) GetBackends() []*Backend {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+type ResponseRate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BytesPerSecond int64 `protobuf:"varint,1,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+	// the rate is measured in each window of the time waiting on the upstream body, excluding the time
+	// blocked in writing to the client, default is 5s
+	Window *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+}
+
+func (x *ResponseRate) Reset() {
+	*x = ResponseRate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseRate) ProtoMessage() {}
+
+func (x *ResponseRate) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseRate.ProtoReflect.Descriptor instead.
+func (*ResponseRate) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResponseRate) GetBytesPerSecond() int64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+func (x *ResponseRate) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+// GRPCRoute matches the gRPC requests of /package.Service/Method.
+type GRPCRoute struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the full name of the service, e.g. helloworld.Greeter
+	Service string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	// all of the methods of the service are matched if not set
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *GRPCRoute) Reset() {
+	*x = GRPCRoute{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GRPCRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCRoute) ProtoMessage() {}
+
+func (x *GRPCRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCRoute.ProtoReflect.Descriptor instead.
+func (*GRPCRoute) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GRPCRoute) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *GRPCRoute) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+// HeaderFilter forwards the headers in the allow list if set, and then removes the ones in the deny list.
+// The hop-by-hop headers are never forwarded.
+type HeaderFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Allow []string `protobuf:"bytes,1,rep,name=allow,proto3" json:"allow,omitempty"`
+	// e.g. ["Server", "X-Powered-By"]
+	Deny []string `protobuf:"bytes,2,rep,name=deny,proto3" json:"deny,omitempty"`
+}
+
+func (x *HeaderFilter) Reset() {
+	*x = HeaderFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeaderFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderFilter) ProtoMessage() {}
+
+func (x *HeaderFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderFilter.ProtoReflect.Descriptor instead.
+func (*HeaderFilter) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HeaderFilter) GetAllow() []string {
+	if x != nil {
+		return x.Allow
+	}
+	return nil
+}
+
+func (x *HeaderFilter) GetDeny() []string {
+	if x != nil {
+		return x.Deny
+	}
+	return nil
+}
+
+// UserAgent rewrites the User-Agent header to the backends.
+type UserAgent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Policy:
+	//	*UserAgent_Set
+	//	*UserAgent_Suffix
+	Policy isUserAgent_Policy `protobuf_oneof:"policy"`
+}
+
+func (x *UserAgent) Reset() {
+	*x = UserAgent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserAgent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAgent) ProtoMessage() {}
+
+func (x *UserAgent) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserAgent.ProtoReflect.Descriptor instead.
+func (*UserAgent) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{18}
+}
+
+func (m *UserAgent) GetPolicy() isUserAgent_Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (x *UserAgent) GetSet() string {
+	if x, ok := x.GetPolicy().(*UserAgent_Set); ok {
+		return x.Set
+	}
+	return ""
+}
+
+func (x *UserAgent) GetSuffix() string {
+	if x, ok := x.GetPolicy().(*UserAgent_Suffix); ok {
+		return x.Suffix
+	}
+	return ""
+}
+
+type isUserAgent_Policy interface {
+	isUserAgent_Policy()
+}
+
+type UserAgent_Set struct {
+	// replace with the fixed value
+	Set string `protobuf:"bytes,1,opt,name=set,proto3,oneof"`
+}
+
+type UserAgent_Suffix struct {
+	// append to the client one, e.g. "gateway/1.0"
+	Suffix string `protobuf:"bytes,2,opt,name=suffix,proto3,oneof"`
+}
+
+func (*UserAgent_Set) isUserAgent_Policy() {}
+
+func (*UserAgent_Suffix) isUserAgent_Policy() {}
+
+// Dialer tunes the TCP connections to the backends.
+type Dialer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// default is PROXY_DIAL_TIMEOUT or 200ms
+	Timeout *durationpb.Duration `protobuf:"bytes,1,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	// TCP keepalive period, default is 30s, negative disables it, otherwise it must be at least 1s.
+	Keepalive *durationpb.Duration `protobuf:"bytes,2,opt,name=keepalive,proto3" json:"keepalive,omitempty"`
+	// default is true as Go does
+	TcpNodelay *bool `protobuf:"varint,3,opt,name=tcp_nodelay,json=tcpNodelay,proto3,oneof" json:"tcp_nodelay,omitempty"`
+}
+
+func (x *Dialer) Reset() {
+	*x = Dialer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dialer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dialer) ProtoMessage() {}
+
+func (x *Dialer) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dialer.ProtoReflect.Descriptor instead.
+func (*Dialer) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Dialer) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *Dialer) GetKeepalive() *durationpb.Duration {
+	if x != nil {
+		return x.Keepalive
+	}
+	return nil
+}
+
+func (x *Dialer) GetTcpNodelay() bool {
+	if x != nil && x.TcpNodelay != nil {
+		return *x.TcpNodelay
+	}
+	return false
+}
+
+// Keepalive detects the dead HTTP/2 connections by the pings.
+type Keepalive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the ping is sent after the connection is idle for reading for the interval, at least 1s,
+	// the connections without the streams are pinged as well.
+	Time *durationpb.Duration `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// the connection is closed if the ping is not acked within the timeout, default is 15s.
+	Timeout *durationpb.Duration `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *Keepalive) Reset() {
+	*x = Keepalive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Keepalive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Keepalive) ProtoMessage() {}
+
+func (x *Keepalive) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Keepalive.ProtoReflect.Descriptor instead.
+func (*Keepalive) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Keepalive) GetTime() *durationpb.Duration {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Keepalive) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// Alias is an extra route of the endpoint.
+type Alias struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// default is the method of the endpoint.
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+}
+
+func (x *Alias) Reset() {
+	*x = Alias{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Alias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Alias) ProtoMessage() {}
+
+func (x *Alias) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Alias.ProtoReflect.Descriptor instead.
+func (*Alias) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Alias) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Alias) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+// Static serves files from the local directory.
+type Static struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// root directory of the files
+	Root string `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	// index file of the directories, default is index.html
+	Index            string `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	DirectoryListing bool   `protobuf:"varint,3,opt,name=directory_listing,json=directoryListing,proto3" json:"directory_listing,omitempty"`
+	// Cache-Control header of the responses
+	CacheControl string `protobuf:"bytes,4,opt,name=cache_control,json=cacheControl,proto3" json:"cache_control,omitempty"`
+	// prefix stripped from the request path, default is the prefix of the endpoint path
+	StripPrefix *string `protobuf:"bytes,5,opt,name=strip_prefix,json=stripPrefix,proto3,oneof" json:"strip_prefix,omitempty"`
+	// file served if not found, e.g. index.html for single page applications
+	Fallback string `protobuf:"bytes,6,opt,name=fallback,proto3" json:"fallback,omitempty"`
+}
+
+func (x *Static) Reset() {
+	*x = Static{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Static) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Static) ProtoMessage() {}
+
+func (x *Static) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Static.ProtoReflect.Descriptor instead.
+func (*Static) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Static) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *Static) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *Static) GetDirectoryListing() bool {
+	if x != nil {
+		return x.DirectoryListing
+	}
+	return false
+}
+
+func (x *Static) GetCacheControl() string {
+	if x != nil {
+		return x.CacheControl
+	}
+	return ""
+}
+
+func (x *Static) GetStripPrefix() string {
+	if x != nil && x.StripPrefix != nil {
+		return *x.StripPrefix
+	}
+	return ""
+}
+
+func (x *Static) GetFallback() string {
+	if x != nil {
+		return x.Fallback
+	}
+	return ""
+}
+
+// Query matches a query parameter of the request.
+type Query struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// matches any value if not set, supports {name:pattern} for regexp.
+	Value *string `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+}
+
+func (x *Query) Reset() {
+	*x = Query{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Query) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Query) ProtoMessage() {}
+
+func (x *Query) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Query.ProtoReflect.Descriptor instead.
+func (*Query) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Query) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Query) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+// UpstreamOverride pins a request to the upstream specified by the header,
+// it is used for debugging.
+type UpstreamOverride struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// default is X-Upstream-Override
+	Header string `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// only requests from the trusted CIDRs are allowed to override
+	TrustedCidrs []string `protobuf:"bytes,3,rep,name=trusted_cidrs,json=trustedCidrs,proto3" json:"trusted_cidrs,omitempty"`
+}
+
+func (x *UpstreamOverride) Reset() {
+	*x = UpstreamOverride{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpstreamOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpstreamOverride) ProtoMessage() {}
+
+func (x *UpstreamOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpstreamOverride.ProtoReflect.Descriptor instead.
+func (*UpstreamOverride) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpstreamOverride) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *UpstreamOverride) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *UpstreamOverride) GetTrustedCidrs() []string {
+	if x != nil {
+		return x.TrustedCidrs
+	}
+	return nil
+}
+
+type ClientDeadline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// the unix milliseconds of the deadline, default is X-Deadline
+	Header string `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// only the deadlines of the requests from the trusted CIDRs are honored,
+	// all are trusted if not specified since the deadline only shortens the timeout.
+	TrustedCidrs []string `protobuf:"bytes,3,rep,name=trusted_cidrs,json=trustedCidrs,proto3" json:"trusted_cidrs,omitempty"`
+}
+
+func (x *ClientDeadline) Reset() {
+	*x = ClientDeadline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClientDeadline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientDeadline) ProtoMessage() {}
+
+func (x *ClientDeadline) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientDeadline.ProtoReflect.Descriptor instead.
+func (*ClientDeadline) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ClientDeadline) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ClientDeadline) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *ClientDeadline) GetTrustedCidrs() []string {
+	if x != nil {
+		return x.TrustedCidrs
+	}
+	return nil
+}
+
+type Middleware struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Options *anypb.Any `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	// run the middleware only for the matched requests, the others skip it, default is all.
+	When *MiddlewareMatch `protobuf:"bytes,3,opt,name=when,proto3" json:"when,omitempty"`
+}
+
+func (x *Middleware) Reset() {
+	*x = Middleware{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Middleware) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Middleware) ProtoMessage() {}
+
+func (x *Middleware) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Middleware.ProtoReflect.Descriptor instead.
+func (*Middleware) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Middleware) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Middleware) GetOptions() *anypb.Any {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Middleware) GetWhen() *MiddlewareMatch {
+	if x != nil {
+		return x.When
+	}
+	return nil
+}
+
+// MiddlewareMatch matches a request when all of the configured matchers are matched.
+type MiddlewareMatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// regexp of the request path
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// the request methods, any of them matched
+	Methods []string `protobuf:"bytes,2,rep,name=methods,proto3" json:"methods,omitempty"`
+	// the request headers present, all of them matched
+	Headers []string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	// negate the match, e.g. the paths other than the public ones
+	Invert bool `protobuf:"varint,4,opt,name=invert,proto3" json:"invert,omitempty"`
+}
+
+func (x *MiddlewareMatch) Reset() {
+	*x = MiddlewareMatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MiddlewareMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MiddlewareMatch) ProtoMessage() {}
+
+func (x *MiddlewareMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MiddlewareMatch.ProtoReflect.Descriptor instead.
+func (*MiddlewareMatch) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MiddlewareMatch) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *MiddlewareMatch) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *MiddlewareMatch) GetHeaders() []string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *MiddlewareMatch) GetInvert() bool {
+	if x != nil {
+		return x.Invert
+	}
+	return false
+}
+
+type Backend struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// localhost
+	// 127.0.0.1:8000
+	// discovery:///service_name
+	Target      string       `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Weight      *int64       `protobuf:"varint,2,opt,name=weight,proto3,oneof" json:"weight,omitempty"`
+	HealthCheck *HealthCheck `protobuf:"bytes,3,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+}
+
+func (x *Backend) Reset() {
+	*x = Backend{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Backend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backend) ProtoMessage() {}
+
+func (x *Backend) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backend.ProtoReflect.Descriptor instead.
+func (*Backend) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Backend) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Backend) GetWeight() int64 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+func (x *Backend) GetHealthCheck() *HealthCheck {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return nil
+}
+
+type HealthCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheck) ProtoMessage() {}
+
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{29}
+}
+
+type Retry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// default attempts is 1. the native gRPC request bodies are streamed to the upstream unless the endpoint retries,
+	// the retries buffer them to replay, so the client streaming RPCs are held until the client ends the stream.
+	// the gRPC requests are POST, so they need an Idempotency-Key header or retry_non_idempotent.
+	Attempts      uint32               `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	PerTryTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=per_try_timeout,json=perTryTimeout,proto3" json:"per_try_timeout,omitempty"`
+	Conditions    []*Condition         `protobuf:"bytes,3,rep,name=conditions,proto3" json:"conditions,omitempty"`
+	// primary,secondary
+	Priorities []string `protobuf:"bytes,4,rep,name=priorities,proto3" json:"priorities,omitempty"`
+	// the retries are not delayed if not set.
+	Backoff *Backoff `protobuf:"bytes,5,opt,name=backoff,proto3" json:"backoff,omitempty"`
+	// retry the non-idempotent methods, e.g. POST and PATCH,
+	// they are retried only with an Idempotency-Key header by default.
+	RetryNonIdempotent bool `protobuf:"varint,6,opt,name=retry_non_idempotent,json=retryNonIdempotent,proto3" json:"retry_non_idempotent,omitempty"`
+	// the status code responded instead of the last response if all the attempts match the conditions,
+	// e.g. 504, the last response is forwarded by default.
+	ExhaustedStatusCode uint32 `protobuf:"varint,7,opt,name=exhausted_status_code,json=exhaustedStatusCode,proto3" json:"exhausted_status_code,omitempty"`
+	// the response header of the attempts if all the attempts match the conditions, e.g. X-Retries-Exhausted.
+	ExhaustedHeader string `protobuf:"bytes,8,opt,name=exhausted_header,json=exhaustedHeader,proto3" json:"exhausted_header,omitempty"`
+	// the Retry-After hint of the gateway-originated 502 and 504, it's not set by default.
+	RetryAfter *RetryAfter `protobuf:"bytes,9,opt,name=retry_after,json=retryAfter,proto3" json:"retry_after,omitempty"`
+}
+
+func (x *Retry) Reset() {
+	*x = Retry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Retry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Retry) ProtoMessage() {}
+
+func (x *Retry) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Retry.ProtoReflect.Descriptor instead.
+func (*Retry) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Retry) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Retry) GetPerTryTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.PerTryTimeout
+	}
+	return nil
+}
+
+func (x *Retry) GetConditions() []*Condition {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+func (x *Retry) GetPriorities() []string {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *Retry) GetBackoff() *Backoff {
+	if x != nil {
+		return x.Backoff
+	}
+	return nil
+}
+
+func (x *Retry) GetRetryNonIdempotent() bool {
+	if x != nil {
+		return x.RetryNonIdempotent
+	}
+	return false
+}
+
+func (x *Retry) GetExhaustedStatusCode() uint32 {
+	if x != nil {
+		return x.ExhaustedStatusCode
+	}
+	return 0
+}
+
+func (x *Retry) GetExhaustedHeader() string {
+	if x != nil {
+		return x.ExhaustedHeader
+	}
+	return ""
+}
+
+func (x *Retry) GetRetryAfter() *RetryAfter {
+	if x != nil {
+		return x.RetryAfter
+	}
+	return nil
+}
+
+type RetryAfter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the fixed delay, it's rounded up to seconds.
+	Fixed *durationpb.Duration `protobuf:"bytes,1,opt,name=fixed,proto3" json:"fixed,omitempty"`
+	// derive the delay from the backoff state of the request, the fixed one is the fallback
+	// if the backoff is not configured.
+	Backoff bool `protobuf:"varint,2,opt,name=backoff,proto3" json:"backoff,omitempty"`
+}
+
+func (x *RetryAfter) Reset() {
+	*x = RetryAfter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RetryAfter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryAfter) ProtoMessage() {}
+
+func (x *RetryAfter) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryAfter.ProtoReflect.Descriptor instead.
+func (*RetryAfter) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RetryAfter) GetFixed() *durationpb.Duration {
+	if x != nil {
+		return x.Fixed
+	}
+	return nil
+}
+
+func (x *RetryAfter) GetBackoff() bool {
+	if x != nil {
+		return x.Backoff
+	}
+	return false
+}
+
+type Backoff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policy BackoffPolicy `protobuf:"varint,1,opt,name=policy,proto3,enum=gateway.config.v1.BackoffPolicy" json:"policy,omitempty"`
+	// default is 25ms
+	Base *durationpb.Duration `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+	// the cap of the delays, default is 1s.
+	Max *durationpb.Duration `protobuf:"bytes,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *Backoff) Reset() {
+	*x = Backoff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Backoff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backoff) ProtoMessage() {}
+
+func (x *Backoff) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backoff.ProtoReflect.Descriptor instead.
+func (*Backoff) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Backoff) GetPolicy() BackoffPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return BackoffPolicy_EXPONENTIAL
+}
+
+func (x *Backoff) GetBase() *durationpb.Duration {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *Backoff) GetMax() *durationpb.Duration {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
+type Condition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Condition:
+	//	*Condition_ByStatusCode
+	//	*Condition_ByHeader
+	//	*Condition_ByEmptyBody
+	Condition isCondition_Condition `protobuf_oneof:"condition"`
+}
+
+func (x *Condition) Reset() {
+	*x = Condition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Condition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Condition) ProtoMessage() {}
+
+func (x *Condition) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Condition.ProtoReflect.Descriptor instead.
+func (*Condition) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{33}
+}
+
+func (m *Condition) GetCondition() isCondition_Condition {
+	if m != nil {
+		return m.Condition
+	}
+	return nil
+}
+
+func (x *Condition) GetByStatusCode() string {
+	if x, ok := x.GetCondition().(*Condition_ByStatusCode); ok {
+		return x.ByStatusCode
+	}
+	return ""
+}
+
+func (x *Condition) GetByHeader() *ConditionHeader {
+	if x, ok := x.GetCondition().(*Condition_ByHeader); ok {
+		return x.ByHeader
+	}
+	return nil
+}
+
+func (x *Condition) GetByEmptyBody() bool {
+	if x, ok := x.GetCondition().(*Condition_ByEmptyBody); ok {
+		return x.ByEmptyBody
+	}
+	return false
+}
+
+type isCondition_Condition interface {
+	isCondition_Condition()
+}
+
+type Condition_ByStatusCode struct {
+	// "500-599", "429"
+	ByStatusCode string `protobuf:"bytes,1,opt,name=by_status_code,json=byStatusCode,proto3,oneof"`
+}
+
+type Condition_ByHeader struct {
+	// {"name": "grpc-status", "value": "14"}
+	ByHeader *ConditionHeader `protobuf:"bytes,2,opt,name=by_header,json=byHeader,proto3,oneof"`
+}
+
+type Condition_ByEmptyBody struct {
+	// the responses without body other than of HEAD, 1xx, 204 and 304, e.g. the truncated ones
+	ByEmptyBody bool `protobuf:"varint,3,opt,name=by_empty_body,json=byEmptyBody,proto3,oneof"`
+}
+
+func (*Condition_ByStatusCode) isCondition_Condition() {}
+
+func (*Condition_ByHeader) isCondition_Condition() {}
+
+func (*Condition_ByEmptyBody) isCondition_Condition() {}
+
+type Split_Variant struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the name of the variant in the cookie, the response header and the metrics, e.g. "control".
+	Name     string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Weight   uint32     `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Backends []*Backend `protobuf:"bytes,3,rep,name=backends,proto3" json:"backends,omitempty"`
+}
+
+func (x *Split_Variant) Reset() {
+	*x = Split_Variant{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Split_Variant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Split_Variant) ProtoMessage() {}
+
+func (x *Split_Variant) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Split_Variant.ProtoReflect.Descriptor instead.
+func (*Split_Variant) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *Split_Variant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Split_Variant) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Split_Variant) GetBackends() []*Backend {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+type Failover_Pool struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the name of the pool in the metrics, e.g. "us-west".
+	Name     string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Backends []*Backend `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty"`
+}
+
+func (x *Failover_Pool) Reset() {
+	*x = Failover_Pool{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Failover_Pool) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Failover_Pool) ProtoMessage() {}
+
+func (x *Failover_Pool) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Failover_Pool.ProtoReflect.Descriptor instead.
+func (*Failover_Pool) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *Failover_Pool) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Failover_Pool) GetBackends() []*Backend {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+type ConditionHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// regular expression matched against the header values, e.g. "^(?i)true$"
+	Pattern string `protobuf:"bytes,3,opt,name=pattern,proto3" json:"pattern,omitempty"`
+}
+
+func (x *ConditionHeader) Reset() {
+	*x = ConditionHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_config_v1_gateway_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConditionHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionHeader) ProtoMessage() {}
+
+func (x *ConditionHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_config_v1_gateway_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionHeader.ProtoReflect.Descriptor instead.
+func (*ConditionHeader) Descriptor() ([]byte, []int) {
+	return file_gateway_config_v1_gateway_proto_rawDescGZIP(), []int{33, 0}
+}
+
+func (x *ConditionHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConditionHeader) GetValue() string {
 	if x != nil {
 		return x.Value
 	}
 	return ""
 }
 
+func (x *ConditionHeader) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
 var File_gateway_config_v1_gateway_proto protoreflect.FileDescriptor
 
 var file_gateway_config_v1_gateway_proto_rawDesc = []byte{
@@ -640,7 +3573,7 @@ var file_gateway_config_v1_gateway_proto_rawDesc = []byte{
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
 	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0xc9, 0x01, 0x0a, 0x07, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0xd4, 0x09, 0x0a, 0x07, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e,
 	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
 	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f, 0x73,
@@ -652,85 +3585,559 @@ var file_gateway_config_v1_gateway_proto_rawDesc = []byte{
 	0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x1d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
 	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x52, 0x0b,
-	0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x73, 0x22, 0xf3, 0x03, 0x0a, 0x08,
-	0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
-	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65,
-	0x74, 0x68, 0x6f, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
-	0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
-	0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x73, 0x12, 0x47, 0x0a, 0x0e, 0x74,
+	0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67,
+	0x53, 0x6c, 0x61, 0x73, 0x68, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x53,
+	0x6c, 0x61, 0x73, 0x68, 0x12, 0x37, 0x0a, 0x08, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x61, 0x6e, 0x69, 0x74,
+	0x69, 0x7a, 0x65, 0x52, 0x08, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x12, 0x2d, 0x0a,
+	0x12, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x6d, 0x69, 0x64, 0x64, 0x6c,
+	0x65, 0x77, 0x61, 0x72, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x34, 0x0a, 0x16,
+	0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x72,
+	0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x6d, 0x61,
+	0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x74, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x64, 0x5f, 0x61, 0x73, 0x5f, 0x67, 0x65,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x65, 0x61, 0x64, 0x41, 0x73, 0x47,
+	0x65, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x6d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x4d, 0x0a, 0x10, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61,
+	0x78, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12,
+	0x46, 0x0a, 0x10, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e,
+	0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x45,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x70, 0x61, 0x74, 0x68, 0x5f,
+	0x63, 0x61, 0x73, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x74, 0x68, 0x43, 0x61, 0x73, 0x65, 0x52, 0x08, 0x70, 0x61, 0x74, 0x68, 0x43, 0x61, 0x73,
+	0x65, 0x12, 0x49, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x40, 0x0a, 0x0b,
+	0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x52, 0x0b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x47,
+	0x0a, 0x0e, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x52, 0x0d, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x5a, 0x0a, 0x10, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2f, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x5f, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x72, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f,
+	0x75, 0x72, 0x6c, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0c, 0x6d, 0x61, 0x78, 0x55, 0x72, 0x6c, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x1a, 0x66,
+	0x0a, 0x14, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x38, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x76, 0x0a, 0x0f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x2e,
+	0x0a, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x22, 0x8b,
+	0x01, 0x0a, 0x0d, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x63, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x23,
+	0x0a, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x69, 0x64, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x43, 0x69,
+	0x64, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x22, 0xe1, 0x01, 0x0a,
+	0x0b, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x45, 0x0a, 0x07, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x65, 0x6d, 0x70, 0x74, 0x50,
+	0x61, 0x74, 0x68, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x3b, 0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x22, 0x74, 0x0a,
+	0x08, 0x53, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x70, 0x61, 0x74, 0x68, 0x5f, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61, 0x74, 0x68, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x73, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x22, 0x9a, 0x15, 0x0a, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1b, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x52, 0x08, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x0b,
+	0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65,
+	0x52, 0x0b, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x73, 0x12, 0x36, 0x0a,
+	0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x08, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x05,
+	0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x45, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x50, 0x0a, 0x11,
+	0x75, 0x70, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x52, 0x10, 0x75, 0x70,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x36,
+	0x0a, 0x17, 0x65, 0x63, 0x68, 0x6f, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x5f,
+	0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x15, 0x65, 0x63, 0x68, 0x6f, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x6d,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x48, 0x0a, 0x0f, 0x78, 0x5f, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x66, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x20, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x58, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x46, 0x6f,
+	0x72, 0x52, 0x0d, 0x78, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x46, 0x6f, 0x72,
+	0x12, 0x32, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71, 0x75, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x52,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x12, 0x2d, 0x0a, 0x12, 0x77, 0x61, 0x72, 0x6d, 0x75,
+	0x70, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x11, 0x77, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65,
+	0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x69, 0x61,
+	0x73, 0x52, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x64, 0x69,
+	0x61, 0x6c, 0x65, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x61, 0x6c, 0x65, 0x72, 0x52, 0x06, 0x64, 0x69, 0x61, 0x6c, 0x65, 0x72, 0x12, 0x3b, 0x0a,
+	0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x52,
+	0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x4a, 0x0a, 0x10, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x67, 0x72, 0x70, 0x63, 0x5f, 0x68,
+	0x74, 0x74, 0x70, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x67, 0x72, 0x70, 0x63, 0x48, 0x74, 0x74, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x2e, 0x0a, 0x13, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x62,
+	0x6f, 0x64, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x30, 0x0a, 0x04, 0x67, 0x72, 0x70, 0x63, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x52, 0x50, 0x43, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x04, 0x67, 0x72,
+	0x70, 0x63, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x74, 0x69, 0x6d,
+	0x69, 0x6e, 0x67, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x6c, 0x73,
+	0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x19, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x74, 0x6c, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x32, 0x0a, 0x15, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x65, 0x6d, 0x70, 0x74,
+	0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x13, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x11, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x61, 0x74,
+	0x65, 0x52, 0x0f, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x61,
+	0x74, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x64, 0x65, 0x66, 0x65, 0x72, 0x5f, 0x65, 0x78, 0x70, 0x65,
+	0x63, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x13, 0x64, 0x65, 0x66, 0x65, 0x72, 0x45, 0x78, 0x70, 0x65, 0x63, 0x74, 0x43, 0x6f,
+	0x6e, 0x74, 0x69, 0x6e, 0x75, 0x65, 0x12, 0x4c, 0x0a, 0x13, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x6e, 0x18, 0x1d, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x11, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4f, 0x6e, 0x12, 0x3a, 0x0a, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x52, 0x09, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x5f, 0x75, 0x72, 0x69,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x55, 0x72, 0x69, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x3a, 0x0a, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x20, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76,
+	0x65, 0x52, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x41, 0x0a, 0x0c,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x21, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x4a, 0x0a, 0x0f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69,
+	0x6e, 0x65, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x0e, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x37, 0x0a, 0x08, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x52, 0x08, 0x62, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x12, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x24, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x19, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x25, 0x20, 0x01, 0x28, 0x03, 0x52, 0x16, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x26, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6c, 0x73, 0x4d, 0x69, 0x6e, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x69,
+	0x70, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x69, 0x74, 0x65, 0x73, 0x18, 0x27, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0f, 0x74, 0x6c, 0x73, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x53, 0x75, 0x69, 0x74,
+	0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x5f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x28, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x74, 0x72,
+	0x69, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x49, 0x0a, 0x13, 0x74,
+	0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x11, 0x74, 0x75, 0x6e, 0x6e, 0x65, 0x6c, 0x49, 0x64, 0x6c, 0x65, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x18,
+	0x2a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52,
+	0x05, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x12, 0x4d, 0x0a, 0x10, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
+	0x5f, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x73, 0x52, 0x0f, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x03, 0x76, 0x69, 0x61, 0x18, 0x2c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x61, 0x52, 0x03, 0x76, 0x69, 0x61, 0x12,
+	0x26, 0x0a, 0x0f, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x65,
+	0x72, 0x74, 0x18, 0x2d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x6c, 0x73, 0x43, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x6c, 0x73, 0x5f, 0x63,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x2e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x74, 0x6c, 0x73, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x2c, 0x0a,
+	0x12, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x18, 0x2f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x43,
+	0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x37, 0x0a, 0x08, 0x66,
+	0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x30, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c,
+	0x6f, 0x76, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x17, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61,
+	0x72, 0x65, 0x5f, 0x62, 0x79, 0x70, 0x61, 0x73, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18,
+	0x31, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
+	0x65, 0x42, 0x79, 0x70, 0x61, 0x73, 0x73, 0x50, 0x61, 0x74, 0x68, 0x73, 0x12, 0x25, 0x0a, 0x0e,
+	0x70, 0x61, 0x73, 0x73, 0x5f, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x32,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x70, 0x61, 0x73, 0x73, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x3d, 0x0a, 0x03, 0x56, 0x69, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e, 0x79, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x73, 0x65, 0x75, 0x64, 0x6f, 0x6e, 0x79, 0x6d, 0x22,
+	0x36, 0x0a, 0x0f, 0x46, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x52, 0x65,
+	0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x22, 0xd5, 0x02, 0x0a, 0x05, 0x53, 0x70, 0x6c, 0x69,
+	0x74, 0x12, 0x3c, 0x0a, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x2e, 0x56, 0x61,
+	0x72, 0x69, 0x61, 0x6e, 0x74, 0x52, 0x08, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x73, 0x12,
+	0x1f, 0x0a, 0x0b, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x61, 0x73, 0x68, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x6f, 0x6f, 0x6b, 0x69, 0x65, 0x12, 0x3f, 0x0a, 0x0e, 0x63, 0x6f, 0x6f, 0x6b,
+	0x69, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x12, 0x3f, 0x0a, 0x0b, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61,
-	0x72, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61, 0x74, 0x65,
-	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69,
-	0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x52, 0x0b, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
-	0x77, 0x61, 0x72, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64,
-	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
-	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b,
-	0x65, 0x6e, 0x64, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a,
-	0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67,
-	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31,
-	0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x05, 0x72, 0x65, 0x74, 0x72, 0x79, 0x12, 0x45, 0x0a,
-	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x29, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x2e, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0x50, 0x0a, 0x0a, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x12,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x63, 0x6f, 0x6f,
+	0x6b, 0x69, 0x65, 0x4d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x76, 0x61, 0x72,
+	0x69, 0x61, 0x6e, 0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x1a, 0x6d, 0x0a, 0x07, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x22,
+	0xe9, 0x02, 0x0a, 0x08, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x05,
+	0x70, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x70,
+	0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x33, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x4d,
+	0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69,
+	0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x13,
+	0x75, 0x6e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68,
+	0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x12, 0x75, 0x6e, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x48, 0x0a,
+	0x12, 0x75, 0x6e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x75, 0x6e, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x52, 0x0a, 0x04, 0x50, 0x6f, 0x6f, 0x6c, 0x12,
 	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x22, 0x8c, 0x01, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x63,
-	0x68, 0x65, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48,
-	0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x77, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x22, 0x0d, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x22, 0xc4, 0x01, 0x0a, 0x05, 0x52, 0x65, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x61,
-	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x61,
-	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x41, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x5f, 0x74,
-	0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x70, 0x65, 0x72,
-	0x54, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
-	0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
-	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6f,
-	0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72,
-	0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x79, 0x5f, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x0c, 0x62, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x42,
-	0x0a, 0x09, 0x62, 0x79, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x23, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x08, 0x62, 0x79, 0x48, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x1a, 0x32, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x2a, 0x2f, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
-	0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
-	0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x47, 0x52,
-	0x50, 0x43, 0x10, 0x02, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74,
-	0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
-	0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e,
+	0x64, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x22, 0x7c, 0x0a, 0x08, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x35, 0x0a, 0x08, 0x68, 0x61, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x4b, 0x65, 0x79,
+	0x52, 0x07, 0x68, 0x61, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x22, 0x40, 0x0a, 0x07, 0x48, 0x61, 0x73,
+	0x68, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x70, 0x22, 0xc7, 0x01, 0x0a, 0x09,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x75, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x4a, 0x0a, 0x0f, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x21, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x0e, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x22, 0x84, 0x01, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x36, 0x0a, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x65,
+	0x6e, 0x64, 0x52, 0x08, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x73, 0x22, 0x6b, 0x0a, 0x0c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x28, 0x0a, 0x10,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0x3d, 0x0a, 0x09, 0x47, 0x52, 0x50,
+	0x43, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x38, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x65, 0x6e, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x65,
+	0x6e, 0x79, 0x22, 0x43, 0x0a, 0x09, 0x55, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12,
+	0x12, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03,
+	0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x75, 0x66, 0x66, 0x69, 0x78, 0x42, 0x08, 0x0a,
+	0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0xac, 0x01, 0x0a, 0x06, 0x44, 0x69, 0x61, 0x6c,
+	0x65, 0x72, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61,
+	0x6c, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x6b, 0x65, 0x65, 0x70, 0x61, 0x6c, 0x69, 0x76, 0x65,
+	0x12, 0x24, 0x0a, 0x0b, 0x74, 0x63, 0x70, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0a, 0x74, 0x63, 0x70, 0x4e, 0x6f, 0x64, 0x65,
+	0x6c, 0x61, 0x79, 0x88, 0x01, 0x01, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x74, 0x63, 0x70, 0x5f, 0x6e,
+	0x6f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x6f, 0x0a, 0x09, 0x4b, 0x65, 0x65, 0x70, 0x61, 0x6c,
+	0x69, 0x76, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x33, 0x0a, 0x05, 0x41, 0x6c, 0x69, 0x61, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0xd9, 0x01, 0x0a,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x5f, 0x6c,
+	0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x63, 0x68, 0x65, 0x43, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x12, 0x26, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x70, 0x5f, 0x70, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x74, 0x72,
+	0x69, 0x70, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x66,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x73, 0x74, 0x72, 0x69,
+	0x70, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x40, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01,
+	0x42, 0x08, 0x0a, 0x06, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x69, 0x0a, 0x10, 0x55, 0x70,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x69, 0x64, 0x72,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64,
+	0x43, 0x69, 0x64, 0x72, 0x73, 0x22, 0x67, 0x0a, 0x0e, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x44,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x72, 0x75,
+	0x73, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x69, 0x64, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0c, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x43, 0x69, 0x64, 0x72, 0x73, 0x22, 0x88,
+	0x01, 0x0a, 0x0a, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x36, 0x0a, 0x04, 0x77, 0x68, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x22, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x04, 0x77, 0x68, 0x65, 0x6e, 0x22, 0x71, 0x0a, 0x0f, 0x4d, 0x69, 0x64,
+	0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x22, 0x8c, 0x01, 0x0a,
+	0x07, 0x42, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x1b, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x48, 0x00, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a,
+	0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x42, 0x09, 0x0a, 0x07, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x0d, 0x0a, 0x0b, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0xcb, 0x03, 0x0a, 0x05, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73,
+	0x12, 0x41, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x70, 0x65, 0x72, 0x54, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x12, 0x34, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x52, 0x07,
+	0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x74, 0x72, 0x79,
+	0x5f, 0x6e, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x72, 0x65, 0x74, 0x72, 0x79, 0x4e, 0x6f, 0x6e, 0x49,
+	0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x65, 0x78, 0x68,
+	0x61, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x65, 0x78, 0x68, 0x61, 0x75, 0x73,
+	0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x29, 0x0a,
+	0x10, 0x65, 0x78, 0x68, 0x61, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x68, 0x61, 0x75, 0x73, 0x74,
+	0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x3e, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x72,
+	0x79, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x52, 0x0a, 0x72, 0x65,
+	0x74, 0x72, 0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x57, 0x0a, 0x0a, 0x52, 0x65, 0x74, 0x72,
+	0x79, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x2f, 0x0a, 0x05, 0x66, 0x69, 0x78, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x05, 0x66, 0x69, 0x78, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f,
+	0x66, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66,
+	0x66, 0x22, 0x9f, 0x01, 0x0a, 0x07, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x12, 0x38, 0x0a,
+	0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52,
+	0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2d, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03,
+	0x6d, 0x61, 0x78, 0x22, 0xf8, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x79, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x79, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x62, 0x79, 0x5f,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x48, 0x00, 0x52, 0x08, 0x62, 0x79, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x24, 0x0a,
+	0x0d, 0x62, 0x79, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0b, 0x62, 0x79, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42,
+	0x6f, 0x64, 0x79, 0x1a, 0x4c, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x42, 0x0b, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x34,
+	0x0a, 0x0d, 0x54, 0x72, 0x61, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x12,
+	0x0c, 0x0a, 0x08, 0x52, 0x45, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x54, 0x52, 0x49, 0x43, 0x54, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x45, 0x52,
+	0x47, 0x45, 0x10, 0x02, 0x2a, 0x39, 0x0a, 0x08, 0x50, 0x61, 0x74, 0x68, 0x43, 0x61, 0x73, 0x65,
+	0x12, 0x0d, 0x0a, 0x09, 0x53, 0x45, 0x4e, 0x53, 0x49, 0x54, 0x49, 0x56, 0x45, 0x10, 0x00, 0x12,
+	0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x53, 0x45, 0x4e, 0x53, 0x49, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01,
+	0x12, 0x0d, 0x0a, 0x09, 0x43, 0x41, 0x4e, 0x4f, 0x4e, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x02, 0x2a,
+	0x2e, 0x0a, 0x0c, 0x46, 0x61, 0x69, 0x6c, 0x6f, 0x76, 0x65, 0x72, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x0d, 0x0a, 0x09, 0x55, 0x4e, 0x48, 0x45, 0x41, 0x4c, 0x54, 0x48, 0x59, 0x10, 0x00, 0x12, 0x0f,
+	0x0a, 0x0b, 0x50, 0x45, 0x52, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x4d, 0x50, 0x54, 0x10, 0x01, 0x2a,
+	0x2e, 0x0a, 0x0e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x32, 0x43, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x4f,
+	0x4e, 0x53, 0x49, 0x53, 0x54, 0x45, 0x4e, 0x54, 0x5f, 0x48, 0x41, 0x53, 0x48, 0x10, 0x01, 0x2a,
+	0x2f, 0x0a, 0x0b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x08,
+	0x0a, 0x04, 0x46, 0x55, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09, 0x41, 0x47, 0x47, 0x52,
+	0x45, 0x47, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x46, 0x46, 0x10, 0x02,
+	0x2a, 0x32, 0x0a, 0x0e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x41, 0x49, 0x4c, 0x5f, 0x41, 0x4c, 0x4c, 0x10, 0x00,
+	0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x5f, 0x45, 0x52, 0x52, 0x4f,
+	0x52, 0x53, 0x10, 0x01, 0x2a, 0x3f, 0x0a, 0x0d, 0x58, 0x46, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64,
+	0x65, 0x64, 0x46, 0x6f, 0x72, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x50, 0x50, 0x45, 0x4e, 0x44, 0x10,
+	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x4f, 0x56, 0x45, 0x52, 0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x43, 0x4c, 0x45, 0x41, 0x52, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x4f,
+	0x4d, 0x49, 0x54, 0x10, 0x03, 0x2a, 0x2f, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
+	0x6c, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x54, 0x54, 0x50, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04,
+	0x47, 0x52, 0x50, 0x43, 0x10, 0x02, 0x2a, 0x39, 0x0a, 0x0d, 0x42, 0x61, 0x63, 0x6b, 0x6f, 0x66,
+	0x66, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x58, 0x50, 0x4f, 0x4e,
+	0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x45, 0x43, 0x4f,
+	0x52, 0x52, 0x45, 0x4c, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x4a, 0x49, 0x54, 0x54, 0x45, 0x52, 0x10,
+	0x01, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -745,41 +4152,139 @@ func file_gateway_config_v1_gateway_proto_rawDescGZIP() []byte {
 	return file_gateway_config_v1_gateway_proto_rawDescData
 }
 
-var file_gateway_config_v1_gateway_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_gateway_config_v1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_gateway_config_v1_gateway_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_gateway_config_v1_gateway_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_gateway_config_v1_gateway_proto_goTypes = []interface{}{
-	(Protocol)(0),               // 0: gateway.config.v1.Protocol
-	(*Gateway)(nil),             // 1: gateway.config.v1.Gateway
-	(*Endpoint)(nil),            // 2: gateway.config.v1.Endpoint
-	(*Middleware)(nil),          // 3: gateway.config.v1.Middleware
-	(*Backend)(nil),             // 4: gateway.config.v1.Backend
-	(*HealthCheck)(nil),         // 5: gateway.config.v1.HealthCheck
-	(*Retry)(nil),               // 6: gateway.config.v1.Retry
-	(*Condition)(nil),           // 7: gateway.config.v1.Condition
-	nil,                         // 8: gateway.config.v1.Endpoint.MetadataEntry
-	(*ConditionHeader)(nil),     // 9: gateway.config.v1.Condition.header
-	(*durationpb.Duration)(nil), // 10: google.protobuf.Duration
-	(*anypb.Any)(nil),           // 11: google.protobuf.Any
+	(TrailingSlash)(0),          // 0: gateway.config.v1.TrailingSlash
+	(PathCase)(0),               // 1: gateway.config.v1.PathCase
+	(FailoverMode)(0),           // 2: gateway.config.v1.FailoverMode
+	(BalancerPolicy)(0),         // 3: gateway.config.v1.BalancerPolicy
+	(MetricsMode)(0),            // 4: gateway.config.v1.MetricsMode
+	(PartialFailure)(0),         // 5: gateway.config.v1.PartialFailure
+	(XForwardedFor)(0),          // 6: gateway.config.v1.XForwardedFor
+	(Protocol)(0),               // 7: gateway.config.v1.Protocol
+	(BackoffPolicy)(0),          // 8: gateway.config.v1.BackoffPolicy
+	(*Gateway)(nil),             // 9: gateway.config.v1.Gateway
+	(*ServiceDefaults)(nil),     // 10: gateway.config.v1.ServiceDefaults
+	(*DecisionTrace)(nil),       // 11: gateway.config.v1.DecisionTrace
+	(*Maintenance)(nil),         // 12: gateway.config.v1.Maintenance
+	(*ConnectionLimit)(nil),     // 13: gateway.config.v1.ConnectionLimit
+	(*Sanitize)(nil),            // 14: gateway.config.v1.Sanitize
+	(*Endpoint)(nil),            // 15: gateway.config.v1.Endpoint
+	(*Via)(nil),                 // 16: gateway.config.v1.Via
+	(*FollowRedirects)(nil),     // 17: gateway.config.v1.FollowRedirects
+	(*Split)(nil),               // 18: gateway.config.v1.Split
+	(*Failover)(nil),            // 19: gateway.config.v1.Failover
+	(*Balancer)(nil),            // 20: gateway.config.v1.Balancer
+	(*HashKey)(nil),             // 21: gateway.config.v1.HashKey
+	(*Aggregate)(nil),           // 22: gateway.config.v1.Aggregate
+	(*SubRequest)(nil),          // 23: gateway.config.v1.SubRequest
+	(*ResponseRate)(nil),        // 24: gateway.config.v1.ResponseRate
+	(*GRPCRoute)(nil),           // 25: gateway.config.v1.GRPCRoute
+	(*HeaderFilter)(nil),        // 26: gateway.config.v1.HeaderFilter
+	(*UserAgent)(nil),           // 27: gateway.config.v1.UserAgent
+	(*Dialer)(nil),              // 28: gateway.config.v1.Dialer
+	(*Keepalive)(nil),           // 29: gateway.config.v1.Keepalive
+	(*Alias)(nil),               // 30: gateway.config.v1.Alias
+	(*Static)(nil),              // 31: gateway.config.v1.Static
+	(*Query)(nil),               // 32: gateway.config.v1.Query
+	(*UpstreamOverride)(nil),    // 33: gateway.config.v1.UpstreamOverride
+	(*ClientDeadline)(nil),      // 34: gateway.config.v1.ClientDeadline
+	(*Middleware)(nil),          // 35: gateway.config.v1.Middleware
+	(*MiddlewareMatch)(nil),     // 36: gateway.config.v1.MiddlewareMatch
+	(*Backend)(nil),             // 37: gateway.config.v1.Backend
+	(*HealthCheck)(nil),         // 38: gateway.config.v1.HealthCheck
+	(*Retry)(nil),               // 39: gateway.config.v1.Retry
+	(*RetryAfter)(nil),          // 40: gateway.config.v1.RetryAfter
+	(*Backoff)(nil),             // 41: gateway.config.v1.Backoff
+	(*Condition)(nil),           // 42: gateway.config.v1.Condition
+	nil,                         // 43: gateway.config.v1.Gateway.ServiceDefaultsEntry
+	nil,                         // 44: gateway.config.v1.Maintenance.HeadersEntry
+	nil,                         // 45: gateway.config.v1.Endpoint.MetadataEntry
+	(*Split_Variant)(nil),       // 46: gateway.config.v1.Split.Variant
+	(*Failover_Pool)(nil),       // 47: gateway.config.v1.Failover.Pool
+	(*ConditionHeader)(nil),     // 48: gateway.config.v1.Condition.header
+	(*durationpb.Duration)(nil), // 49: google.protobuf.Duration
+	(*anypb.Any)(nil),           // 50: google.protobuf.Any
 }
 var file_gateway_config_v1_gateway_proto_depIdxs = []int32{
-	2,  // 0: gateway.config.v1.Gateway.endpoints:type_name -> gateway.config.v1.Endpoint
-	3,  // 1: gateway.config.v1.Gateway.middlewares:type_name -> gateway.config.v1.Middleware
-	0,  // 2: gateway.config.v1.Endpoint.protocol:type_name -> gateway.config.v1.Protocol
-	10, // 3: gateway.config.v1.Endpoint.timeout:type_name -> google.protobuf.Duration
-	3,  // 4: gateway.config.v1.Endpoint.middlewares:type_name -> gateway.config.v1.Middleware
-	4,  // 5: gateway.config.v1.Endpoint.backends:type_name -> gateway.config.v1.Backend
-	6,  // 6: gateway.config.v1.Endpoint.retry:type_name -> gateway.config.v1.Retry
-	8,  // 7: gateway.config.v1.Endpoint.metadata:type_name -> gateway.config.v1.Endpoint.MetadataEntry
-	11, // 8: gateway.config.v1.Middleware.options:type_name -> google.protobuf.Any
-	5,  // 9: gateway.config.v1.Backend.health_check:type_name -> gateway.config.v1.HealthCheck
-	10, // 10: gateway.config.v1.Retry.per_try_timeout:type_name -> google.protobuf.Duration
-	7,  // 11: gateway.config.v1.Retry.conditions:type_name -> gateway.config.v1.Condition
-	9,  // 12: gateway.config.v1.Condition.by_header:type_name -> gateway.config.v1.Condition.header
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	15, // 0: gateway.config.v1.Gateway.endpoints:type_name -> gateway.config.v1.Endpoint
+	35, // 1: gateway.config.v1.Gateway.middlewares:type_name -> gateway.config.v1.Middleware
+	0,  // 2: gateway.config.v1.Gateway.trailing_slash:type_name -> gateway.config.v1.TrailingSlash
+	14, // 3: gateway.config.v1.Gateway.sanitize:type_name -> gateway.config.v1.Sanitize
+	13, // 4: gateway.config.v1.Gateway.connection_limit:type_name -> gateway.config.v1.ConnectionLimit
+	15, // 5: gateway.config.v1.Gateway.default_endpoint:type_name -> gateway.config.v1.Endpoint
+	1,  // 6: gateway.config.v1.Gateway.path_case:type_name -> gateway.config.v1.PathCase
+	49, // 7: gateway.config.v1.Gateway.max_request_timeout:type_name -> google.protobuf.Duration
+	12, // 8: gateway.config.v1.Gateway.maintenance:type_name -> gateway.config.v1.Maintenance
+	11, // 9: gateway.config.v1.Gateway.decision_trace:type_name -> gateway.config.v1.DecisionTrace
+	43, // 10: gateway.config.v1.Gateway.service_defaults:type_name -> gateway.config.v1.Gateway.ServiceDefaultsEntry
+	49, // 11: gateway.config.v1.ServiceDefaults.timeout:type_name -> google.protobuf.Duration
+	39, // 12: gateway.config.v1.ServiceDefaults.retry:type_name -> gateway.config.v1.Retry
+	44, // 13: gateway.config.v1.Maintenance.headers:type_name -> gateway.config.v1.Maintenance.HeadersEntry
+	7,  // 14: gateway.config.v1.Endpoint.protocol:type_name -> gateway.config.v1.Protocol
+	49, // 15: gateway.config.v1.Endpoint.timeout:type_name -> google.protobuf.Duration
+	35, // 16: gateway.config.v1.Endpoint.middlewares:type_name -> gateway.config.v1.Middleware
+	37, // 17: gateway.config.v1.Endpoint.backends:type_name -> gateway.config.v1.Backend
+	39, // 18: gateway.config.v1.Endpoint.retry:type_name -> gateway.config.v1.Retry
+	45, // 19: gateway.config.v1.Endpoint.metadata:type_name -> gateway.config.v1.Endpoint.MetadataEntry
+	33, // 20: gateway.config.v1.Endpoint.upstream_override:type_name -> gateway.config.v1.UpstreamOverride
+	6,  // 21: gateway.config.v1.Endpoint.x_forwarded_for:type_name -> gateway.config.v1.XForwardedFor
+	32, // 22: gateway.config.v1.Endpoint.queries:type_name -> gateway.config.v1.Query
+	31, // 23: gateway.config.v1.Endpoint.static:type_name -> gateway.config.v1.Static
+	30, // 24: gateway.config.v1.Endpoint.aliases:type_name -> gateway.config.v1.Alias
+	28, // 25: gateway.config.v1.Endpoint.dialer:type_name -> gateway.config.v1.Dialer
+	27, // 26: gateway.config.v1.Endpoint.user_agent:type_name -> gateway.config.v1.UserAgent
+	26, // 27: gateway.config.v1.Endpoint.response_headers:type_name -> gateway.config.v1.HeaderFilter
+	25, // 28: gateway.config.v1.Endpoint.grpc:type_name -> gateway.config.v1.GRPCRoute
+	24, // 29: gateway.config.v1.Endpoint.min_response_rate:type_name -> gateway.config.v1.ResponseRate
+	42, // 30: gateway.config.v1.Endpoint.close_connection_on:type_name -> gateway.config.v1.Condition
+	22, // 31: gateway.config.v1.Endpoint.aggregate:type_name -> gateway.config.v1.Aggregate
+	29, // 32: gateway.config.v1.Endpoint.keepalive:type_name -> gateway.config.v1.Keepalive
+	4,  // 33: gateway.config.v1.Endpoint.metrics_mode:type_name -> gateway.config.v1.MetricsMode
+	34, // 34: gateway.config.v1.Endpoint.client_deadline:type_name -> gateway.config.v1.ClientDeadline
+	20, // 35: gateway.config.v1.Endpoint.balancer:type_name -> gateway.config.v1.Balancer
+	49, // 36: gateway.config.v1.Endpoint.tunnel_idle_timeout:type_name -> google.protobuf.Duration
+	18, // 37: gateway.config.v1.Endpoint.split:type_name -> gateway.config.v1.Split
+	17, // 38: gateway.config.v1.Endpoint.follow_redirects:type_name -> gateway.config.v1.FollowRedirects
+	16, // 39: gateway.config.v1.Endpoint.via:type_name -> gateway.config.v1.Via
+	19, // 40: gateway.config.v1.Endpoint.failover:type_name -> gateway.config.v1.Failover
+	46, // 41: gateway.config.v1.Split.variants:type_name -> gateway.config.v1.Split.Variant
+	49, // 42: gateway.config.v1.Split.cookie_max_age:type_name -> google.protobuf.Duration
+	47, // 43: gateway.config.v1.Failover.pools:type_name -> gateway.config.v1.Failover.Pool
+	2,  // 44: gateway.config.v1.Failover.mode:type_name -> gateway.config.v1.FailoverMode
+	49, // 45: gateway.config.v1.Failover.unhealthy_duration:type_name -> google.protobuf.Duration
+	3,  // 46: gateway.config.v1.Balancer.policy:type_name -> gateway.config.v1.BalancerPolicy
+	21, // 47: gateway.config.v1.Balancer.hash_key:type_name -> gateway.config.v1.HashKey
+	23, // 48: gateway.config.v1.Aggregate.requests:type_name -> gateway.config.v1.SubRequest
+	49, // 49: gateway.config.v1.Aggregate.timeout:type_name -> google.protobuf.Duration
+	5,  // 50: gateway.config.v1.Aggregate.partial_failure:type_name -> gateway.config.v1.PartialFailure
+	37, // 51: gateway.config.v1.SubRequest.backends:type_name -> gateway.config.v1.Backend
+	49, // 52: gateway.config.v1.ResponseRate.window:type_name -> google.protobuf.Duration
+	49, // 53: gateway.config.v1.Dialer.timeout:type_name -> google.protobuf.Duration
+	49, // 54: gateway.config.v1.Dialer.keepalive:type_name -> google.protobuf.Duration
+	49, // 55: gateway.config.v1.Keepalive.time:type_name -> google.protobuf.Duration
+	49, // 56: gateway.config.v1.Keepalive.timeout:type_name -> google.protobuf.Duration
+	50, // 57: gateway.config.v1.Middleware.options:type_name -> google.protobuf.Any
+	36, // 58: gateway.config.v1.Middleware.when:type_name -> gateway.config.v1.MiddlewareMatch
+	38, // 59: gateway.config.v1.Backend.health_check:type_name -> gateway.config.v1.HealthCheck
+	49, // 60: gateway.config.v1.Retry.per_try_timeout:type_name -> google.protobuf.Duration
+	42, // 61: gateway.config.v1.Retry.conditions:type_name -> gateway.config.v1.Condition
+	41, // 62: gateway.config.v1.Retry.backoff:type_name -> gateway.config.v1.Backoff
+	40, // 63: gateway.config.v1.Retry.retry_after:type_name -> gateway.config.v1.RetryAfter
+	49, // 64: gateway.config.v1.RetryAfter.fixed:type_name -> google.protobuf.Duration
+	8,  // 65: gateway.config.v1.Backoff.policy:type_name -> gateway.config.v1.BackoffPolicy
+	49, // 66: gateway.config.v1.Backoff.base:type_name -> google.protobuf.Duration
+	49, // 67: gateway.config.v1.Backoff.max:type_name -> google.protobuf.Duration
+	48, // 68: gateway.config.v1.Condition.by_header:type_name -> gateway.config.v1.Condition.header
+	10, // 69: gateway.config.v1.Gateway.ServiceDefaultsEntry.value:type_name -> gateway.config.v1.ServiceDefaults
+	37, // 70: gateway.config.v1.Split.Variant.backends:type_name -> gateway.config.v1.Backend
+	37, // 71: gateway.config.v1.Failover.Pool.backends:type_name -> gateway.config.v1.Backend
+	72, // [72:72] is the sub-list for method output_type
+	72, // [72:72] is the sub-list for method input_type
+	72, // [72:72] is the sub-list for extension type_name
+	72, // [72:72] is the sub-list for extension extendee
+	0,  // [0:72] is the sub-list for field type_name
 }
 
 func init() { file_gateway_config_v1_gateway_proto_init() }
@@ -800,7 +4305,67 @@ func file_gateway_config_v1_gateway_proto_init() {
 				return nil
 			}
 		}
-		file_gateway_config_v1_gateway_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_gateway_config_v1_gateway_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServiceDefaults); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DecisionTrace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Maintenance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConnectionLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sanitize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Endpoint); i {
 			case 0:
 				return &v.state
@@ -812,7 +4377,235 @@ func file_gateway_config_v1_gateway_proto_init() {
 				return nil
 			}
 		}
-		file_gateway_config_v1_gateway_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_gateway_config_v1_gateway_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Via); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FollowRedirects); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Split); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Failover); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Balancer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HashKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Aggregate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseRate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GRPCRoute); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeaderFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserAgent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dialer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Keepalive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Alias); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Static); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Query); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpstreamOverride); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClientDeadline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Middleware); i {
 			case 0:
 				return &v.state
@@ -824,7 +4617,19 @@ func file_gateway_config_v1_gateway_proto_init() {
 				return nil
 			}
 		}
-		file_gateway_config_v1_gateway_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_gateway_config_v1_gateway_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MiddlewareMatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Backend); i {
 			case 0:
 				return &v.state
@@ -836,7 +4641,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 				return nil
 			}
 		}
-		file_gateway_config_v1_gateway_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_gateway_config_v1_gateway_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HealthCheck); i {
 			case 0:
 				return &v.state
@@ -848,7 +4653,7 @@ func file_gateway_config_v1_gateway_proto_init() {
 				return nil
 			}
 		}
-		file_gateway_config_v1_gateway_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_gateway_config_v1_gateway_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Retry); i {
 			case 0:
 				return &v.state
@@ -860,7 +4665,31 @@ func file_gateway_config_v1_gateway_proto_init() {
 				return nil
 			}
 		}
-		file_gateway_config_v1_gateway_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_gateway_config_v1_gateway_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RetryAfter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Backoff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Condition); i {
 			case 0:
 				return &v.state
@@ -872,7 +4701,31 @@ func file_gateway_config_v1_gateway_proto_init() {
 				return nil
 			}
 		}
-		file_gateway_config_v1_gateway_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_gateway_config_v1_gateway_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Split_Variant); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Failover_Pool); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_config_v1_gateway_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConditionHeader); i {
 			case 0:
 				return &v.state
@@ -885,18 +4738,26 @@ func file_gateway_config_v1_gateway_proto_init() {
 			}
 		}
 	}
-	file_gateway_config_v1_gateway_proto_msgTypes[3].OneofWrappers = []interface{}{}
-	file_gateway_config_v1_gateway_proto_msgTypes[6].OneofWrappers = []interface{}{
+	file_gateway_config_v1_gateway_proto_msgTypes[18].OneofWrappers = []interface{}{
+		(*UserAgent_Set)(nil),
+		(*UserAgent_Suffix)(nil),
+	}
+	file_gateway_config_v1_gateway_proto_msgTypes[19].OneofWrappers = []interface{}{}
+	file_gateway_config_v1_gateway_proto_msgTypes[22].OneofWrappers = []interface{}{}
+	file_gateway_config_v1_gateway_proto_msgTypes[23].OneofWrappers = []interface{}{}
+	file_gateway_config_v1_gateway_proto_msgTypes[28].OneofWrappers = []interface{}{}
+	file_gateway_config_v1_gateway_proto_msgTypes[33].OneofWrappers = []interface{}{
 		(*Condition_ByStatusCode)(nil),
 		(*Condition_ByHeader)(nil),
+		(*Condition_ByEmptyBody)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_gateway_config_v1_gateway_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   9,
+			NumEnums:      9,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
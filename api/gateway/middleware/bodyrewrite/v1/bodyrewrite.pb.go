@@ -0,0 +1,482 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.2
+// source: gateway/middleware/bodyrewrite/v1/bodyrewrite.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BodyRewrite middleware config, it rewrites the JSON request bodies.
+// The operations are applied in order, the non-JSON, malformed or larger bodies are passed through.
+type BodyRewrite struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Operations []*Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	// default is 1MB, it's the limit of both the encoded and the decoded bodies.
+	MaxBodyBytes int64 `protobuf:"varint,2,opt,name=max_body_bytes,json=maxBodyBytes,proto3" json:"max_body_bytes,omitempty"`
+	// decompress the gzip or deflate bodies for the rewriting, the bodies of the other encodings are passed through.
+	// Note it's only for the rewriting and the upstream receives the Content-Encoding of the client,
+	// so the body-decompression of the upstream is still required unless it's forwarded decompressed.
+	Decompress bool `protobuf:"varint,3,opt,name=decompress,proto3" json:"decompress,omitempty"`
+	// compress the rewritten bodies by the encoding of the client again,
+	// otherwise they are forwarded decompressed without the Content-Encoding.
+	Recompress bool `protobuf:"varint,4,opt,name=recompress,proto3" json:"recompress,omitempty"`
+}
+
+func (x *BodyRewrite) Reset() {
+	*x = BodyRewrite{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BodyRewrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BodyRewrite) ProtoMessage() {}
+
+func (x *BodyRewrite) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BodyRewrite.ProtoReflect.Descriptor instead.
+func (*BodyRewrite) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BodyRewrite) GetOperations() []*Operation {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *BodyRewrite) GetMaxBodyBytes() int64 {
+	if x != nil {
+		return x.MaxBodyBytes
+	}
+	return 0
+}
+
+func (x *BodyRewrite) GetDecompress() bool {
+	if x != nil {
+		return x.Decompress
+	}
+	return false
+}
+
+func (x *BodyRewrite) GetRecompress() bool {
+	if x != nil {
+		return x.Recompress
+	}
+	return false
+}
+
+type Operation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Operation:
+	//	*Operation_Wrap
+	//	*Operation_Rename
+	//	*Operation_SetDefault
+	//	*Operation_Drop
+	Operation isOperation_Operation `protobuf_oneof:"operation"`
+}
+
+func (x *Operation) Reset() {
+	*x = Operation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Operation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Operation) ProtoMessage() {}
+
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *Operation) GetOperation() isOperation_Operation {
+	if m != nil {
+		return m.Operation
+	}
+	return nil
+}
+
+func (x *Operation) GetWrap() string {
+	if x, ok := x.GetOperation().(*Operation_Wrap); ok {
+		return x.Wrap
+	}
+	return ""
+}
+
+func (x *Operation) GetRename() *Rename {
+	if x, ok := x.GetOperation().(*Operation_Rename); ok {
+		return x.Rename
+	}
+	return nil
+}
+
+func (x *Operation) GetSetDefault() *SetDefault {
+	if x, ok := x.GetOperation().(*Operation_SetDefault); ok {
+		return x.SetDefault
+	}
+	return nil
+}
+
+func (x *Operation) GetDrop() string {
+	if x, ok := x.GetOperation().(*Operation_Drop); ok {
+		return x.Drop
+	}
+	return ""
+}
+
+type isOperation_Operation interface {
+	isOperation_Operation()
+}
+
+type Operation_Wrap struct {
+	// wrap the body in an object under the key, e.g. {"data": body}
+	Wrap string `protobuf:"bytes,1,opt,name=wrap,proto3,oneof"`
+}
+
+type Operation_Rename struct {
+	// rename the top-level field
+	Rename *Rename `protobuf:"bytes,2,opt,name=rename,proto3,oneof"`
+}
+
+type Operation_SetDefault struct {
+	// set the top-level field if it's absent
+	SetDefault *SetDefault `protobuf:"bytes,3,opt,name=set_default,json=setDefault,proto3,oneof"`
+}
+
+type Operation_Drop struct {
+	// drop the top-level field
+	Drop string `protobuf:"bytes,4,opt,name=drop,proto3,oneof"`
+}
+
+func (*Operation_Wrap) isOperation_Operation() {}
+
+func (*Operation_Rename) isOperation_Operation() {}
+
+func (*Operation_SetDefault) isOperation_Operation() {}
+
+func (*Operation_Drop) isOperation_Operation() {}
+
+type Rename struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *Rename) Reset() {
+	*x = Rename{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rename) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rename) ProtoMessage() {}
+
+func (x *Rename) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rename.ProtoReflect.Descriptor instead.
+func (*Rename) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Rename) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Rename) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type SetDefault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// JSON encoded value, e.g. "1", "\"text\"" or "{}"
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *SetDefault) Reset() {
+	*x = SetDefault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetDefault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDefault) ProtoMessage() {}
+
+func (x *SetDefault) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDefault.ProtoReflect.Descriptor instead.
+func (*SetDefault) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetDefault) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SetDefault) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDesc = []byte{
+	0x0a, 0x33, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x62, 0x6f, 0x64, 0x79, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65,
+	0x2f, 0x76, 0x31, 0x2f, 0x62, 0x6f, 0x64, 0x79, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x21, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x62, 0x6f, 0x64, 0x79, 0x72, 0x65,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x22, 0xc1, 0x01, 0x0a, 0x0b, 0x42, 0x6f, 0x64,
+	0x79, 0x52, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x12, 0x4c, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
+	0x65, 0x2e, 0x62, 0x6f, 0x64, 0x79, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x6f,
+	0x64, 0x79, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x6d, 0x61, 0x78, 0x42, 0x6f, 0x64, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x64, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x72, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x22, 0xdb, 0x01, 0x0a,
+	0x09, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x04, 0x77, 0x72,
+	0x61, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x77, 0x72, 0x61, 0x70,
+	0x12, 0x43, 0x0a, 0x06, 0x72, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c,
+	0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x62, 0x6f, 0x64, 0x79, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x48, 0x00, 0x52, 0x06, 0x72,
+	0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x73, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e,
+	0x62, 0x6f, 0x64, 0x79, 0x72, 0x65, 0x77, 0x72, 0x69, 0x74, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x65, 0x74, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x73, 0x65, 0x74,
+	0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x42, 0x0b, 0x0a,
+	0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2c, 0x0a, 0x06, 0x52, 0x65,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x38, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x44,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x62, 0x6f, 0x64, 0x79, 0x72, 0x65,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescOnce sync.Once
+	file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescData = file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDesc
+)
+
+func file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescData)
+	})
+	return file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDescData
+}
+
+var file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_goTypes = []interface{}{
+	(*BodyRewrite)(nil), // 0: gateway.middleware.bodyrewrite.v1.BodyRewrite
+	(*Operation)(nil),   // 1: gateway.middleware.bodyrewrite.v1.Operation
+	(*Rename)(nil),      // 2: gateway.middleware.bodyrewrite.v1.Rename
+	(*SetDefault)(nil),  // 3: gateway.middleware.bodyrewrite.v1.SetDefault
+}
+var file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_depIdxs = []int32{
+	1, // 0: gateway.middleware.bodyrewrite.v1.BodyRewrite.operations:type_name -> gateway.middleware.bodyrewrite.v1.Operation
+	2, // 1: gateway.middleware.bodyrewrite.v1.Operation.rename:type_name -> gateway.middleware.bodyrewrite.v1.Rename
+	3, // 2: gateway.middleware.bodyrewrite.v1.Operation.set_default:type_name -> gateway.middleware.bodyrewrite.v1.SetDefault
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_init() }
+func file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_init() {
+	if File_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BodyRewrite); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Operation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rename); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetDefault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Operation_Wrap)(nil),
+		(*Operation_Rename)(nil),
+		(*Operation_SetDefault)(nil),
+		(*Operation_Drop)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto = out.File
+	file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_rawDesc = nil
+	file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_goTypes = nil
+	file_gateway_middleware_bodyrewrite_v1_bodyrewrite_proto_depIdxs = nil
+}
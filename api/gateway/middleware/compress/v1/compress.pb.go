@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.2
+// source: gateway/middleware/compress/v1/compress.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Compress middleware config, it compresses the responses with gzip for the clients accepting it.
+// The responses already encoded by the upstream are passed through.
+type Compress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// gzip level 1-9, default is 6
+	Level int32 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	// the responses smaller than the size are not compressed, default is 1024.
+	// the ones with unknown length, e.g. chunked, are read ahead up to the size to decide, so the first bytes
+	// of a streaming response are delayed until the size is reached or it ends. the server-sent events are
+	// compressed as they're streamed without reading ahead.
+	MinSize int64 `protobuf:"varint,2,opt,name=min_size,json=minSize,proto3" json:"min_size,omitempty"`
+	// the responses larger than the threshold are compressed with the large_body_level to limit the CPU,
+	// default is not switched.
+	LargeBodyThreshold int64 `protobuf:"varint,3,opt,name=large_body_threshold,json=largeBodyThreshold,proto3" json:"large_body_threshold,omitempty"`
+	// default is 1, the fastest
+	LargeBodyLevel int32 `protobuf:"varint,4,opt,name=large_body_level,json=largeBodyLevel,proto3" json:"large_body_level,omitempty"`
+	// the request header of the level hint, the values are "fast" and "best", e.g. X-Compression-Level.
+	// it overrides the size based level.
+	HintHeader string `protobuf:"bytes,5,opt,name=hint_header,json=hintHeader,proto3" json:"hint_header,omitempty"`
+	// prefixes of the compressed content types,
+	// default is text/, application/json, application/javascript and application/xml.
+	ContentTypes []string `protobuf:"bytes,6,rep,name=content_types,json=contentTypes,proto3" json:"content_types,omitempty"`
+}
+
+func (x *Compress) Reset() {
+	*x = Compress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_compress_v1_compress_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Compress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Compress) ProtoMessage() {}
+
+func (x *Compress) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_compress_v1_compress_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Compress.ProtoReflect.Descriptor instead.
+func (*Compress) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_compress_v1_compress_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Compress) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Compress) GetMinSize() int64 {
+	if x != nil {
+		return x.MinSize
+	}
+	return 0
+}
+
+func (x *Compress) GetLargeBodyThreshold() int64 {
+	if x != nil {
+		return x.LargeBodyThreshold
+	}
+	return 0
+}
+
+func (x *Compress) GetLargeBodyLevel() int32 {
+	if x != nil {
+		return x.LargeBodyLevel
+	}
+	return 0
+}
+
+func (x *Compress) GetHintHeader() string {
+	if x != nil {
+		return x.HintHeader
+	}
+	return ""
+}
+
+func (x *Compress) GetContentTypes() []string {
+	if x != nil {
+		return x.ContentTypes
+	}
+	return nil
+}
+
+var File_gateway_middleware_compress_v1_compress_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_compress_v1_compress_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x2f, 0x76, 0x31,
+	0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77,
+	0x61, 0x72, 0x65, 0x2e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x76, 0x31, 0x22,
+	0xdd, 0x01, 0x0a, 0x08, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x69, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x30, 0x0a,
+	0x14, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x74, 0x68, 0x72, 0x65,
+	0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x6c, 0x61, 0x72,
+	0x67, 0x65, 0x42, 0x6f, 0x64, 0x79, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12,
+	0x28, 0x0a, 0x10, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6c, 0x61, 0x72, 0x67, 0x65,
+	0x42, 0x6f, 0x64, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x69, 0x6e,
+	0x74, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x68, 0x69, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42,
+	0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f,
+	0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64,
+	0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x2f,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_compress_v1_compress_proto_rawDescOnce sync.Once
+	file_gateway_middleware_compress_v1_compress_proto_rawDescData = file_gateway_middleware_compress_v1_compress_proto_rawDesc
+)
+
+func file_gateway_middleware_compress_v1_compress_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_compress_v1_compress_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_compress_v1_compress_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_compress_v1_compress_proto_rawDescData)
+	})
+	return file_gateway_middleware_compress_v1_compress_proto_rawDescData
+}
+
+var file_gateway_middleware_compress_v1_compress_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_gateway_middleware_compress_v1_compress_proto_goTypes = []interface{}{
+	(*Compress)(nil), // 0: gateway.middleware.compress.v1.Compress
+}
+var file_gateway_middleware_compress_v1_compress_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_compress_v1_compress_proto_init() }
+func file_gateway_middleware_compress_v1_compress_proto_init() {
+	if File_gateway_middleware_compress_v1_compress_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_compress_v1_compress_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Compress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_compress_v1_compress_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_compress_v1_compress_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_compress_v1_compress_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_compress_v1_compress_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_compress_v1_compress_proto = out.File
+	file_gateway_middleware_compress_v1_compress_proto_rawDesc = nil
+	file_gateway_middleware_compress_v1_compress_proto_goTypes = nil
+	file_gateway_middleware_compress_v1_compress_proto_depIdxs = nil
+}
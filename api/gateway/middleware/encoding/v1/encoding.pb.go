@@ -0,0 +1,260 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.2
+// source: gateway/middleware/encoding/v1/encoding.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Encoding middleware config, it normalizes the Accept-Encoding header to the upstream.
+// The Accept-Encoding of the client is passed through if no policy is set.
+// Note the gateway never decompresses the responses, so that the encoding negotiated
+// with the upstream is the one the client receives.
+type Encoding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Policy:
+	//	*Encoding_Set
+	//	*Encoding_Strip
+	Policy isEncoding_Policy `protobuf_oneof:"policy"`
+}
+
+func (x *Encoding) Reset() {
+	*x = Encoding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_encoding_v1_encoding_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Encoding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Encoding) ProtoMessage() {}
+
+func (x *Encoding) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_encoding_v1_encoding_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Encoding.ProtoReflect.Descriptor instead.
+func (*Encoding) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_encoding_v1_encoding_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *Encoding) GetPolicy() isEncoding_Policy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (x *Encoding) GetSet() string {
+	if x, ok := x.GetPolicy().(*Encoding_Set); ok {
+		return x.Set
+	}
+	return ""
+}
+
+func (x *Encoding) GetStrip() *Strip {
+	if x, ok := x.GetPolicy().(*Encoding_Strip); ok {
+		return x.Strip
+	}
+	return nil
+}
+
+type isEncoding_Policy interface {
+	isEncoding_Policy()
+}
+
+type Encoding_Set struct {
+	// force the value, e.g. "gzip" or "identity"
+	Set string `protobuf:"bytes,1,opt,name=set,proto3,oneof"`
+}
+
+type Encoding_Strip struct {
+	// keep the supported encodings only
+	Strip *Strip `protobuf:"bytes,2,opt,name=strip,proto3,oneof"`
+}
+
+func (*Encoding_Set) isEncoding_Policy() {}
+
+func (*Encoding_Strip) isEncoding_Policy() {}
+
+type Strip struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// e.g. ["gzip", "br"], the x-gzip is taken as gzip.
+	Supported []string `protobuf:"bytes,1,rep,name=supported,proto3" json:"supported,omitempty"`
+}
+
+func (x *Strip) Reset() {
+	*x = Strip{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_encoding_v1_encoding_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Strip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Strip) ProtoMessage() {}
+
+func (x *Strip) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_encoding_v1_encoding_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Strip.ProtoReflect.Descriptor instead.
+func (*Strip) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_encoding_v1_encoding_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Strip) GetSupported() []string {
+	if x != nil {
+		return x.Supported
+	}
+	return nil
+}
+
+var File_gateway_middleware_encoding_v1_encoding_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_encoding_v1_encoding_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31,
+	0x2f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77,
+	0x61, 0x72, 0x65, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x22,
+	0x67, 0x0a, 0x08, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x03, 0x73,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12,
+	0x3d, 0x0a, 0x05, 0x73, 0x74, 0x72, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77,
+	0x61, 0x72, 0x65, 0x2e, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x72, 0x69, 0x70, 0x48, 0x00, 0x52, 0x05, 0x73, 0x74, 0x72, 0x69, 0x70, 0x42, 0x08,
+	0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x25, 0x0a, 0x05, 0x53, 0x74, 0x72, 0x69,
+	0x70, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x42,
+	0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f,
+	0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64,
+	0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x2f,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_encoding_v1_encoding_proto_rawDescOnce sync.Once
+	file_gateway_middleware_encoding_v1_encoding_proto_rawDescData = file_gateway_middleware_encoding_v1_encoding_proto_rawDesc
+)
+
+func file_gateway_middleware_encoding_v1_encoding_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_encoding_v1_encoding_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_encoding_v1_encoding_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_encoding_v1_encoding_proto_rawDescData)
+	})
+	return file_gateway_middleware_encoding_v1_encoding_proto_rawDescData
+}
+
+var file_gateway_middleware_encoding_v1_encoding_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_gateway_middleware_encoding_v1_encoding_proto_goTypes = []interface{}{
+	(*Encoding)(nil), // 0: gateway.middleware.encoding.v1.Encoding
+	(*Strip)(nil),    // 1: gateway.middleware.encoding.v1.Strip
+}
+var file_gateway_middleware_encoding_v1_encoding_proto_depIdxs = []int32{
+	1, // 0: gateway.middleware.encoding.v1.Encoding.strip:type_name -> gateway.middleware.encoding.v1.Strip
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_encoding_v1_encoding_proto_init() }
+func file_gateway_middleware_encoding_v1_encoding_proto_init() {
+	if File_gateway_middleware_encoding_v1_encoding_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_encoding_v1_encoding_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Encoding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_encoding_v1_encoding_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Strip); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_middleware_encoding_v1_encoding_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Encoding_Set)(nil),
+		(*Encoding_Strip)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_encoding_v1_encoding_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_encoding_v1_encoding_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_encoding_v1_encoding_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_encoding_v1_encoding_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_encoding_v1_encoding_proto = out.File
+	file_gateway_middleware_encoding_v1_encoding_proto_rawDesc = nil
+	file_gateway_middleware_encoding_v1_encoding_proto_goTypes = nil
+	file_gateway_middleware_encoding_v1_encoding_proto_depIdxs = nil
+}
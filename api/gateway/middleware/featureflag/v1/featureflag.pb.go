@@ -0,0 +1,320 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.2
+// source: gateway/middleware/featureflag/v1/featureflag.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FeatureFlag middleware config.
+// It evaluates the flags of each subject and injects them as the request headers for the upstream,
+// e.g. X-Feature-Foo: on, the inbound headers with the prefix are removed.
+type FeatureFlag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// default is X-Feature-
+	HeaderPrefix string  `protobuf:"bytes,1,opt,name=header_prefix,json=headerPrefix,proto3" json:"header_prefix,omitempty"`
+	Flags        []*Flag `protobuf:"bytes,2,rep,name=flags,proto3" json:"flags,omitempty"`
+	// subject of the requests, the requests without it are evaluated by the percentage of an empty subject.
+	//
+	// Types that are assignable to Subject:
+	//	*FeatureFlag_ByClientIp
+	//	*FeatureFlag_ByHeader
+	Subject isFeatureFlag_Subject `protobuf_oneof:"subject"`
+	// cache the evaluated flags of each subject, default is not cached.
+	CacheTtl *durationpb.Duration `protobuf:"bytes,5,opt,name=cache_ttl,json=cacheTtl,proto3" json:"cache_ttl,omitempty"`
+}
+
+func (x *FeatureFlag) Reset() {
+	*x = FeatureFlag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeatureFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureFlag) ProtoMessage() {}
+
+func (x *FeatureFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureFlag.ProtoReflect.Descriptor instead.
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FeatureFlag) GetHeaderPrefix() string {
+	if x != nil {
+		return x.HeaderPrefix
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetFlags() []*Flag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (m *FeatureFlag) GetSubject() isFeatureFlag_Subject {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (x *FeatureFlag) GetByClientIp() bool {
+	if x, ok := x.GetSubject().(*FeatureFlag_ByClientIp); ok {
+		return x.ByClientIp
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetByHeader() string {
+	if x, ok := x.GetSubject().(*FeatureFlag_ByHeader); ok {
+		return x.ByHeader
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.CacheTtl
+	}
+	return nil
+}
+
+type isFeatureFlag_Subject interface {
+	isFeatureFlag_Subject()
+}
+
+type FeatureFlag_ByClientIp struct {
+	ByClientIp bool `protobuf:"varint,3,opt,name=by_client_ip,json=byClientIp,proto3,oneof"`
+}
+
+type FeatureFlag_ByHeader struct {
+	ByHeader string `protobuf:"bytes,4,opt,name=by_header,json=byHeader,proto3,oneof"`
+}
+
+func (*FeatureFlag_ByClientIp) isFeatureFlag_Subject() {}
+
+func (*FeatureFlag_ByHeader) isFeatureFlag_Subject() {}
+
+type Flag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// the percentage of the subjects the flag is on, 0-100
+	Percentage uint32 `protobuf:"varint,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// the subjects the flag is always on regardless of the percentage
+	Subjects []string `protobuf:"bytes,3,rep,name=subjects,proto3" json:"subjects,omitempty"`
+}
+
+func (x *Flag) Reset() {
+	*x = Flag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Flag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Flag) ProtoMessage() {}
+
+func (x *Flag) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Flag.ProtoReflect.Descriptor instead.
+func (*Flag) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Flag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Flag) GetPercentage() uint32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *Flag) GetSubjects() []string {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+var File_gateway_middleware_featureflag_v1_featureflag_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_featureflag_v1_featureflag_proto_rawDesc = []byte{
+	0x0a, 0x33, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x66, 0x6c, 0x61, 0x67,
+	0x2f, 0x76, 0x31, 0x2f, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x66, 0x6c, 0x61, 0x67, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x21, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d,
+	0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x66, 0x6c, 0x61, 0x67, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf7, 0x01, 0x0a, 0x0b, 0x46, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x3d, 0x0a,
+	0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
+	0x65, 0x2e, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x66, 0x6c, 0x61, 0x67, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x6c, 0x61, 0x67, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x62, 0x79, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x00, 0x52, 0x0a, 0x62, 0x79, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x70,
+	0x12, 0x1d, 0x0a, 0x09, 0x62, 0x79, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x62, 0x79, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12,
+	0x36, 0x0a, 0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63,
+	0x61, 0x63, 0x68, 0x65, 0x54, 0x74, 0x6c, 0x42, 0x09, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x22, 0x56, 0x0a, 0x04, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x42, 0x44, 0x5a, 0x42, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74,
+	0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67,
+	0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
+	0x65, 0x2f, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x66, 0x6c, 0x61, 0x67, 0x2f, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescOnce sync.Once
+	file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescData = file_gateway_middleware_featureflag_v1_featureflag_proto_rawDesc
+)
+
+func file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescData)
+	})
+	return file_gateway_middleware_featureflag_v1_featureflag_proto_rawDescData
+}
+
+var file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_gateway_middleware_featureflag_v1_featureflag_proto_goTypes = []interface{}{
+	(*FeatureFlag)(nil),         // 0: gateway.middleware.featureflag.v1.FeatureFlag
+	(*Flag)(nil),                // 1: gateway.middleware.featureflag.v1.Flag
+	(*durationpb.Duration)(nil), // 2: google.protobuf.Duration
+}
+var file_gateway_middleware_featureflag_v1_featureflag_proto_depIdxs = []int32{
+	1, // 0: gateway.middleware.featureflag.v1.FeatureFlag.flags:type_name -> gateway.middleware.featureflag.v1.Flag
+	2, // 1: gateway.middleware.featureflag.v1.FeatureFlag.cache_ttl:type_name -> google.protobuf.Duration
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_featureflag_v1_featureflag_proto_init() }
+func file_gateway_middleware_featureflag_v1_featureflag_proto_init() {
+	if File_gateway_middleware_featureflag_v1_featureflag_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeatureFlag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Flag); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*FeatureFlag_ByClientIp)(nil),
+		(*FeatureFlag_ByHeader)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_featureflag_v1_featureflag_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_featureflag_v1_featureflag_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_featureflag_v1_featureflag_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_featureflag_v1_featureflag_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_featureflag_v1_featureflag_proto = out.File
+	file_gateway_middleware_featureflag_v1_featureflag_proto_rawDesc = nil
+	file_gateway_middleware_featureflag_v1_featureflag_proto_goTypes = nil
+	file_gateway_middleware_featureflag_v1_featureflag_proto_depIdxs = nil
+}
@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.2
+// source: gateway/middleware/queue/v1/queue.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Discipline int32
+
+const (
+	Discipline_FIFO Discipline = 0
+	// serve the freshest requests first under overload, their clients are less likely to give up.
+	Discipline_LIFO Discipline = 1
+)
+
+// Enum value maps for Discipline.
+var (
+	Discipline_name = map[int32]string{
+		0: "FIFO",
+		1: "LIFO",
+	}
+	Discipline_value = map[string]int32{
+		"FIFO": 0,
+		"LIFO": 1,
+	}
+)
+
+func (x Discipline) Enum() *Discipline {
+	p := new(Discipline)
+	*p = x
+	return p
+}
+
+func (x Discipline) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Discipline) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_middleware_queue_v1_queue_proto_enumTypes[0].Descriptor()
+}
+
+func (Discipline) Type() protoreflect.EnumType {
+	return &file_gateway_middleware_queue_v1_queue_proto_enumTypes[0]
+}
+
+func (x Discipline) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Discipline.Descriptor instead.
+func (Discipline) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_middleware_queue_v1_queue_proto_rawDescGZIP(), []int{0}
+}
+
+// Queue middleware config, it's an admission controller limiting the concurrent requests.
+// The requests beyond max_concurrency wait in the queue, they are rejected with 503
+// if the queue is full or they have waited longer than max_age.
+type Queue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxConcurrency int64 `protobuf:"varint,1,opt,name=max_concurrency,json=maxConcurrency,proto3" json:"max_concurrency,omitempty"`
+	// default is max_concurrency
+	MaxQueue int64 `protobuf:"varint,2,opt,name=max_queue,json=maxQueue,proto3" json:"max_queue,omitempty"`
+	// default is 1s
+	MaxAge     *durationpb.Duration `protobuf:"bytes,3,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	Discipline Discipline           `protobuf:"varint,4,opt,name=discipline,proto3,enum=gateway.middleware.queue.v1.Discipline" json:"discipline,omitempty"`
+	// cap the share of max_concurrency of each client, the requests beyond it are rejected with 503.
+	Fairness *Fairness `protobuf:"bytes,5,opt,name=fairness,proto3" json:"fairness,omitempty"`
+}
+
+func (x *Queue) Reset() {
+	*x = Queue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_queue_v1_queue_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Queue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Queue) ProtoMessage() {}
+
+func (x *Queue) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_queue_v1_queue_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Queue.ProtoReflect.Descriptor instead.
+func (*Queue) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_queue_v1_queue_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Queue) GetMaxConcurrency() int64 {
+	if x != nil {
+		return x.MaxConcurrency
+	}
+	return 0
+}
+
+func (x *Queue) GetMaxQueue() int64 {
+	if x != nil {
+		return x.MaxQueue
+	}
+	return 0
+}
+
+func (x *Queue) GetMaxAge() *durationpb.Duration {
+	if x != nil {
+		return x.MaxAge
+	}
+	return nil
+}
+
+func (x *Queue) GetDiscipline() Discipline {
+	if x != nil {
+		return x.Discipline
+	}
+	return Discipline_FIFO
+}
+
+func (x *Queue) GetFairness() *Fairness {
+	if x != nil {
+		return x.Fairness
+	}
+	return nil
+}
+
+// Fairness caps the slots of each client including the queued ones, so that a noisy client can't starve the others.
+type Fairness struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the request header identifying the client, e.g. X-Tenant-Id, the client IP if not specified or absent.
+	KeyHeader string `protobuf:"bytes,1,opt,name=key_header,json=keyHeader,proto3" json:"key_header,omitempty"`
+	// the share of max_concurrency of a client in (0, 1], e.g. 0.25, it's at least one slot.
+	MaxShare float64 `protobuf:"fixed64,2,opt,name=max_share,json=maxShare,proto3" json:"max_share,omitempty"`
+	// the shares of the specific clients by the key instead of max_share, e.g. {"batch": 0.1}.
+	Shares map[string]float64 `protobuf:"bytes,3,rep,name=shares,proto3" json:"shares,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// the max clients labeled by their keys in the metrics, the others are labeled "other", default is 20.
+	MaxTrackedClients int32 `protobuf:"varint,4,opt,name=max_tracked_clients,json=maxTrackedClients,proto3" json:"max_tracked_clients,omitempty"`
+}
+
+func (x *Fairness) Reset() {
+	*x = Fairness{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_queue_v1_queue_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Fairness) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fairness) ProtoMessage() {}
+
+func (x *Fairness) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_queue_v1_queue_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fairness.ProtoReflect.Descriptor instead.
+func (*Fairness) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_queue_v1_queue_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Fairness) GetKeyHeader() string {
+	if x != nil {
+		return x.KeyHeader
+	}
+	return ""
+}
+
+func (x *Fairness) GetMaxShare() float64 {
+	if x != nil {
+		return x.MaxShare
+	}
+	return 0
+}
+
+func (x *Fairness) GetShares() map[string]float64 {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+func (x *Fairness) GetMaxTrackedClients() int32 {
+	if x != nil {
+		return x.MaxTrackedClients
+	}
+	return 0
+}
+
+var File_gateway_middleware_queue_v1_queue_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_queue_v1_queue_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8d, 0x02, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x75, 0x65,
+	0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x43, 0x6f,
+	0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x71, 0x75, 0x65, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61,
+	0x78, 0x51, 0x75, 0x65, 0x75, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x41, 0x67, 0x65, 0x12, 0x47, 0x0a, 0x0a, 0x64, 0x69,
+	0x73, 0x63, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27,
+	0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77,
+	0x61, 0x72, 0x65, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73,
+	0x63, 0x69, 0x70, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x63, 0x69, 0x70, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x41, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e,
+	0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x69, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x52, 0x08, 0x66, 0x61,
+	0x69, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x22, 0xfc, 0x01, 0x0a, 0x08, 0x46, 0x61, 0x69, 0x72, 0x6e,
+	0x65, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6b, 0x65, 0x79, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12,
+	0x49, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x31, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61,
+	0x69, 0x72, 0x6e, 0x65, 0x73, 0x73, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x6d, 0x61,
+	0x78, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x54, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x64, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x53, 0x68,
+	0x61, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x20, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x69, 0x70, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x46, 0x4f, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x4c, 0x49, 0x46, 0x4f, 0x10, 0x01, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65,
+	0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x71,
+	0x75, 0x65, 0x75, 0x65, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_queue_v1_queue_proto_rawDescOnce sync.Once
+	file_gateway_middleware_queue_v1_queue_proto_rawDescData = file_gateway_middleware_queue_v1_queue_proto_rawDesc
+)
+
+func file_gateway_middleware_queue_v1_queue_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_queue_v1_queue_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_queue_v1_queue_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_queue_v1_queue_proto_rawDescData)
+	})
+	return file_gateway_middleware_queue_v1_queue_proto_rawDescData
+}
+
+var file_gateway_middleware_queue_v1_queue_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_gateway_middleware_queue_v1_queue_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_gateway_middleware_queue_v1_queue_proto_goTypes = []interface{}{
+	(Discipline)(0),             // 0: gateway.middleware.queue.v1.Discipline
+	(*Queue)(nil),               // 1: gateway.middleware.queue.v1.Queue
+	(*Fairness)(nil),            // 2: gateway.middleware.queue.v1.Fairness
+	nil,                         // 3: gateway.middleware.queue.v1.Fairness.SharesEntry
+	(*durationpb.Duration)(nil), // 4: google.protobuf.Duration
+}
+var file_gateway_middleware_queue_v1_queue_proto_depIdxs = []int32{
+	4, // 0: gateway.middleware.queue.v1.Queue.max_age:type_name -> google.protobuf.Duration
+	0, // 1: gateway.middleware.queue.v1.Queue.discipline:type_name -> gateway.middleware.queue.v1.Discipline
+	2, // 2: gateway.middleware.queue.v1.Queue.fairness:type_name -> gateway.middleware.queue.v1.Fairness
+	3, // 3: gateway.middleware.queue.v1.Fairness.shares:type_name -> gateway.middleware.queue.v1.Fairness.SharesEntry
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_queue_v1_queue_proto_init() }
+func file_gateway_middleware_queue_v1_queue_proto_init() {
+	if File_gateway_middleware_queue_v1_queue_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_queue_v1_queue_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Queue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_gateway_middleware_queue_v1_queue_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Fairness); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_queue_v1_queue_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_queue_v1_queue_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_queue_v1_queue_proto_depIdxs,
+		EnumInfos:         file_gateway_middleware_queue_v1_queue_proto_enumTypes,
+		MessageInfos:      file_gateway_middleware_queue_v1_queue_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_queue_v1_queue_proto = out.File
+	file_gateway_middleware_queue_v1_queue_proto_rawDesc = nil
+	file_gateway_middleware_queue_v1_queue_proto_goTypes = nil
+	file_gateway_middleware_queue_v1_queue_proto_depIdxs = nil
+}
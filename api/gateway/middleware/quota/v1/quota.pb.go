@@ -0,0 +1,231 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.2
+// source: gateway/middleware/quota/v1/quota.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Quota middleware config.
+// It limits the requests of each user per window, the windows are aligned to the unix epoch,
+// e.g. the daily quota is reset at 00:00 UTC.
+type Quota struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// max requests per window
+	Limit int64 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// default is 24h
+	Window *durationpb.Duration `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+	// user identifier of the requests, the requests without it share one quota.
+	//
+	// Types that are assignable to Key:
+	//	*Quota_ByClientIp
+	//	*Quota_ByHeader
+	Key isQuota_Key `protobuf_oneof:"key"`
+	// status code of the exhausted requests, default is 429.
+	StatusCode int32 `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+}
+
+func (x *Quota) Reset() {
+	*x = Quota{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_quota_v1_quota_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quota) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quota) ProtoMessage() {}
+
+func (x *Quota) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_quota_v1_quota_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quota.ProtoReflect.Descriptor instead.
+func (*Quota) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_quota_v1_quota_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Quota) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Quota) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
+	}
+	return nil
+}
+
+func (m *Quota) GetKey() isQuota_Key {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+func (x *Quota) GetByClientIp() bool {
+	if x, ok := x.GetKey().(*Quota_ByClientIp); ok {
+		return x.ByClientIp
+	}
+	return false
+}
+
+func (x *Quota) GetByHeader() string {
+	if x, ok := x.GetKey().(*Quota_ByHeader); ok {
+		return x.ByHeader
+	}
+	return ""
+}
+
+func (x *Quota) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+type isQuota_Key interface {
+	isQuota_Key()
+}
+
+type Quota_ByClientIp struct {
+	ByClientIp bool `protobuf:"varint,3,opt,name=by_client_ip,json=byClientIp,proto3,oneof"`
+}
+
+type Quota_ByHeader struct {
+	ByHeader string `protobuf:"bytes,4,opt,name=by_header,json=byHeader,proto3,oneof"`
+}
+
+func (*Quota_ByClientIp) isQuota_Key() {}
+
+func (*Quota_ByHeader) isQuota_Key() {}
+
+var File_gateway_middleware_quota_v1_quota_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_quota_v1_quota_proto_rawDesc = []byte{
+	0x0a, 0x27, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x71, 0x75, 0x6f, 0x74, 0x61, 0x2f, 0x76, 0x31, 0x2f, 0x71, 0x75,
+	0x6f, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x71, 0x75,
+	0x6f, 0x74, 0x61, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xbb, 0x01, 0x0a, 0x05, 0x51, 0x75, 0x6f, 0x74, 0x61,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x79, 0x5f,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x00, 0x52, 0x0a, 0x62, 0x79, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x70, 0x12, 0x1d, 0x0a,
+	0x09, 0x62, 0x79, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x08, 0x62, 0x79, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x05, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74,
+	0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79,
+	0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x71, 0x75, 0x6f, 0x74,
+	0x61, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_quota_v1_quota_proto_rawDescOnce sync.Once
+	file_gateway_middleware_quota_v1_quota_proto_rawDescData = file_gateway_middleware_quota_v1_quota_proto_rawDesc
+)
+
+func file_gateway_middleware_quota_v1_quota_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_quota_v1_quota_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_quota_v1_quota_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_quota_v1_quota_proto_rawDescData)
+	})
+	return file_gateway_middleware_quota_v1_quota_proto_rawDescData
+}
+
+var file_gateway_middleware_quota_v1_quota_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_gateway_middleware_quota_v1_quota_proto_goTypes = []interface{}{
+	(*Quota)(nil),               // 0: gateway.middleware.quota.v1.Quota
+	(*durationpb.Duration)(nil), // 1: google.protobuf.Duration
+}
+var file_gateway_middleware_quota_v1_quota_proto_depIdxs = []int32{
+	1, // 0: gateway.middleware.quota.v1.Quota.window:type_name -> google.protobuf.Duration
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_quota_v1_quota_proto_init() }
+func file_gateway_middleware_quota_v1_quota_proto_init() {
+	if File_gateway_middleware_quota_v1_quota_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_quota_v1_quota_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quota); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_middleware_quota_v1_quota_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Quota_ByClientIp)(nil),
+		(*Quota_ByHeader)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_quota_v1_quota_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_quota_v1_quota_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_quota_v1_quota_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_quota_v1_quota_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_quota_v1_quota_proto = out.File
+	file_gateway_middleware_quota_v1_quota_proto_rawDesc = nil
+	file_gateway_middleware_quota_v1_quota_proto_goTypes = nil
+	file_gateway_middleware_quota_v1_quota_proto_depIdxs = nil
+}
@@ -0,0 +1,238 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.2
+// source: gateway/middleware/requestid/v1/requestid.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Format int32
+
+const (
+	// the random UUID, e.g. 1b4e28ba-2fa1-4d3b-a3f5-ef19b5a7633b.
+	Format_UUID_V4 Format = 0
+	// the UUID sortable by the unix milliseconds, see https://www.rfc-editor.org/rfc/rfc9562#section-5.7
+	Format_UUID_V7 Format = 1
+	// the 26 chars in Crockford's base32 sortable by the unix milliseconds, see https://github.com/ulid/spec
+	Format_ULID Format = 2
+	// the 22 random chars in [0-9A-Za-z].
+	Format_BASE62 Format = 3
+)
+
+// Enum value maps for Format.
+var (
+	Format_name = map[int32]string{
+		0: "UUID_V4",
+		1: "UUID_V7",
+		2: "ULID",
+		3: "BASE62",
+	}
+	Format_value = map[string]int32{
+		"UUID_V4": 0,
+		"UUID_V7": 1,
+		"ULID":    2,
+		"BASE62":  3,
+	}
+)
+
+func (x Format) Enum() *Format {
+	p := new(Format)
+	*p = x
+	return p
+}
+
+func (x Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_gateway_middleware_requestid_v1_requestid_proto_enumTypes[0].Descriptor()
+}
+
+func (Format) Type() protoreflect.EnumType {
+	return &file_gateway_middleware_requestid_v1_requestid_proto_enumTypes[0]
+}
+
+func (x Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Format.Descriptor instead.
+func (Format) EnumDescriptor() ([]byte, []int) {
+	return file_gateway_middleware_requestid_v1_requestid_proto_rawDescGZIP(), []int{0}
+}
+
+// RequestID middleware config, it generates the id of the requests without one,
+// the id is forwarded to the upstream and responded in the same header.
+type RequestID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// default is X-Request-Id.
+	Header string `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Format Format `protobuf:"varint,2,opt,name=format,proto3,enum=gateway.middleware.requestid.v1.Format" json:"format,omitempty"`
+	// replace the incoming ids with the generated ones, e.g. for the untrusted clients.
+	Override bool `protobuf:"varint,3,opt,name=override,proto3" json:"override,omitempty"`
+}
+
+func (x *RequestID) Reset() {
+	*x = RequestID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_requestid_v1_requestid_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestID) ProtoMessage() {}
+
+func (x *RequestID) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_requestid_v1_requestid_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestID.ProtoReflect.Descriptor instead.
+func (*RequestID) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_requestid_v1_requestid_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RequestID) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *RequestID) GetFormat() Format {
+	if x != nil {
+		return x.Format
+	}
+	return Format_UUID_V4
+}
+
+func (x *RequestID) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
+}
+
+var File_gateway_middleware_requestid_v1_requestid_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_requestid_v1_requestid_proto_rawDesc = []byte{
+	0x0a, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x64, 0x2f, 0x76,
+	0x31, 0x2f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x1f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c,
+	0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x64, 0x2e,
+	0x76, 0x31, 0x22, 0x80, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x44,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x3f, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x67, 0x61, 0x74, 0x65, 0x77,
+	0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x2a, 0x38, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x55, 0x49, 0x44, 0x5f, 0x56, 0x34, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x55, 0x55, 0x49, 0x44, 0x5f, 0x56, 0x37, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x55, 0x4c, 0x49,
+	0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x41, 0x53, 0x45, 0x36, 0x32, 0x10, 0x03, 0x42,
+	0x42, 0x5a, 0x40, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f,
+	0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64,
+	0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x64,
+	0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_requestid_v1_requestid_proto_rawDescOnce sync.Once
+	file_gateway_middleware_requestid_v1_requestid_proto_rawDescData = file_gateway_middleware_requestid_v1_requestid_proto_rawDesc
+)
+
+func file_gateway_middleware_requestid_v1_requestid_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_requestid_v1_requestid_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_requestid_v1_requestid_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_requestid_v1_requestid_proto_rawDescData)
+	})
+	return file_gateway_middleware_requestid_v1_requestid_proto_rawDescData
+}
+
+var file_gateway_middleware_requestid_v1_requestid_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_gateway_middleware_requestid_v1_requestid_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_gateway_middleware_requestid_v1_requestid_proto_goTypes = []interface{}{
+	(Format)(0),       // 0: gateway.middleware.requestid.v1.Format
+	(*RequestID)(nil), // 1: gateway.middleware.requestid.v1.RequestID
+}
+var file_gateway_middleware_requestid_v1_requestid_proto_depIdxs = []int32{
+	0, // 0: gateway.middleware.requestid.v1.RequestID.format:type_name -> gateway.middleware.requestid.v1.Format
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_requestid_v1_requestid_proto_init() }
+func file_gateway_middleware_requestid_v1_requestid_proto_init() {
+	if File_gateway_middleware_requestid_v1_requestid_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_requestid_v1_requestid_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestID); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_requestid_v1_requestid_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_requestid_v1_requestid_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_requestid_v1_requestid_proto_depIdxs,
+		EnumInfos:         file_gateway_middleware_requestid_v1_requestid_proto_enumTypes,
+		MessageInfos:      file_gateway_middleware_requestid_v1_requestid_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_requestid_v1_requestid_proto = out.File
+	file_gateway_middleware_requestid_v1_requestid_proto_rawDesc = nil
+	file_gateway_middleware_requestid_v1_requestid_proto_goTypes = nil
+	file_gateway_middleware_requestid_v1_requestid_proto_depIdxs = nil
+}
@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.2
+// source: gateway/middleware/throttle/v1/throttle.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Throttle middleware config.
+// The requests of the tenants over the threshold are delayed in proportion to the excess rate,
+// the delay reaches max_delay once the rate is twice of the threshold.
+type Throttle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// soft threshold of requests per second of each tenant
+	Threshold float64 `protobuf:"fixed64,1,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// default is 1s
+	MaxDelay *durationpb.Duration `protobuf:"bytes,2,opt,name=max_delay,json=maxDelay,proto3" json:"max_delay,omitempty"`
+	// tenant identifier of the requests, the requests without it are not throttled.
+	//
+	// Types that are assignable to Tenant:
+	//	*Throttle_ByClientIp
+	//	*Throttle_ByHeader
+	Tenant isThrottle_Tenant `protobuf_oneof:"tenant"`
+	// tenant to the class name labeled in metrics, the others are labeled as "default".
+	TenantClasses map[string]string `protobuf:"bytes,5,rep,name=tenant_classes,json=tenantClasses,proto3" json:"tenant_classes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Throttle) Reset() {
+	*x = Throttle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_gateway_middleware_throttle_v1_throttle_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Throttle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Throttle) ProtoMessage() {}
+
+func (x *Throttle) ProtoReflect() protoreflect.Message {
+	mi := &file_gateway_middleware_throttle_v1_throttle_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Throttle.ProtoReflect.Descriptor instead.
+func (*Throttle) Descriptor() ([]byte, []int) {
+	return file_gateway_middleware_throttle_v1_throttle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Throttle) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Throttle) GetMaxDelay() *durationpb.Duration {
+	if x != nil {
+		return x.MaxDelay
+	}
+	return nil
+}
+
+func (m *Throttle) GetTenant() isThrottle_Tenant {
+	if m != nil {
+		return m.Tenant
+	}
+	return nil
+}
+
+func (x *Throttle) GetByClientIp() bool {
+	if x, ok := x.GetTenant().(*Throttle_ByClientIp); ok {
+		return x.ByClientIp
+	}
+	return false
+}
+
+func (x *Throttle) GetByHeader() string {
+	if x, ok := x.GetTenant().(*Throttle_ByHeader); ok {
+		return x.ByHeader
+	}
+	return ""
+}
+
+func (x *Throttle) GetTenantClasses() map[string]string {
+	if x != nil {
+		return x.TenantClasses
+	}
+	return nil
+}
+
+type isThrottle_Tenant interface {
+	isThrottle_Tenant()
+}
+
+type Throttle_ByClientIp struct {
+	ByClientIp bool `protobuf:"varint,3,opt,name=by_client_ip,json=byClientIp,proto3,oneof"`
+}
+
+type Throttle_ByHeader struct {
+	ByHeader string `protobuf:"bytes,4,opt,name=by_header,json=byHeader,proto3,oneof"`
+}
+
+func (*Throttle_ByClientIp) isThrottle_Tenant() {}
+
+func (*Throttle_ByHeader) isThrottle_Tenant() {}
+
+var File_gateway_middleware_throttle_v1_throttle_proto protoreflect.FileDescriptor
+
+var file_gateway_middleware_throttle_v1_throttle_proto_rawDesc = []byte{
+	0x0a, 0x2d, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x2f, 0x76, 0x31,
+	0x2f, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1e, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77,
+	0x61, 0x72, 0x65, 0x2e, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x1a,
+	0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xd3, 0x02, 0x0a, 0x08, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x09, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x36, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c,
+	0x61, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x79, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0a, 0x62, 0x79, 0x43, 0x6c,
+	0x69, 0x65, 0x6e, 0x74, 0x49, 0x70, 0x12, 0x1d, 0x0a, 0x09, 0x62, 0x79, 0x5f, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x62, 0x79, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x62, 0x0a, 0x0e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x5f,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e,
+	0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61,
+	0x72, 0x65, 0x2e, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x2e, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x43, 0x6c,
+	0x61, 0x73, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0d, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x1a, 0x40, 0x0a, 0x12, 0x54, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x42, 0x41, 0x5a, 0x3f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x6b, 0x72, 0x61, 0x74, 0x6f, 0x73, 0x2f, 0x67, 0x61,
+	0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61,
+	0x79, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x74, 0x68, 0x72,
+	0x6f, 0x74, 0x74, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gateway_middleware_throttle_v1_throttle_proto_rawDescOnce sync.Once
+	file_gateway_middleware_throttle_v1_throttle_proto_rawDescData = file_gateway_middleware_throttle_v1_throttle_proto_rawDesc
+)
+
+func file_gateway_middleware_throttle_v1_throttle_proto_rawDescGZIP() []byte {
+	file_gateway_middleware_throttle_v1_throttle_proto_rawDescOnce.Do(func() {
+		file_gateway_middleware_throttle_v1_throttle_proto_rawDescData = protoimpl.X.CompressGZIP(file_gateway_middleware_throttle_v1_throttle_proto_rawDescData)
+	})
+	return file_gateway_middleware_throttle_v1_throttle_proto_rawDescData
+}
+
+var file_gateway_middleware_throttle_v1_throttle_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_gateway_middleware_throttle_v1_throttle_proto_goTypes = []interface{}{
+	(*Throttle)(nil),            // 0: gateway.middleware.throttle.v1.Throttle
+	nil,                         // 1: gateway.middleware.throttle.v1.Throttle.TenantClassesEntry
+	(*durationpb.Duration)(nil), // 2: google.protobuf.Duration
+}
+var file_gateway_middleware_throttle_v1_throttle_proto_depIdxs = []int32{
+	2, // 0: gateway.middleware.throttle.v1.Throttle.max_delay:type_name -> google.protobuf.Duration
+	1, // 1: gateway.middleware.throttle.v1.Throttle.tenant_classes:type_name -> gateway.middleware.throttle.v1.Throttle.TenantClassesEntry
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_gateway_middleware_throttle_v1_throttle_proto_init() }
+func file_gateway_middleware_throttle_v1_throttle_proto_init() {
+	if File_gateway_middleware_throttle_v1_throttle_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_gateway_middleware_throttle_v1_throttle_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Throttle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_gateway_middleware_throttle_v1_throttle_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Throttle_ByClientIp)(nil),
+		(*Throttle_ByHeader)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gateway_middleware_throttle_v1_throttle_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_gateway_middleware_throttle_v1_throttle_proto_goTypes,
+		DependencyIndexes: file_gateway_middleware_throttle_v1_throttle_proto_depIdxs,
+		MessageInfos:      file_gateway_middleware_throttle_v1_throttle_proto_msgTypes,
+	}.Build()
+	File_gateway_middleware_throttle_v1_throttle_proto = out.File
+	file_gateway_middleware_throttle_v1_throttle_proto_rawDesc = nil
+	file_gateway_middleware_throttle_v1_throttle_proto_goTypes = nil
+	file_gateway_middleware_throttle_v1_throttle_proto_depIdxs = nil
+}
@@ -0,0 +1,158 @@
+package certs
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultWatchInterval is the interval of checking the changes of the certificate files.
+const DefaultWatchInterval = 10 * time.Second
+
+var (
+	_metricReloadsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "tls_certificate_reloads_total",
+		Help:      "The total number of the certificate reloads by the result, the failed ones keep the previous certificate",
+	}, []string{"cert", "result"})
+	_metricNotAfter = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "tls_certificate_not_after_seconds",
+		Help:      "The expiry of the certificate in use in unix seconds",
+	}, []string{"cert"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricReloadsTotal)
+	prometheus.MustRegister(_metricNotAfter)
+}
+
+// Manager holds the certificate of the files, the reloaded certificate is used by the new handshakes
+// while the established connections are kept.
+type Manager struct {
+	certFile string
+	keyFile  string
+	cert     atomic.Value
+	lock     sync.Mutex
+	digest   string
+	now      func() time.Time
+}
+
+// NewManager returns the manager of the certificate and key files in PEM, they must be valid.
+func NewManager(certFile, keyFile string) (*Manager, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("certs: both the certificate and key files must be specified")
+	}
+	m := &Manager{certFile: certFile, keyFile: keyFile, now: time.Now}
+	if _, err := m.reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Manager) fileDigest() (string, error) {
+	h := sha256.New()
+	for _, file := range []string{m.certFile, m.keyFile} {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// load reads and validates the certificate, it's not valid if the key doesn't match or it's expired.
+func (m *Manager) load() (*tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(m.certFile, m.keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return nil, err
+	}
+	if now := m.now(); now.Before(cert.Leaf.NotBefore) || now.After(cert.Leaf.NotAfter) {
+		return nil, fmt.Errorf("certs: certificate is not valid at %s: not before %s, not after %s",
+			now.Format(time.RFC3339), cert.Leaf.NotBefore.Format(time.RFC3339), cert.Leaf.NotAfter.Format(time.RFC3339))
+	}
+	return &cert, nil
+}
+
+// reload loads the certificate if the files changed, it reports whether the certificate is replaced.
+func (m *Manager) reload() (bool, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	digest, err := m.fileDigest()
+	if err != nil {
+		_metricReloadsTotal.WithLabelValues(m.certFile, "failure").Inc()
+		return false, err
+	}
+	if digest == m.digest {
+		return false, nil
+	}
+	cert, err := m.load()
+	if err != nil {
+		_metricReloadsTotal.WithLabelValues(m.certFile, "failure").Inc()
+		return false, err
+	}
+	m.cert.Store(cert)
+	m.digest = digest
+	_metricReloadsTotal.WithLabelValues(m.certFile, "success").Inc()
+	_metricNotAfter.WithLabelValues(m.certFile).Set(float64(cert.Leaf.NotAfter.Unix()))
+	return true, nil
+}
+
+// Reload replaces the certificate if the files changed, the invalid ones don't replace the one in use.
+func (m *Manager) Reload() error {
+	replaced, err := m.reload()
+	if err != nil {
+		log.Errorf("Failed to reload the certificate %s, keep the previous one: %+v", m.certFile, err)
+		return err
+	}
+	if replaced {
+		log.Infof("certificate reloaded: %s", m.certFile)
+	}
+	return nil
+}
+
+// Watch reloads the certificate in the interval until the context is done.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = m.Reload()
+		}
+	}
+}
+
+// Certificate returns the certificate in use.
+func (m *Manager) Certificate() *tls.Certificate {
+	return m.cert.Load().(*tls.Certificate)
+}
+
+// GetCertificate is the tls.Config GetCertificate of the servers.
+func (m *Manager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return m.Certificate(), nil
+}
+
+// GetClientCertificate is the tls.Config GetClientCertificate of the clients.
+func (m *Manager) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return m.Certificate(), nil
+}
@@ -0,0 +1,100 @@
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func writeCert(t *testing.T, certFile, keyFile string, serial int64, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    notAfter.Add(-48 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestManagerReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gateway-certs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	if _, err := NewManager(certFile, keyFile); err == nil {
+		t.Fatal("want the missing files rejected")
+	}
+	writeCert(t, certFile, keyFile, 1, time.Now().Add(24*time.Hour))
+	m, err := NewManager(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial := func() int64 {
+		cert, err := m.GetCertificate(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert.Leaf.SerialNumber.Int64()
+	}
+	if got := serial(); got != 1 {
+		t.Fatalf("want the serial 1 but got %d", got)
+	}
+
+	writeCert(t, certFile, keyFile, 2, time.Now().Add(24*time.Hour))
+	if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if got := serial(); got != 2 {
+		t.Fatalf("want the reloaded serial 2 but got %d", got)
+	}
+
+	// the invalid certificates don't replace the one in use
+	failures := testutil.ToFloat64(_metricReloadsTotal.WithLabelValues(certFile, "failure"))
+	if err := ioutil.WriteFile(keyFile, []byte("invalid"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Reload(); err == nil {
+		t.Fatal("want the invalid key rejected")
+	}
+	writeCert(t, certFile, keyFile, 3, time.Now().Add(-time.Hour))
+	if err := m.Reload(); err == nil {
+		t.Fatal("want the expired certificate rejected")
+	}
+	if got := serial(); got != 2 {
+		t.Fatalf("want the serial 2 kept but got %d", got)
+	}
+	if got := testutil.ToFloat64(_metricReloadsTotal.WithLabelValues(certFile, "failure")) - failures; got != 2 {
+		t.Fatalf("want 2 failed reloads but got %v", got)
+	}
+}
@@ -9,14 +9,21 @@ import (
 )
 
 type client struct {
-	applier  *nodeApplier
-	selector selector.Selector
+	applier    *nodeApplier
+	selector   selector.Selector
+	override   *upstreamOverride
+	connCloser *connCloser
+	// hashKey is the key of the consistent hash balancer if set.
+	hashKey *hashKey
 }
 
-func newClient(applier *nodeApplier, selector selector.Selector) *client {
+func newClient(applier *nodeApplier, selector selector.Selector, override *upstreamOverride, connCloser *connCloser, hashKey *hashKey) *client {
 	return &client{
-		applier:  applier,
-		selector: selector,
+		applier:    applier,
+		selector:   selector,
+		override:   override,
+		connCloser: connCloser,
+		hashKey:    hashKey,
 	}
 }
 
@@ -29,7 +36,16 @@ func (c *client) RoundTrip(req *http.Request) (resp *http.Response, err error) {
 	ctx := req.Context()
 	reqOpt, _ := middleware.FromRequestContext(ctx)
 	filter, _ := middleware.SelectorFiltersFromContext(ctx)
-	n, done, err := c.selector.Select(ctx, selector.WithFilter(filter...))
+	if c.override != nil {
+		if pinned, ok := c.override.filter(req); ok {
+			filter = append(filter[:len(filter):len(filter)], pinned)
+		}
+	}
+	selectCtx := ctx
+	if c.hashKey != nil {
+		selectCtx = newHashKeyContext(ctx, c.hashKey.key(req))
+	}
+	n, done, err := c.selector.Select(selectCtx, selector.WithFilter(filter...))
 	if err != nil {
 		return nil, err
 	}
@@ -37,8 +53,12 @@ func (c *client) RoundTrip(req *http.Request) (resp *http.Response, err error) {
 	addr := n.Address()
 	reqOpt.Backends = append(reqOpt.Backends, addr)
 	req.URL.Host = addr
-	req.URL.Scheme = "http"
+	req.URL.Scheme = n.(*node).urlScheme()
 	req.RequestURI = ""
+	var got *gotConn
+	if c.connCloser != nil {
+		req, got = c.connCloser.trace(req)
+	}
 	startAt := time.Now()
 	resp, err = n.(*node).client.Do(req)
 	reqOpt.UpstreamResponseTime = append(reqOpt.UpstreamResponseTime, time.Since(startAt).Seconds())
@@ -48,5 +68,8 @@ func (c *client) RoundTrip(req *http.Request) (resp *http.Response, err error) {
 		return nil, err
 	}
 	reqOpt.UpstreamStatusCode = append(reqOpt.UpstreamStatusCode, resp.StatusCode)
+	if c.connCloser != nil {
+		c.connCloser.after(resp, got)
+	}
 	return resp, nil
 }
@@ -1 +1,456 @@
 package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestUpstreamOverride(t *testing.T) {
+	override, err := newUpstreamOverride(&config.UpstreamOverride{
+		Enabled:      true,
+		TrustedCidrs: []string{"10.0.0.0/8"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes := []selector.Node{
+		newNode("10.0.0.5:8080", config.Protocol_HTTP, nil, nil),
+		newNode("10.0.0.6:8080", config.Protocol_HTTP, nil, nil),
+	}
+	tests := []struct {
+		remoteAddr string
+		target     string
+		want       int
+		result     string
+	}{
+		{remoteAddr: "10.1.1.1:1234", target: "10.0.0.5:8080", want: 1, result: "applied"},
+		{remoteAddr: "10.1.1.1:1234", target: "10.0.0.7:8080", want: 2, result: "unknown"},
+		{remoteAddr: "192.168.1.1:1234", target: "10.0.0.5:8080", want: -1, result: "untrusted"},
+		{remoteAddr: "10.1.1.1:1234", want: -1},
+	}
+	for _, test := range tests {
+		before := testutil.ToFloat64(_metricOverridesTotal.WithLabelValues(test.result))
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = test.remoteAddr
+		if test.target != "" {
+			req.Header.Set(defaultOverrideHeader, test.target)
+		}
+		filter, ok := override.filter(req)
+		if req.Header.Get(defaultOverrideHeader) != "" {
+			t.Fatal("override header should be removed")
+		}
+		if ok {
+			if got := filter(context.Background(), nodes); len(got) != test.want {
+				t.Fatalf("%+v: want %d nodes but got %d", test, test.want, len(got))
+			}
+		} else if test.want != -1 {
+			t.Fatalf("%+v: want filter but got none", test)
+		}
+		if test.result != "" && testutil.ToFloat64(_metricOverridesTotal.WithLabelValues(test.result))-before != 1 {
+			t.Fatalf("%+v: want the override counted as %s", test, test.result)
+		}
+	}
+	if o, _ := newUpstreamOverride(&config.UpstreamOverride{}); o != nil {
+		t.Fatal("override should be disabled by default")
+	}
+	if _, err := newUpstreamOverride(&config.UpstreamOverride{Enabled: true, TrustedCidrs: []string{"invalid"}}); err == nil {
+		t.Fatal("want error on invalid cidr")
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	var conns, handled int64
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&handled, 1)
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt64(&conns, 1)
+			// hold the connection so that the concurrent warmups dial their own
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	warmup([]*node{newNode(strings.TrimPrefix(srv.URL, "http://"), config.Protocol_HTTP, nil, nil)}, 3)
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt64(&conns) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if n := atomic.LoadInt64(&conns); n != 3 {
+		t.Fatalf("want 3 warmup connections, got: %d", n)
+	}
+	if n := atomic.LoadInt64(&handled); n != 0 {
+		t.Fatalf("want the warmups not handled by the backend, got: %d", n)
+	}
+
+	// only the added nodes are warmed up
+	warmed := &warmedNodes{}
+	a, b := newNode("a:80", config.Protocol_HTTP, nil, nil), newNode("b:80", config.Protocol_HTTP, nil, nil)
+	if added := warmed.added([]*node{a}); len(added) != 1 {
+		t.Fatalf("want the node added, got: %d", len(added))
+	}
+	if added := warmed.added([]*node{a, b}); len(added) != 1 || added[0] != b {
+		t.Fatalf("want only the new node added, got: %v", added)
+	}
+	warmed.added([]*node{b})
+	if added := warmed.added([]*node{a, b}); len(added) != 1 || added[0] != a {
+		t.Fatalf("want the node added again after removed, got: %v", added)
+	}
+
+	if err := checkWarmup(&config.Endpoint{Protocol: config.Protocol_GRPC, WarmupConnections: 2}); err == nil {
+		t.Fatal("want error on multiple warmup connections of gRPC")
+	}
+}
+
+func TestNewDialer(t *testing.T) {
+	noDelay := false
+	tests := []struct {
+		dialer    *config.Dialer
+		keepAlive time.Duration
+		noDelay   bool
+		err       bool
+	}{
+		{dialer: &config.Dialer{}, keepAlive: defaultKeepAlive, noDelay: true},
+		{dialer: &config.Dialer{Keepalive: durationpb.New(10 * time.Second), TcpNodelay: &noDelay}, keepAlive: 10 * time.Second},
+		{dialer: &config.Dialer{Keepalive: durationpb.New(-1)}, keepAlive: -1, noDelay: true},
+		{dialer: &config.Dialer{Keepalive: durationpb.New(time.Millisecond)}, err: true},
+		{dialer: &config.Dialer{Keepalive: durationpb.New(0)}, err: true},
+		{dialer: &config.Dialer{Timeout: durationpb.New(0)}, err: true},
+	}
+	for _, test := range tests {
+		d, noDelay, err := newDialer(test.dialer)
+		if (err != nil) != test.err {
+			t.Fatalf("%v: want error %v but got %v", test.dialer, test.err, err)
+		}
+		if err != nil {
+			continue
+		}
+		if d.KeepAlive != test.keepAlive || noDelay != test.noDelay {
+			t.Errorf("%v: want keepalive %v nodelay %v but got %v %v", test.dialer, test.keepAlive, test.noDelay, d.KeepAlive, noDelay)
+		}
+	}
+	if d, _, _ := newDialer(nil); d != nil {
+		t.Error("want the shared transport if dialer is not set")
+	}
+}
+
+func TestTLSServerName(t *testing.T) {
+	var serverName atomic.Value
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverName.Store(hello.ServerName)
+			return nil, nil
+		},
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	if _, err := newEndpointClient(&config.Endpoint{TlsServerName: "example.com"}); err == nil {
+		t.Fatal("want error for the server name without tls")
+	}
+	client, err := newEndpointClient(&config.Endpoint{Tls: true, TlsServerName: "example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the certificate of httptest is valid for example.com
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	client.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+	n := &node{address: strings.TrimPrefix(srv.URL, "https://"), secure: true}
+	resp, err := client.Get(n.urlScheme() + "://" + n.address + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if got := serverName.Load(); got != "example.com" {
+		t.Fatalf("want SNI example.com but got %v", got)
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	srv.StartTLS()
+	defer srv.Close()
+
+	for _, endpoint := range []*config.Endpoint{
+		{TlsMinVersion: "1.2"},
+		{Tls: true, TlsMinVersion: "1.4"},
+		{Tls: true, TlsCipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}},
+		{Tls: true, TlsMinVersion: "1.3", TlsCipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}},
+		{TlsClientCert: "tls.crt", TlsClientKey: "tls.key"},
+		{Tls: true, TlsClientCert: "tls.crt"},
+		{Tls: true, TlsClientCert: "missing.crt", TlsClientKey: "missing.key"},
+	} {
+		if _, err := newEndpointClient(endpoint); err == nil {
+			t.Errorf("%v: want error", endpoint)
+		}
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+	get := func(endpoint *config.Endpoint) error {
+		client, err := newEndpointClient(endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+	if err := get(&config.Endpoint{Tls: true, TlsMinVersion: "1.2", TlsCipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}}); err != nil {
+		t.Fatal(err)
+	}
+	// the server supports up to TLS 1.2
+	if err := get(&config.Endpoint{Tls: true, TlsMinVersion: "1.3"}); err == nil {
+		t.Fatal("want the handshake below the min version rejected")
+	}
+
+	// the cipher suites are enforced by pinning TLS 1.2 against the servers supporting TLS 1.3
+	srv13 := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv13.TLS = &tls.Config{CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}}
+	srv13.StartTLS()
+	defer srv13.Close()
+	roots.AddCert(srv13.Certificate())
+	client, err := newEndpointClient(&config.Endpoint{Tls: true, TlsCipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+	if _, err := client.Get(srv13.URL); err == nil {
+		t.Fatal("want the handshake without the allowed cipher suites rejected")
+	}
+}
+
+func TestConnCloser(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		w.Write([]byte("ok"))
+	}))
+	srv.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	cc, err := newConnCloser(&config.Endpoint{
+		Protocol:          config.Protocol_HTTP,
+		CloseConnectionOn: []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "500-599"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hc := &http.Client{Transport: &http.Transport{}}
+	do := func(path string) {
+		req, got := cc.trace(httptest.NewRequest("GET", srv.URL+path, nil))
+		req.RequestURI = ""
+		resp, err := hc.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cc.after(resp, got)
+		io.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	do("/ok")
+	do("/ok")
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Fatalf("want the connection reused, got %d connections", n)
+	}
+	do("/error")
+	do("/ok")
+	if n := atomic.LoadInt32(&conns); n != 2 {
+		t.Fatalf("want the connection closed after the error, got %d connections", n)
+	}
+	if cc, _ := newConnCloser(&config.Endpoint{Protocol: config.Protocol_GRPC, CloseConnectionOn: []*config.Condition{{}}}); cc != nil {
+		t.Fatal("want no closer for gRPC")
+	}
+}
+
+func TestKeepalive(t *testing.T) {
+	for _, c := range []*config.Endpoint{
+		{Protocol: config.Protocol_HTTP, Keepalive: &config.Keepalive{Time: durationpb.New(time.Second)}},
+		{Protocol: config.Protocol_GRPC, Keepalive: &config.Keepalive{Time: durationpb.New(time.Millisecond)}},
+		{Protocol: config.Protocol_GRPC, Keepalive: &config.Keepalive{Time: durationpb.New(time.Second), Timeout: durationpb.New(0)}},
+	} {
+		if _, err := newEndpointClient(c); err == nil {
+			t.Fatalf("want error for the keepalive: %v", c.Keepalive)
+		}
+	}
+	client, err := newEndpointClient(&config.Endpoint{
+		Protocol:  config.Protocol_GRPC,
+		Keepalive: &config.Keepalive{Time: durationpb.New(10 * time.Second)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := client.Transport.(*http2.Transport)
+	if transport.ReadIdleTimeout != 10*time.Second || transport.PingTimeout != defaultKeepaliveTimeout {
+		t.Fatalf("unexpected keepalive: %v %v", transport.ReadIdleTimeout, transport.PingTimeout)
+	}
+}
+
+func TestConsistentHash(t *testing.T) {
+	if _, _, err := newSelector(&config.Balancer{Policy: config.BalancerPolicy_CONSISTENT_HASH}); err == nil {
+		t.Fatal("want error for the consistent hash without the hash key")
+	}
+	s, key, err := newSelector(&config.Balancer{
+		Policy:  config.BalancerPolicy_CONSISTENT_HASH,
+		HashKey: &config.HashKey{Headers: []string{"X-Tenant", "X-User"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var nodes []selector.Node
+	for i := 0; i < 5; i++ {
+		nodes = append(nodes, &node{address: "10.0.0." + strconv.Itoa(i) + ":8000"})
+	}
+	s.Apply(nodes)
+	pick := func(tenant, user string) string {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Tenant", tenant)
+		req.Header.Set("X-User", user)
+		n, _, err := s.Select(newHashKeyContext(context.Background(), key.key(req)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n.Address()
+	}
+	picked := pick("a", "1")
+	for i := 0; i < 10; i++ {
+		if got := pick("a", "1"); got != picked {
+			t.Fatalf("want the same key mapped to %s but got %s", picked, got)
+		}
+	}
+	// the key is stable regardless of the order of the nodes
+	s.Apply([]selector.Node{nodes[4], nodes[3], nodes[2], nodes[1], nodes[0]})
+	if got := pick("a", "1"); got != picked {
+		t.Fatalf("want the same key mapped to %s after reordering but got %s", picked, got)
+	}
+	hits := map[string]struct{}{}
+	for i := 0; i < 100; i++ {
+		hits[pick("a", strconv.Itoa(i))] = struct{}{}
+	}
+	if len(hits) < 3 {
+		t.Fatalf("want the keys spread over the nodes but got %v", hits)
+	}
+	if got := key.key(httptest.NewRequest("GET", "/", nil)); got != "" {
+		t.Fatalf("want empty key without the headers but got %q", got)
+	}
+}
+
+func TestMaxResponseHeaderBytes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Large", strings.Repeat("x", 4096))
+	}))
+	defer srv.Close()
+
+	if _, err := newEndpointClient(&config.Endpoint{MaxResponseHeaderBytes: -1}); err == nil {
+		t.Fatal("want error for the negative limit")
+	}
+	client, err := newEndpointClient(&config.Endpoint{MaxResponseHeaderBytes: 1024})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(srv.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("want error for the oversized response header")
+	}
+	if !strings.Contains(err.Error(), "server response headers exceeded") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestFollowRedirects(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("/c"))
+	}))
+	defer other.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a":
+			http.Redirect(w, r, "/b", http.StatusFound)
+		case "/b":
+			http.Redirect(w, r, "/c", http.StatusMovedPermanently)
+		case "/other":
+			http.Redirect(w, r, other.URL+"/", http.StatusFound)
+		default:
+			w.Write([]byte(r.URL.Path))
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		endpoint *config.Endpoint
+		path     string
+		status   int
+		location string
+	}{
+		// the redirects are followed by default
+		{endpoint: &config.Endpoint{}, path: "/a", status: http.StatusOK},
+		{endpoint: &config.Endpoint{}, path: "/other", status: http.StatusOK},
+		{endpoint: &config.Endpoint{PassRedirects: true}, path: "/a", status: http.StatusFound, location: "/b"},
+		{endpoint: &config.Endpoint{MaxResponseHeaderBytes: 1024, PassRedirects: true}, path: "/a", status: http.StatusFound, location: "/b"},
+		{endpoint: &config.Endpoint{FollowRedirects: &config.FollowRedirects{}}, path: "/a", status: http.StatusOK},
+		{endpoint: &config.Endpoint{FollowRedirects: &config.FollowRedirects{MaxRedirects: 1}}, path: "/a", status: http.StatusMovedPermanently, location: "/c"},
+		{endpoint: &config.Endpoint{FollowRedirects: &config.FollowRedirects{}}, path: "/other", status: http.StatusFound, location: other.URL + "/"},
+	}
+	for _, test := range tests {
+		client, err := newEndpointClient(test.endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client, err = withRedirects(client, test.endpoint)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if client == nil {
+			client = _globalClient
+		}
+		resp, err := client.Get(srv.URL + test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != test.status || resp.Header.Get("Location") != test.location {
+			t.Fatalf("%+v: want %d %s but got %d %s", test.endpoint, test.status, test.location, resp.StatusCode, resp.Header.Get("Location"))
+		}
+		if test.status == http.StatusOK && string(body) != "/c" {
+			t.Fatalf("want the final response of /c but got %s", body)
+		}
+	}
+	if _globalClient.CheckRedirect != nil || _globalH2Client.CheckRedirect != nil {
+		t.Fatal("want the shared clients following the redirects")
+	}
+	if _, err := withRedirects(nil, &config.Endpoint{FollowRedirects: &config.FollowRedirects{}, PassRedirects: true}); err == nil {
+		t.Fatal("want error with both follow_redirects and pass_redirects")
+	}
+}
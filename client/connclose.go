@@ -0,0 +1,101 @@
+package client
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/proxy/condition"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _metricForcedClosesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "client_forced_connection_closes_total",
+	Help:      "The total number of upstream connections closed by the close conditions",
+}, []string{"protocol"})
+
+func init() {
+	prometheus.MustRegister(_metricForcedClosesTotal)
+}
+
+// connCloser closes the upstream connections after the responses matched the conditions,
+// so that the connections in a bad state are not reused.
+type connCloser struct {
+	conditions []condition.Condition
+	closes     prometheus.Counter
+}
+
+// newConnCloser returns nil if no condition is configured, it's only for HTTP/1
+// since the HTTP/2 connections are shared by the concurrent requests.
+func newConnCloser(endpoint *config.Endpoint) (*connCloser, error) {
+	if len(endpoint.CloseConnectionOn) == 0 || endpoint.Protocol == config.Protocol_GRPC {
+		return nil, nil
+	}
+	conditions, err := condition.ParseConditon(endpoint.CloseConnectionOn...)
+	if err != nil {
+		return nil, err
+	}
+	return &connCloser{
+		conditions: conditions,
+		closes:     _metricForcedClosesTotal.WithLabelValues(endpoint.Protocol.String()),
+	}, nil
+}
+
+// gotConn is the connection of the request reported by httptrace.
+type gotConn struct {
+	lock sync.Mutex
+	conn net.Conn
+}
+
+func (g *gotConn) get() net.Conn {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.conn
+}
+
+func (c *connCloser) trace(req *http.Request) (*http.Request, *gotConn) {
+	got := &gotConn{}
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			got.lock.Lock()
+			got.conn = info.Conn
+			got.lock.Unlock()
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), got
+}
+
+// after closes the connection once the response body is closed if the response matched the conditions.
+func (c *connCloser) after(resp *http.Response, got *gotConn) {
+	conn := got.get()
+	if conn == nil || !condition.JudgeConditons(c.conditions, resp, false) {
+		return
+	}
+	if resp.Body == nil {
+		conn.Close()
+		c.closes.Inc()
+		return
+	}
+	resp.Body = &closingBody{ReadCloser: resp.Body, conn: conn, closes: c.closes}
+}
+
+type closingBody struct {
+	io.ReadCloser
+	conn   net.Conn
+	closes prometheus.Counter
+	once   sync.Once
+}
+
+func (b *closingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.conn.Close()
+		b.closes.Inc()
+	})
+	return err
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"golang.org/x/net/http2"
+)
+
+const defaultKeepAlive = 30 * time.Second
+
+// newDialer returns the dialer of the endpoint, or nil to use the shared transport.
+func newDialer(c *config.Dialer) (*net.Dialer, bool, error) {
+	if c == nil {
+		return nil, false, nil
+	}
+	d := &net.Dialer{
+		Timeout:   _dialTimeout,
+		KeepAlive: defaultKeepAlive,
+	}
+	if c.Timeout != nil {
+		if d.Timeout = c.Timeout.AsDuration(); d.Timeout <= 0 {
+			return nil, false, errors.New("dialer timeout must be greater than zero")
+		}
+	}
+	if c.Keepalive != nil {
+		d.KeepAlive = c.Keepalive.AsDuration()
+		// the keepalive period is set in seconds, so zero and the periods below 1s are rejected
+		if d.KeepAlive >= 0 && d.KeepAlive < time.Second {
+			return nil, false, errors.New("dialer keepalive must be at least 1s or negative to disable")
+		}
+	}
+	noDelay := true
+	if c.TcpNodelay != nil {
+		noDelay = c.GetTcpNodelay()
+	}
+	return d, noDelay, nil
+}
+
+func dialContext(d *net.Dialer, noDelay bool) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := d.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		if tcpConn, ok := conn.(*net.TCPConn); ok && !noDelay {
+			if err := tcpConn.SetNoDelay(false); err != nil {
+				conn.Close()
+				return nil, err
+			}
+		}
+		return conn, nil
+	}
+}
+
+// newDialerClient returns the client of the endpoint with its own transport,
+// or nil if none of the dialer, keepalive and response header limit is specified.
+func newDialerClient(endpoint *config.Endpoint) (*http.Client, error) {
+	d, noDelay, err := newDialer(endpoint.Dialer)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		if endpoint.Keepalive == nil && endpoint.MaxResponseHeaderBytes == 0 {
+			return nil, nil
+		}
+		d, noDelay = &net.Dialer{Timeout: _dialTimeout, KeepAlive: defaultKeepAlive}, true
+	}
+	dial := dialContext(d, noDelay)
+	if endpoint.Protocol == config.Protocol_GRPC {
+		transport := &http2.Transport{
+			AllowHTTP:          true,
+			DisableCompression: true,
+			DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				return dial(context.Background(), network, addr)
+			},
+		}
+		applyKeepalive(transport, endpoint.Keepalive)
+		limitResponseHeader(transport, endpoint)
+		return &http.Client{Transport: transport}, nil
+	}
+	transport := defaultClient().Transport.(*http.Transport)
+	transport.DialContext = dial
+	limitResponseHeader(transport, endpoint)
+	return &http.Client{Transport: transport}, nil
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/registry"
 	"github.com/go-kratos/kratos/v2/selector"
-	"github.com/go-kratos/kratos/v2/selector/p2c"
 )
 
 // Factory is returns service client.
@@ -21,17 +20,40 @@ type Factory func(*config.Endpoint) (http.RoundTripper, error)
 // NewFactory new a client factory.
 func NewFactory(r registry.Discovery) Factory {
 	return func(endpoint *config.Endpoint) (http.RoundTripper, error) {
-		picker := p2c.New()
+		if err := checkWarmup(endpoint); err != nil {
+			return nil, err
+		}
+		override, err := newUpstreamOverride(endpoint.UpstreamOverride)
+		if err != nil {
+			return nil, err
+		}
+		client, err := newEndpointClient(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		client, err = withRedirects(client, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		connCloser, err := newConnCloser(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		picker, hashKey, err := newSelector(endpoint.Balancer)
+		if err != nil {
+			return nil, err
+		}
 		ctx, cancel := context.WithCancel(context.Background())
 		applier := &nodeApplier{
 			cancel:   cancel,
 			endpoint: endpoint,
 			registry: r,
+			client:   client,
 		}
 		if err := applier.apply(ctx, picker); err != nil {
 			return nil, err
 		}
-		return newClient(applier, picker), nil
+		return newClient(applier, picker, override, connCloser, hashKey), nil
 	}
 }
 
@@ -40,6 +62,17 @@ type nodeApplier struct {
 	cancel   context.CancelFunc
 	endpoint *config.Endpoint
 	registry registry.Discovery
+	// client is the client of the endpoint dialer or TLS, the shared ones are used if nil.
+	client *http.Client
+}
+
+func (na *nodeApplier) newNode(addr string, weight *int64, md map[string]string) *node {
+	n := newNode(addr, na.endpoint.Protocol, weight, md)
+	n.secure = na.endpoint.Tls
+	if na.client != nil {
+		n.client = na.client
+	}
+	return n
 }
 
 func (na *nodeApplier) apply(ctx context.Context, dst selector.Selector) error {
@@ -52,10 +85,12 @@ func (na *nodeApplier) apply(ctx context.Context, dst selector.Selector) error {
 		weighted := backend.Weight
 		switch target.Scheme {
 		case "direct":
-			node := newNode(backend.Target, na.endpoint.Protocol, weighted, map[string]string{})
-			nodes = append(nodes, node)
+			n := na.newNode(backend.Target, weighted, map[string]string{})
+			nodes = append(nodes, n)
 			dst.Apply(nodes)
+			warmup([]*node{n}, na.endpoint.WarmupConnections)
 		case "discovery":
+			warmed := &warmedNodes{}
 			existed := AddWatch(ctx, na.registry, target.Endpoint, func(services []*registry.ServiceInstance) error {
 				if atomic.LoadInt64(&na.canceled) == 1 {
 					return ErrCancelWatch
@@ -63,7 +98,10 @@ func (na *nodeApplier) apply(ctx context.Context, dst selector.Selector) error {
 				if len(services) == 0 {
 					return nil
 				}
-				var nodes []selector.Node
+				var (
+					nodes   []selector.Node
+					applied []*node
+				)
 				for _, ser := range services {
 					scheme := strings.ToLower(na.endpoint.Protocol.String())
 					addr, err := parseEndpoint(ser.Endpoints, scheme, false)
@@ -71,10 +109,12 @@ func (na *nodeApplier) apply(ctx context.Context, dst selector.Selector) error {
 						log.Errorf("failed to parse endpoint: %v", err)
 						continue
 					}
-					node := newNode(addr, na.endpoint.Protocol, weighted, ser.Metadata)
+					node := na.newNode(addr, weighted, ser.Metadata)
 					nodes = append(nodes, node)
+					applied = append(applied, node)
 				}
 				dst.Apply(nodes)
+				warmup(warmed.added(applied), na.endpoint.WarmupConnections)
 				return nil
 			})
 			if existed {
@@ -90,4 +130,7 @@ func (na *nodeApplier) apply(ctx context.Context, dst selector.Selector) error {
 func (na *nodeApplier) Cancel() {
 	atomic.StoreInt64(&na.canceled, 1)
 	na.cancel()
+	if na.client != nil {
+		na.client.CloseIdleConnections()
+	}
 }
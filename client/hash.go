@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/selector/node/direct"
+	"github.com/go-kratos/kratos/v2/selector/p2c"
+)
+
+// newSelector returns the selector of the endpoint balancer, and the hash key of the requests if it's consistent hashing.
+func newSelector(c *config.Balancer) (selector.Selector, *hashKey, error) {
+	if c == nil || c.Policy == config.BalancerPolicy_P2C {
+		return p2c.New(), nil, nil
+	}
+	if c.HashKey == nil || (len(c.HashKey.Headers) == 0 && !c.HashKey.ClientIp) {
+		return nil, nil, errors.New("consistent hash requires the hash key headers or client ip")
+	}
+	s := (&selector.DefaultBuilder{
+		Node:     &direct.Builder{},
+		Balancer: &hashBalancerBuilder{},
+	}).Build()
+	return s, &hashKey{headers: c.HashKey.Headers, clientIP: c.HashKey.ClientIp}, nil
+}
+
+type hashKey struct {
+	headers  []string
+	clientIP bool
+}
+
+// key returns the hash key of the request, or empty if none of the parts present.
+func (k *hashKey) key(req *http.Request) string {
+	parts := make([]string, 0, len(k.headers)+1)
+	present := false
+	for _, name := range k.headers {
+		v := req.Header.Get(name)
+		present = present || v != ""
+		parts = append(parts, v)
+	}
+	if k.clientIP {
+		ip := req.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		present = present || ip != ""
+		parts = append(parts, ip)
+	}
+	if !present {
+		return ""
+	}
+	return strings.Join(parts, "\x00")
+}
+
+type hashKeyContextKey struct{}
+
+func newHashKeyContext(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, hashKeyContextKey{}, key)
+}
+
+type hashBalancerBuilder struct{}
+
+func (*hashBalancerBuilder) Build() selector.Balancer {
+	return &hashBalancer{r: rand.New(rand.NewSource(time.Now().UnixNano()))}
+}
+
+// hashBalancer picks the node by the rendezvous hashing of the key,
+// so that only the keys of the changed nodes are remapped, and it's stable among the filtered nodes.
+type hashBalancer struct {
+	mu sync.Mutex
+	r  *rand.Rand
+}
+
+func (b *hashBalancer) Pick(ctx context.Context, nodes []selector.WeightedNode) (selector.WeightedNode, selector.DoneFunc, error) {
+	if len(nodes) == 0 {
+		return nil, nil, selector.ErrNoAvailable
+	}
+	key, _ := ctx.Value(hashKeyContextKey{}).(string)
+	if key == "" {
+		b.mu.Lock()
+		selected := nodes[b.r.Intn(len(nodes))]
+		b.mu.Unlock()
+		return selected, selected.Pick(), nil
+	}
+	var (
+		selected selector.WeightedNode
+		maxScore = math.Inf(-1)
+	)
+	for _, n := range nodes {
+		if score := rendezvousScore(key, n); score > maxScore {
+			selected, maxScore = n, score
+		}
+	}
+	return selected, selected.Pick(), nil
+}
+
+// rendezvousScore returns the weighted score of the node for the key,
+// the initial weight is used since the runtime one is not stable.
+func rendezvousScore(key string, n selector.WeightedNode) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(n.Address()))
+	// the uniform value in (0, 1)
+	u := (float64(h.Sum64()>>11) + 0.5) / (1 << 53)
+	weight := 1.0
+	if w := n.Raw().InitialWeight(); w != nil && *w > 0 {
+		weight = float64(*w)
+	}
+	return -weight / math.Log(u)
+}
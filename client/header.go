@@ -0,0 +1,31 @@
+package client
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"golang.org/x/net/http2"
+)
+
+func validateMaxResponseHeaderBytes(endpoint *config.Endpoint) error {
+	if n := endpoint.MaxResponseHeaderBytes; n < 0 || n > math.MaxUint32 {
+		return errors.New("max response header bytes must be in 0-4294967295")
+	}
+	return nil
+}
+
+// limitResponseHeader applies the limit of the response header to the transport of HTTP/1 or HTTP/2.
+func limitResponseHeader(transport http.RoundTripper, endpoint *config.Endpoint) {
+	n := endpoint.MaxResponseHeaderBytes
+	if n <= 0 {
+		return
+	}
+	switch t := transport.(type) {
+	case *http.Transport:
+		t.MaxResponseHeaderBytes = n
+	case *http2.Transport:
+		t.MaxHeaderListSize = uint32(n)
+	}
+}
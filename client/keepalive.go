@@ -0,0 +1,43 @@
+package client
+
+import (
+	"errors"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"golang.org/x/net/http2"
+)
+
+const (
+	minKeepaliveTime        = time.Second
+	defaultKeepaliveTimeout = 15 * time.Second
+)
+
+// validateKeepalive checks the keepalive of the endpoint, it's only for the HTTP/2 transport of gRPC.
+func validateKeepalive(endpoint *config.Endpoint) error {
+	c := endpoint.Keepalive
+	if c == nil {
+		return nil
+	}
+	if endpoint.Protocol != config.Protocol_GRPC {
+		return errors.New("keepalive is only for the grpc upstreams")
+	}
+	if c.Time.AsDuration() < minKeepaliveTime {
+		return errors.New("keepalive time must be at least 1s")
+	}
+	if c.Timeout != nil && c.Timeout.AsDuration() <= 0 {
+		return errors.New("keepalive timeout must be greater than zero")
+	}
+	return nil
+}
+
+func applyKeepalive(t *http2.Transport, c *config.Keepalive) {
+	if c == nil {
+		return
+	}
+	t.ReadIdleTimeout = c.Time.AsDuration()
+	t.PingTimeout = defaultKeepaliveTimeout
+	if c.Timeout != nil {
+		t.PingTimeout = c.Timeout.AsDuration()
+	}
+}
@@ -84,6 +84,16 @@ type node struct {
 
 	client   *http.Client
 	protocol config.Protocol
+	// secure is whether the node is connected with TLS.
+	secure bool
+}
+
+// urlScheme returns the URL scheme of the requests to the node.
+func (n *node) urlScheme() string {
+	if n.secure {
+		return "https"
+	}
+	return "http"
 }
 
 func (n *node) Scheme() string {
@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultOverrideHeader = "X-Upstream-Override"
+
+var _metricOverridesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "client_upstream_overrides_total",
+	Help:      "The total number of upstream override headers by result",
+}, []string{"result"})
+
+func init() {
+	prometheus.MustRegister(_metricOverridesTotal)
+}
+
+type upstreamOverride struct {
+	header  string
+	trusted []*net.IPNet
+}
+
+func newUpstreamOverride(in *config.UpstreamOverride) (*upstreamOverride, error) {
+	if in == nil || !in.Enabled {
+		return nil, nil
+	}
+	trusted, err := middleware.ParseCIDRs(in.TrustedCidrs)
+	if err != nil {
+		return nil, err
+	}
+	o := &upstreamOverride{
+		header:  defaultOverrideHeader,
+		trusted: trusted,
+	}
+	if in.Header != "" {
+		o.header = in.Header
+	}
+	return o, nil
+}
+
+// filter returns a selector filter which pins the request to the override target,
+// the header is always removed before forwarding to the upstream.
+func (o *upstreamOverride) filter(req *http.Request) (selector.Filter, bool) {
+	target := req.Header.Get(o.header)
+	if target == "" {
+		return nil, false
+	}
+	req.Header.Del(o.header)
+	if !middleware.Trusted(o.trusted, req.RemoteAddr, false) {
+		// the header is sent by the clients at will, so it's not worth a warning
+		log.Debugf("Ignore upstream override from untrusted source: %s", req.RemoteAddr)
+		_metricOverridesTotal.WithLabelValues("untrusted").Inc()
+		return nil, false
+	}
+	return func(_ context.Context, nodes []selector.Node) []selector.Node {
+		for _, n := range nodes {
+			if n.Address() == target {
+				_metricOverridesTotal.WithLabelValues("applied").Inc()
+				return []selector.Node{n}
+			}
+		}
+		log.Debugf("Ignore upstream override to unknown target: %s", target)
+		_metricOverridesTotal.WithLabelValues("unknown").Inc()
+		return nodes
+	}, true
+}
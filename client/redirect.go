@@ -0,0 +1,56 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+const defaultMaxRedirects = 10
+
+// passRedirect passes the redirects of the upstreams through to the clients.
+func passRedirect(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// newCheckRedirect returns the redirect policy of the endpoint, the redirects to the same host are followed
+// up to the max redirects, and the others are passed through.
+func newCheckRedirect(c *config.FollowRedirects) func(*http.Request, []*http.Request) error {
+	max := defaultMaxRedirects
+	if c.MaxRedirects > 0 {
+		max = int(c.MaxRedirects)
+	}
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > max || req.URL.Host != via[0].URL.Host {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+}
+
+// withRedirects returns the client of the endpoint with the redirect policy,
+// the shared clients follow the redirects as the default client of Go.
+func withRedirects(client *http.Client, endpoint *config.Endpoint) (*http.Client, error) {
+	var checkRedirect func(*http.Request, []*http.Request) error
+	switch {
+	case endpoint.FollowRedirects != nil && endpoint.PassRedirects:
+		return nil, errors.New("follow_redirects and pass_redirects are exclusive")
+	case endpoint.FollowRedirects != nil:
+		checkRedirect = newCheckRedirect(endpoint.FollowRedirects)
+	case endpoint.PassRedirects:
+		checkRedirect = passRedirect
+	default:
+		return client, nil
+	}
+	if client == nil {
+		shared := _globalClient
+		if endpoint.Protocol == config.Protocol_GRPC {
+			shared = _globalH2Client
+		}
+		copied := *shared
+		client = &copied
+	}
+	client.CheckRedirect = checkRedirect
+	return client, nil
+}
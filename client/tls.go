@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/certs"
+	"golang.org/x/net/http2"
+)
+
+// newEndpointClient returns the client of the endpoint with its own transport,
+// or nil if none of the dialer, TLS, keepalive and response header limit is specified.
+func newEndpointClient(endpoint *config.Endpoint) (*http.Client, error) {
+	if !endpoint.Tls {
+		if endpoint.TlsServerName != "" {
+			return nil, errors.New("tls server name is only for the tls upstreams")
+		}
+		if endpoint.TlsMinVersion != "" || len(endpoint.TlsCipherSuites) > 0 {
+			return nil, errors.New("tls min version and cipher suites are only for the tls upstreams")
+		}
+		if endpoint.TlsClientCert != "" || endpoint.TlsClientKey != "" {
+			return nil, errors.New("tls client certificate is only for the tls upstreams")
+		}
+	}
+	if err := validateKeepalive(endpoint); err != nil {
+		return nil, err
+	}
+	if err := validateMaxResponseHeaderBytes(endpoint); err != nil {
+		return nil, err
+	}
+	if !endpoint.Tls {
+		return newDialerClient(endpoint)
+	}
+	return newTLSClient(endpoint)
+}
+
+// newTLSClient returns the client connecting to the backends with TLS,
+// the server name is sent as the SNI instead of the backend host if specified.
+func newTLSClient(endpoint *config.Endpoint) (*http.Client, error) {
+	d, noDelay, err := newDialer(endpoint.Dialer)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		d, noDelay = &net.Dialer{Timeout: _dialTimeout, KeepAlive: defaultKeepAlive}, true
+	}
+	dial := dialContext(d, noDelay)
+	tlsConfig, err := newTLSConfig(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint.Protocol == config.Protocol_GRPC {
+		transport := &http2.Transport{
+			DisableCompression: true,
+			TLSClientConfig:    tlsConfig,
+			DialTLS: func(network, addr string, cfg *tls.Config) (net.Conn, error) {
+				conn, err := dial(context.Background(), network, addr)
+				if err != nil {
+					return nil, err
+				}
+				tlsConn := tls.Client(conn, cfg)
+				if err := tlsConn.Handshake(); err != nil {
+					conn.Close()
+					return nil, err
+				}
+				return tlsConn, nil
+			},
+		}
+		applyKeepalive(transport, endpoint.Keepalive)
+		limitResponseHeader(transport, endpoint)
+		return &http.Client{Transport: transport}, nil
+	}
+	transport := defaultClient().Transport.(*http.Transport)
+	transport.DialContext = dial
+	transport.TLSClientConfig = tlsConfig
+	limitResponseHeader(transport, endpoint)
+	return &http.Client{Transport: transport}, nil
+}
+
+var (
+	_clientCertsLock sync.Mutex
+	// _clientCerts are the managers of the client certificates by the files, they're shared by the endpoints
+	// and watched since they're created.
+	_clientCerts = make(map[[2]string]*certs.Manager)
+)
+
+func clientCertManager(certFile, keyFile string) (*certs.Manager, error) {
+	_clientCertsLock.Lock()
+	defer _clientCertsLock.Unlock()
+	key := [2]string{certFile, keyFile}
+	if m, ok := _clientCerts[key]; ok {
+		return m, nil
+	}
+	m, err := certs.NewManager(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	go m.Watch(context.Background(), certs.DefaultWatchInterval)
+	_clientCerts[key] = m
+	return m, nil
+}
+
+var _tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// newTLSConfig returns the TLS config of the backends, the handshakes below the min version
+// or without any of the allowed cipher suites are rejected. The max version is pinned to TLS 1.2
+// with the cipher suites, since Go ignores them under TLS 1.3.
+func newTLSConfig(endpoint *config.Endpoint) (*tls.Config, error) {
+	c := &tls.Config{ServerName: endpoint.TlsServerName}
+	if endpoint.TlsClientCert != "" || endpoint.TlsClientKey != "" {
+		m, err := clientCertManager(endpoint.TlsClientCert, endpoint.TlsClientKey)
+		if err != nil {
+			return nil, err
+		}
+		c.GetClientCertificate = m.GetClientCertificate
+	}
+	if endpoint.TlsMinVersion != "" {
+		version, ok := _tlsVersions[endpoint.TlsMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unknown tls min version: %s", endpoint.TlsMinVersion)
+		}
+		c.MinVersion = version
+	}
+	if len(endpoint.TlsCipherSuites) == 0 {
+		return c, nil
+	}
+	if c.MinVersion == tls.VersionTLS13 {
+		return nil, errors.New("tls cipher suites are not configurable with tls 1.3")
+	}
+	suites := make(map[string]uint16)
+	for _, s := range tls.CipherSuites() {
+		suites[s.Name] = s.ID
+	}
+	for _, name := range endpoint.TlsCipherSuites {
+		id, ok := suites[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure tls cipher suite: %s", name)
+		}
+		c.CipherSuites = append(c.CipherSuites, id)
+	}
+	c.MaxVersion = tls.VersionTLS12
+	return c, nil
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	_warmupTimeout = time.Second
+
+	_metricWarmupTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "client_warmup_connections_total",
+		Help:      "The total number of warmup connections by result",
+	}, []string{"protocol", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricWarmupTotal)
+}
+
+// checkWarmup rejects more than one warmup connection of the gRPC endpoints,
+// the concurrent requests are multiplexed over a single HTTP/2 connection.
+func checkWarmup(endpoint *config.Endpoint) error {
+	if endpoint.Protocol == config.Protocol_GRPC && endpoint.WarmupConnections > 1 {
+		return errors.New("warmup connections of the gRPC endpoint must be at most 1")
+	}
+	return nil
+}
+
+// warmedNodes is the addresses of the nodes of the last update, so that only the added ones are warmed up.
+type warmedNodes struct {
+	lock      sync.Mutex
+	addresses map[string]struct{}
+}
+
+// added returns the nodes not in the last update, the update replaces it.
+func (w *warmedNodes) added(nodes []*node) []*node {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	addresses := make(map[string]struct{}, len(nodes))
+	var added []*node
+	for _, n := range nodes {
+		addresses[n.address] = struct{}{}
+		if _, ok := w.addresses[n.address]; !ok {
+			added = append(added, n)
+		}
+	}
+	w.addresses = addresses
+	return added
+}
+
+// warmup establishes the idle connections to the nodes in background,
+// the concurrent requests make the transport dial a connection for each of them.
+func warmup(nodes []*node, connections uint32) {
+	if connections == 0 || len(nodes) == 0 {
+		return
+	}
+	go func() {
+		var wg sync.WaitGroup
+		for _, n := range nodes {
+			for i := uint32(0); i < connections; i++ {
+				wg.Add(1)
+				go func(n *node) {
+					defer wg.Done()
+					if err := warmupNode(n); err != nil {
+						log.Warnf("failed to warmup connection to %s: %+v", n.address, err)
+						_metricWarmupTotal.WithLabelValues(n.protocol.String(), "failure").Inc()
+						return
+					}
+					_metricWarmupTotal.WithLabelValues(n.protocol.String(), "success").Inc()
+				}(n)
+			}
+		}
+		wg.Wait()
+	}()
+}
+
+// warmupNode opens a connection with an "OPTIONS *" request, which is about the server
+// rather than any of its resources, so that it doesn't reach the handlers of the backend.
+func warmupNode(n *node) error {
+	ctx, cancel := context.WithTimeout(context.Background(), _warmupTimeout)
+	defer cancel()
+	req := (&http.Request{
+		Method:     http.MethodOptions,
+		URL:        &url.URL{Scheme: n.urlScheme(), Host: n.address, Opaque: "*"},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     make(http.Header),
+		Host:       n.address,
+	}).WithContext(ctx)
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	// drain the body so that the connection goes back to the idle pool
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return resp.Body.Close()
+}
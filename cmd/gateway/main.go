@@ -2,10 +2,14 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/go-kratos/gateway/certs"
 	"github.com/go-kratos/gateway/client"
 	"github.com/go-kratos/gateway/config"
 	configLoader "github.com/go-kratos/gateway/config/config-loader"
@@ -19,13 +23,31 @@ import (
 
 	_ "github.com/go-kratos/gateway/discovery/consul"
 	_ "github.com/go-kratos/gateway/discovery/nacos"
+	"github.com/go-kratos/gateway/middleware/apiversion"
 	_ "github.com/go-kratos/gateway/middleware/bbr"
+	_ "github.com/go-kratos/gateway/middleware/bodyrewrite"
+	_ "github.com/go-kratos/gateway/middleware/cache"
+	_ "github.com/go-kratos/gateway/middleware/checksum"
 	"github.com/go-kratos/gateway/middleware/circuitbreaker"
+	_ "github.com/go-kratos/gateway/middleware/compress"
 	_ "github.com/go-kratos/gateway/middleware/cors"
+	_ "github.com/go-kratos/gateway/middleware/encoding"
+	_ "github.com/go-kratos/gateway/middleware/featureflag"
 	_ "github.com/go-kratos/gateway/middleware/logging"
+	"github.com/go-kratos/gateway/middleware/mirror"
+	_ "github.com/go-kratos/gateway/middleware/priority"
+	_ "github.com/go-kratos/gateway/middleware/queue"
+	_ "github.com/go-kratos/gateway/middleware/quota"
+	_ "github.com/go-kratos/gateway/middleware/ratelimit"
+	_ "github.com/go-kratos/gateway/middleware/requestid"
 	_ "github.com/go-kratos/gateway/middleware/rewrite"
+	_ "github.com/go-kratos/gateway/middleware/status"
+	_ "github.com/go-kratos/gateway/middleware/tee"
+	_ "github.com/go-kratos/gateway/middleware/throttle"
 	_ "github.com/go-kratos/gateway/middleware/tracing"
 	_ "github.com/go-kratos/gateway/middleware/transcoder"
+	_ "github.com/go-kratos/gateway/middleware/transform"
+	_ "github.com/go-kratos/gateway/middleware/waf"
 	_ "go.uber.org/automaxprocs"
 
 	"github.com/go-kratos/kratos/v2"
@@ -40,14 +62,20 @@ var (
 	proxyAddr    string
 	proxyConfig  string
 	withDebug    bool
+	withProbe    bool
+	tlsCert      string
+	tlsKey       string
 )
 
 func init() {
 	flag.BoolVar(&withDebug, "debug", false, "enable debug handlers")
+	flag.BoolVar(&withProbe, "probe", false, "enable /livez and /readyz probe handlers, they take over the routes of the same paths")
 	flag.StringVar(&proxyAddr, "addr", ":8080", "proxy address, eg: -addr 0.0.0.0:8080")
-	flag.StringVar(&proxyConfig, "conf", "config.yaml", "config path, eg: -conf config.yaml")
+	flag.StringVar(&proxyConfig, "conf", "config.yaml", "config paths of the files or directories merged in order, eg: -conf config.yaml,prod/")
 	flag.StringVar(&ctrlName, "ctrl.name", os.Getenv("ADVERTISE_NAME"), "control gateway name, eg: gateway")
 	flag.StringVar(&ctrlService, "ctrl.service", "", "control service host, eg: http://127.0.0.1:8000")
+	flag.StringVar(&tlsCert, "tls.cert", "", "TLS certificate file of the proxy, it's reloaded on change or SIGHUP, eg: -tls.cert tls.crt")
+	flag.StringVar(&tlsKey, "tls.key", "", "TLS key file of the proxy, eg: -tls.key tls.key")
 	flag.StringVar(&discoveryDSN, "discovery.dsn", "", "discovery dsn, eg: consul://127.0.0.1:7070?token=secret&datacenter=prod")
 }
 
@@ -71,6 +99,8 @@ func main() {
 		log.Fatalf("failed to new proxy: %v", err)
 	}
 	circuitbreaker.Init(clientFactory)
+	mirror.Init(clientFactory)
+	apiversion.Init(clientFactory)
 
 	ctx := context.Background()
 	var ctrlLoader *configLoader.CtrlConfigLoader
@@ -120,11 +150,31 @@ func main() {
 		}
 		serverHandler = debug.MashupWithDebugHandler(p)
 	}
+	if withProbe {
+		serverHandler = p.ProbeHandler(serverHandler)
+	}
+	proxyServer := server.NewProxy(serverHandler, proxyAddr)
+	proxyServer.ConnState = p.ConnState
+	if tlsCert != "" || tlsKey != "" {
+		certManager, err := certs.NewManager(tlsCert, tlsKey)
+		if err != nil {
+			log.Fatalf("failed to load TLS certificate: %v", err)
+		}
+		proxyServer.TLSConfig = &tls.Config{GetCertificate: certManager.GetCertificate}
+		go certManager.Watch(ctx, certs.DefaultWatchInterval)
+		go func() {
+			sighup := make(chan os.Signal, 1)
+			signal.Notify(sighup, syscall.SIGHUP)
+			for range sighup {
+				_ = certManager.Reload()
+			}
+		}()
+	}
 	app := kratos.New(
 		kratos.Name(bc.Name),
 		kratos.Context(ctx),
 		kratos.Server(
-			server.NewProxy(serverHandler, proxyAddr),
+			proxyServer,
 		),
 	)
 	if err := app.Run(); err != nil {
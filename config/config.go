@@ -6,8 +6,12 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +39,9 @@ type FileLoader struct {
 
 var _jsonOptions = &protojson.UnmarshalOptions{DiscardUnknown: true}
 
+// NewFileLoader returns the loader of the config files, the confPath is a comma-separated list of
+// the files or the directories, e.g. "base.yaml,prod/". the files of a directory are the *.yaml, *.yml
+// and *.json ones in the lexical order. they're merged in order, see Merge.
 func NewFileLoader(confPath string) (*FileLoader, error) {
 	fl := &FileLoader{
 		confPath: confPath,
@@ -64,18 +71,69 @@ func sha256sum(in []byte) string {
 	return hex.EncodeToString(sum[:])
 }
 
+// configFiles returns the config files of the sources in order.
+func (f *FileLoader) configFiles() ([]string, error) {
+	var files []string
+	for _, source := range strings.Split(f.confPath, ",") {
+		if source = strings.TrimSpace(source); source == "" {
+			continue
+		}
+		info, err := os.Stat(source)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, source)
+			continue
+		}
+		entries, err := ioutil.ReadDir(source)
+		if err != nil {
+			return nil, err
+		}
+		// the entries are sorted by the names
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(source, entry.Name()))
+				}
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no config files found: %s", f.confPath)
+	}
+	return files, nil
+}
+
+// configSHA256 returns the digest of the config files, including their names so that the files added
+// to or removed from the directories are also the changes.
 func (f *FileLoader) configSHA256() (string, error) {
-	configData, err := ioutil.ReadFile(f.confPath)
+	files, err := f.configFiles()
 	if err != nil {
 		return "", err
 	}
-	return sha256sum(configData), nil
+	if len(files) == 1 && files[0] == f.confPath {
+		configData, err := ioutil.ReadFile(f.confPath)
+		if err != nil {
+			return "", err
+		}
+		return sha256sum(configData), nil
+	}
+	h := sha256.New()
+	for _, file := range files {
+		configData, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", file, len(configData))
+		h.Write(configData)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
-func (f *FileLoader) Load(_ context.Context) (*configv1.Gateway, error) {
-	log.Infof("loading config file: %s", f.confPath)
-
-	configData, err := ioutil.ReadFile(f.confPath)
+func loadConfigFile(file string) (*configv1.Gateway, error) {
+	configData, err := ioutil.ReadFile(file)
 	if err != nil {
 		return nil, err
 	}
@@ -86,8 +144,29 @@ func (f *FileLoader) Load(_ context.Context) (*configv1.Gateway, error) {
 	}
 	out := &configv1.Gateway{}
 	if err := _jsonOptions.Unmarshal(jsonData, out); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return out, nil
+}
+
+func (f *FileLoader) Load(_ context.Context) (*configv1.Gateway, error) {
+	log.Infof("loading config file: %s", f.confPath)
+
+	files, err := f.configFiles()
+	if err != nil {
 		return nil, err
 	}
+	out, err := loadConfigFile(files[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files[1:] {
+		src, err := loadConfigFile(file)
+		if err != nil {
+			return nil, err
+		}
+		Merge(out, src)
+	}
 	return out, nil
 }
 
@@ -0,0 +1,92 @@
+package config
+
+import (
+	configv1 "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Merge merges the src into the dst, the fields specified by the src override the ones of the dst:
+//   - the endpoints of the same method and path are replaced, the others are appended.
+//   - the middlewares of the same name are replaced in place, the others are appended.
+//   - the other lists are replaced as a whole.
+//   - the maps are merged by the keys, the values of the same key are replaced.
+//   - the messages are merged recursively, except that the middleware options are replaced.
+//
+// The zero values of the scalars are the unspecified ones, so they can't override the dst.
+func Merge(dst, src *configv1.Gateway) {
+	endpoints := mergeEndpoints(dst.Endpoints, src.Endpoints)
+	middlewares := mergeMiddlewares(dst.Middlewares, src.Middlewares)
+	src = proto.Clone(src).(*configv1.Gateway)
+	src.Endpoints, src.Middlewares = nil, nil
+	mergeMessage(dst.ProtoReflect(), src.ProtoReflect())
+	dst.Endpoints, dst.Middlewares = endpoints, middlewares
+}
+
+func endpointKey(e *configv1.Endpoint) string {
+	return e.Method + " " + e.Path
+}
+
+func mergeEndpoints(dst, src []*configv1.Endpoint) []*configv1.Endpoint {
+	if len(src) == 0 {
+		return dst
+	}
+	index := make(map[string]int, len(dst))
+	for i, e := range dst {
+		index[endpointKey(e)] = i
+	}
+	for _, e := range src {
+		e = proto.Clone(e).(*configv1.Endpoint)
+		if i, ok := index[endpointKey(e)]; ok {
+			dst[i] = e
+			continue
+		}
+		index[endpointKey(e)] = len(dst)
+		dst = append(dst, e)
+	}
+	return dst
+}
+
+func mergeMiddlewares(dst, src []*configv1.Middleware) []*configv1.Middleware {
+	if len(src) == 0 {
+		return dst
+	}
+	index := make(map[string]int, len(dst))
+	for i, m := range dst {
+		index[m.Name] = i
+	}
+	for _, m := range src {
+		m = proto.Clone(m).(*configv1.Middleware)
+		if i, ok := index[m.Name]; ok {
+			dst[i] = m
+			continue
+		}
+		index[m.Name] = len(dst)
+		dst = append(dst, m)
+	}
+	return dst
+}
+
+func mergeMessage(dst, src protoreflect.Message) {
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			list := dst.NewField(fd).List()
+			for i := 0; i < v.List().Len(); i++ {
+				list.Append(v.List().Get(i))
+			}
+			dst.Set(fd, protoreflect.ValueOfList(list))
+		case fd.IsMap():
+			m := dst.Mutable(fd).Map()
+			v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+				m.Set(k, v)
+				return true
+			})
+		case fd.Message() != nil && fd.Message().FullName() != "google.protobuf.Any" && dst.Has(fd):
+			mergeMessage(dst.Mutable(fd).Message(), v.Message())
+		default:
+			dst.Set(fd, v)
+		}
+		return true
+	})
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configv1 "github.com/go-kratos/gateway/api/gateway/config/v1"
+	corsv1 "github.com/go-kratos/gateway/api/gateway/middleware/cors/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestMerge(t *testing.T) {
+	dst := &configv1.Gateway{
+		Name:  "helloworld",
+		Hosts: []string{"localhost", "127.0.0.1"},
+		ServiceDefaults: map[string]*configv1.ServiceDefaults{
+			"a": {Timeout: durationpb.New(time.Second)},
+			"b": {Timeout: durationpb.New(time.Second)},
+		},
+		Endpoints: []*configv1.Endpoint{
+			{Path: "/a", Method: "GET", Timeout: durationpb.New(time.Second)},
+			{Path: "/b", Method: "GET"},
+		},
+		Middlewares: []*configv1.Middleware{
+			{Name: "cors", Options: asAny(&corsv1.Cors{AllowOrigins: []string{".google.com"}, AllowCredentials: true})},
+			{Name: "logging"},
+		},
+		Maintenance: &configv1.Maintenance{Body: "down", ExemptPaths: []string{"/healthz"}},
+	}
+	src := &configv1.Gateway{
+		Version: "v2",
+		Hosts:   []string{"example.com"},
+		ServiceDefaults: map[string]*configv1.ServiceDefaults{
+			"b": {Retry: &configv1.Retry{Attempts: 2}},
+		},
+		Endpoints: []*configv1.Endpoint{
+			{Path: "/b", Method: "GET", Timeout: durationpb.New(2 * time.Second)},
+			{Path: "/c", Method: "POST"},
+		},
+		Middlewares: []*configv1.Middleware{
+			{Name: "cors", Options: asAny(&corsv1.Cors{AllowOrigins: []string{".example.com"}})},
+			{Name: "tracing"},
+		},
+		Maintenance: &configv1.Maintenance{Enabled: true, ExemptPaths: []string{"/livez", "/readyz"}},
+	}
+	Merge(dst, src)
+	want := &configv1.Gateway{
+		Name:    "helloworld",
+		Version: "v2",
+		Hosts:   []string{"example.com"},
+		ServiceDefaults: map[string]*configv1.ServiceDefaults{
+			"a": {Timeout: durationpb.New(time.Second)},
+			"b": {Retry: &configv1.Retry{Attempts: 2}},
+		},
+		Endpoints: []*configv1.Endpoint{
+			{Path: "/a", Method: "GET", Timeout: durationpb.New(time.Second)},
+			{Path: "/b", Method: "GET", Timeout: durationpb.New(2 * time.Second)},
+			{Path: "/c", Method: "POST"},
+		},
+		Middlewares: []*configv1.Middleware{
+			{Name: "cors", Options: asAny(&corsv1.Cors{AllowOrigins: []string{".example.com"}})},
+			{Name: "logging"},
+			{Name: "tracing"},
+		},
+		Maintenance: &configv1.Maintenance{Enabled: true, Body: "down", ExemptPaths: []string{"/livez", "/readyz"}},
+	}
+	if !proto.Equal(dst, want) {
+		t.Fatalf("want %v but got %v", want, dst)
+	}
+	// the src is not aliased by the dst
+	src.Endpoints[0].Path = "/changed"
+	src.Hosts[0] = "changed"
+	if dst.Endpoints[1].Path != "/b" || dst.Hosts[0] != "example.com" {
+		t.Fatalf("want the merged config independent of the src but got %v", dst)
+	}
+}
+
+func TestFileLoaderSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gateway-config-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	overrides := filepath.Join(dir, "overrides")
+	if err := os.Mkdir(overrides, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(overrides, "20-prod.yaml"):  "version: v3\n",
+		filepath.Join(overrides, "10-hosts.json"): `{"hosts": ["example.com"], "version": "v2"}`,
+		filepath.Join(overrides, "README.md"):     "not a config",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(name, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fl := &FileLoader{confPath: "./fixtures/config.yaml," + overrides}
+	cfg, err := fl.Load(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := equalTo()
+	want.Version = "v3"
+	want.Hosts = []string{"example.com"}
+	if !proto.Equal(cfg, want) {
+		t.Fatalf("want %v but got %v", want, cfg)
+	}
+	before, err := fl.configSHA256()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(overrides, "30-more.yml"), []byte("name: more\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if after, err := fl.configSHA256(); err != nil || after == before {
+		t.Fatalf("want the digest changed by the added file but got %s %v", after, err)
+	}
+	if _, err := (&FileLoader{confPath: filepath.Join(dir, "missing.yaml")}).Load(context.TODO()); err == nil {
+		t.Fatal("want the missing source rejected")
+	}
+}
@@ -7,6 +7,7 @@ require (
 	github.com/go-kratos/kratos/contrib/registry/consul/v2 v2.0.0-20220318065833-e66a2905ab70
 	github.com/go-kratos/kratos/contrib/registry/nacos/v2 v2.0.0-20220809040010-c407afc81d44
 	github.com/go-kratos/kratos/v2 v2.4.1
+	github.com/go-redis/redis/v8 v8.11.5
 	github.com/google/uuid v1.3.0
 	github.com/gorilla/mux v1.8.0
 	github.com/hashicorp/consul/api v1.12.0
@@ -20,6 +21,7 @@ require (
 	go.uber.org/automaxprocs v1.4.0
 	golang.org/x/net v0.0.0-20220513224357-95641704303c
 	google.golang.org/genproto v0.0.0-20220519153652-3a47de7e79bd
+	google.golang.org/grpc v1.46.2
 	google.golang.org/protobuf v1.28.0
 	sigs.k8s.io/yaml v1.3.0
 )
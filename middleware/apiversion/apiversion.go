@@ -0,0 +1,120 @@
+package apiversion
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/apiversion/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultHeader = "Accept"
+	// _defaultLabel is the version label of the requests sent to the backends of the endpoint.
+	_defaultLabel = "default"
+)
+
+var _metricRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_api_version_total",
+	Help:      "The total number of requests by the resolved API version",
+}, []string{"version"})
+
+func init() {
+	prometheus.MustRegister(_metricRequestsTotal)
+}
+
+// Init registers the API version middleware with the client factory of the version endpoints.
+func Init(clientFactory client.Factory) {
+	middleware.Register("apiversion", New(clientFactory))
+}
+
+type versionRouter struct {
+	header         string
+	pattern        *regexp.Regexp
+	trippers       map[string]http.RoundTripper
+	defaultVersion string
+}
+
+// version returns the configured version of the request, or the default version.
+func (r *versionRouter) version(req *http.Request) string {
+	for _, v := range req.Header.Values(r.header) {
+		m := r.pattern.FindStringSubmatch(v)
+		if m == nil {
+			continue
+		}
+		version := m[0]
+		if len(m) > 1 {
+			version = m[1]
+		}
+		if _, ok := r.trippers[version]; ok {
+			return version
+		}
+	}
+	return r.defaultVersion
+}
+
+// New returns the factory of the API version middleware.
+func New(factory client.Factory) middleware.Factory {
+	return func(c *config.Middleware) (middleware.Middleware, error) {
+		options := &v1.APIVersion{}
+		if c.Options != nil {
+			if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+				return nil, err
+			}
+		}
+		if options.Pattern == "" {
+			return nil, errors.New("apiversion: pattern must be specified")
+		}
+		pattern, err := regexp.Compile(options.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		r := &versionRouter{
+			header:         defaultHeader,
+			pattern:        pattern,
+			trippers:       make(map[string]http.RoundTripper, len(options.Versions)),
+			defaultVersion: options.DefaultVersion,
+		}
+		if options.Header != "" {
+			r.header = options.Header
+		}
+		for _, v := range options.Versions {
+			if v.Name == "" || v.Name == _defaultLabel || v.Endpoint == nil {
+				return nil, fmt.Errorf("apiversion: invalid version: %q", v.Name)
+			}
+			if _, ok := r.trippers[v.Name]; ok {
+				return nil, fmt.Errorf("apiversion: duplicate version: %s", v.Name)
+			}
+			tripper, err := factory(v.Endpoint)
+			if err != nil {
+				return nil, err
+			}
+			r.trippers[v.Name] = tripper
+		}
+		if _, ok := r.trippers[r.defaultVersion]; r.defaultVersion != "" && !ok {
+			return nil, fmt.Errorf("apiversion: unknown default version: %s", r.defaultVersion)
+		}
+		return func(next http.RoundTripper) http.RoundTripper {
+			return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				version := r.version(req)
+				tripper, ok := r.trippers[version]
+				if !ok {
+					_metricRequestsTotal.WithLabelValues(_defaultLabel).Inc()
+					return next.RoundTrip(req)
+				}
+				// the label is bounded by the configured versions
+				_metricRequestsTotal.WithLabelValues(version).Inc()
+				return tripper.RoundTrip(req)
+			})
+		}, nil
+	}
+}
@@ -0,0 +1,67 @@
+package apiversion
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/apiversion/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestAPIVersion(t *testing.T) {
+	factory := func(e *config.Endpoint) (http.RoundTripper, error) {
+		target := e.Backends[0].Target
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Backend": {target}}}, nil
+		}), nil
+	}
+	newOptions := func(defaultVersion string) *config.Middleware {
+		v, err := anypb.New(&v1.APIVersion{
+			Pattern: `application/vnd\.myapi\.(v\d+)\+json`,
+			Versions: []*v1.Version{
+				{Name: "v1", Endpoint: &config.Endpoint{Backends: []*config.Backend{{Target: "v1:8000"}}}},
+				{Name: "v2", Endpoint: &config.Endpoint{Backends: []*config.Backend{{Target: "v2:8000"}}}},
+			},
+			DefaultVersion: defaultVersion,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &config.Middleware{Options: v}
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Backend": {"endpoint"}}}, nil
+	})
+	tests := []struct {
+		defaultVersion string
+		accept         string
+		want           string
+	}{
+		{accept: "application/vnd.myapi.v2+json", want: "v2:8000"},
+		{accept: "application/vnd.myapi.v1+json", want: "v1:8000"},
+		{accept: "application/vnd.myapi.v3+json", want: "endpoint"},
+		{accept: "application/json", want: "endpoint"},
+		{defaultVersion: "v2", accept: "application/json", want: "v2:8000"},
+	}
+	for _, test := range tests {
+		m, err := New(factory)(newOptions(test.defaultVersion))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("GET", "/users", nil)
+		req.Header.Set("Accept", test.accept)
+		resp, err := m(next).RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resp.Header.Get("Backend"); got != test.want {
+			t.Errorf("%s: want %s but got %s", test.accept, test.want, got)
+		}
+	}
+	if _, err := New(factory)(newOptions("v9")); err == nil {
+		t.Fatal("want error for the unknown default version")
+	}
+}
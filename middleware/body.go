@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"strings"
+)
+
+// ErrBodyTooLarge is returned if the decoded body exceeds the limit, e.g. the zip bomb.
+var ErrBodyTooLarge = errors.New("decoded body too large")
+
+// ErrUnsupportedEncoding is returned if the content encoding is not supported.
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+func normalizeEncoding(contentEncoding string) string {
+	encoding := strings.ToLower(strings.TrimSpace(contentEncoding))
+	if encoding == "x-gzip" {
+		return "gzip"
+	}
+	return encoding
+}
+
+// DecodeBody decodes the body of the Content-Encoding for the body-inspecting middlewares,
+// the gzip and deflate are supported, and the decoded body is limited to the max bytes.
+func DecodeBody(contentEncoding string, body []byte, maxBytes int64) ([]byte, error) {
+	var reader io.Reader
+	switch normalizeEncoding(contentEncoding) {
+	case "", "identity":
+		return body, nil
+	case "gzip":
+		r, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		reader = r
+	case "deflate":
+		// the deflate of HTTP is the zlib format, but some clients send the raw deflate
+		r, err := zlib.NewReader(bytes.NewReader(body))
+		if err != nil {
+			reader = flate.NewReader(bytes.NewReader(body))
+		} else {
+			reader = r
+		}
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+	out, err := io.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(out)) > maxBytes {
+		return nil, ErrBodyTooLarge
+	}
+	return out, nil
+}
+
+// EncodeBody encodes the body of the Content-Encoding, it's the reverse of DecodeBody.
+func EncodeBody(contentEncoding string, body []byte) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   io.WriteCloser
+	)
+	switch normalizeEncoding(contentEncoding) {
+	case "", "identity":
+		return body, nil
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	default:
+		return nil, ErrUnsupportedEncoding
+	}
+	if _, err := w.Write(body); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
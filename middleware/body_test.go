@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"strings"
+	"testing"
+)
+
+func TestDecodeBody(t *testing.T) {
+	for _, encoding := range []string{"gzip", "x-gzip", "deflate", "identity"} {
+		encoded, err := EncodeBody(encoding, []byte(`{"name":"foo"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodeBody(encoding, encoded, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(decoded) != `{"name":"foo"}` {
+			t.Fatalf("%s: unexpected decoded body: %s", encoding, decoded)
+		}
+	}
+	// the raw deflate
+	var buf bytes.Buffer
+	w, _ := flate.NewWriter(&buf, flate.DefaultCompression)
+	w.Write([]byte("raw"))
+	w.Close()
+	if decoded, err := DecodeBody("deflate", buf.Bytes(), 64); err != nil || string(decoded) != "raw" {
+		t.Fatalf("want the raw deflate decoded but got %q %v", decoded, err)
+	}
+	bomb, err := EncodeBody("gzip", []byte(strings.Repeat("x", 1<<20)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeBody("gzip", bomb, 1024); err != ErrBodyTooLarge {
+		t.Fatalf("want ErrBodyTooLarge but got %v", err)
+	}
+	if _, err := DecodeBody("br", []byte("x"), 64); err != ErrUnsupportedEncoding {
+		t.Fatalf("want ErrUnsupportedEncoding but got %v", err)
+	}
+}
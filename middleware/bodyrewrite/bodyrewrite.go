@@ -0,0 +1,171 @@
+package bodyrewrite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/bodyrewrite/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const defaultMaxBodyBytes = 1 << 20
+
+func init() {
+	middleware.Register("bodyrewrite", Middleware)
+}
+
+// operation rewrites the JSON body, the body is taken as the object if it's not nil.
+type operation func(body json.RawMessage, object map[string]json.RawMessage) (json.RawMessage, map[string]json.RawMessage)
+
+func newOperation(in *v1.Operation) (operation, error) {
+	switch op := in.Operation.(type) {
+	case *v1.Operation_Wrap:
+		return func(body json.RawMessage, object map[string]json.RawMessage) (json.RawMessage, map[string]json.RawMessage) {
+			if object != nil {
+				// the object may be rewritten by the prior operations
+				b, err := json.Marshal(object)
+				if err != nil {
+					return body, object
+				}
+				body = b
+			}
+			return nil, map[string]json.RawMessage{op.Wrap: body}
+		}, nil
+	case *v1.Operation_Rename:
+		return func(body json.RawMessage, object map[string]json.RawMessage) (json.RawMessage, map[string]json.RawMessage) {
+			if v, ok := object[op.Rename.From]; ok {
+				delete(object, op.Rename.From)
+				object[op.Rename.To] = v
+			}
+			return body, object
+		}, nil
+	case *v1.Operation_SetDefault:
+		value := json.RawMessage(op.SetDefault.Value)
+		if !json.Valid(value) {
+			return nil, fmt.Errorf("bodyrewrite: invalid default value of %s: %s", op.SetDefault.Field, value)
+		}
+		return func(body json.RawMessage, object map[string]json.RawMessage) (json.RawMessage, map[string]json.RawMessage) {
+			if _, ok := object[op.SetDefault.Field]; !ok && object != nil {
+				object[op.SetDefault.Field] = value
+			}
+			return body, object
+		}, nil
+	case *v1.Operation_Drop:
+		return func(body json.RawMessage, object map[string]json.RawMessage) (json.RawMessage, map[string]json.RawMessage) {
+			delete(object, op.Drop)
+			return body, object
+		}, nil
+	default:
+		return nil, fmt.Errorf("bodyrewrite: unknown operation: %T", op)
+	}
+}
+
+func isJSON(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// rewrite applies the operations, it reports false if the body is malformed.
+func rewrite(operations []operation, in []byte) ([]byte, bool) {
+	if !json.Valid(in) {
+		return nil, false
+	}
+	body := json.RawMessage(in)
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(in, &object); err != nil {
+		object = nil
+	}
+	for _, op := range operations {
+		body, object = op(body, object)
+		if object != nil {
+			body = nil
+		}
+	}
+	if object == nil {
+		return body, true
+	}
+	out, err := json.Marshal(object)
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+// Middleware rewrites the JSON request bodies for the legacy backends.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.BodyRewrite{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	operations := make([]operation, 0, len(options.Operations))
+	for _, in := range options.Operations {
+		op, err := newOperation(in)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, op)
+	}
+	maxBodyBytes := int64(defaultMaxBodyBytes)
+	if options.MaxBodyBytes > 0 {
+		maxBodyBytes = options.MaxBodyBytes
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Body == nil || req.Body == http.NoBody || !isJSON(req.Header.Get("Content-Type")) || req.ContentLength > maxBodyBytes {
+				return next.RoundTrip(req)
+			}
+			in, err := io.ReadAll(io.LimitReader(req.Body, maxBodyBytes+1))
+			if err != nil {
+				return nil, err
+			}
+			out, ok := in, false
+			contentEncoding := req.Header.Get("Content-Encoding")
+			if int64(len(in)) <= maxBodyBytes {
+				decoded := in
+				if options.Decompress {
+					// the unsupported encodings and the zip bombs are passed through as is
+					decoded, err = middleware.DecodeBody(contentEncoding, in, maxBodyBytes)
+				}
+				if err == nil {
+					out, ok = rewrite(operations, decoded)
+				}
+			}
+			if ok && options.Decompress {
+				if options.Recompress {
+					out, err = middleware.EncodeBody(contentEncoding, out)
+					if err != nil {
+						return nil, err
+					}
+				} else {
+					req.Header.Del("Content-Encoding")
+				}
+			}
+			if !ok {
+				// pass through the malformed or larger bodies as is
+				req.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(in), req.Body), req.Body}
+				return next.RoundTrip(req)
+			}
+			req.Body.Close()
+			req.Body = io.NopCloser(bytes.NewReader(out))
+			req.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(out)), nil
+			}
+			req.ContentLength = int64(len(out))
+			req.Header.Set("Content-Length", strconv.Itoa(len(out)))
+			return next.RoundTrip(req)
+		})
+	}, nil
+}
@@ -0,0 +1,114 @@
+package bodyrewrite
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/bodyrewrite/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestBodyRewrite(t *testing.T) {
+	v, err := anypb.New(&v1.BodyRewrite{
+		MaxBodyBytes: 64,
+		Operations: []*v1.Operation{
+			{Operation: &v1.Operation_Rename{Rename: &v1.Rename{From: "name", To: "user_name"}}},
+			{Operation: &v1.Operation_SetDefault{SetDefault: &v1.SetDefault{Field: "version", Value: "1"}}},
+			{Operation: &v1.Operation_Drop{Drop: "debug"}},
+			{Operation: &v1.Operation_Wrap{Wrap: "data"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var received string
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		b, _ := io.ReadAll(req.Body)
+		received = string(b)
+		if req.ContentLength >= 0 && req.ContentLength != int64(len(b)) {
+			t.Errorf("want content length %d but got %d", len(b), req.ContentLength)
+		}
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+	tests := []struct {
+		contentType string
+		body        string
+		want        string
+	}{
+		{contentType: "application/json", body: `{"name":"foo","debug":true}`, want: `{"data":{"user_name":"foo","version":1}}`},
+		{contentType: "application/json", body: `[1,2]`, want: `{"data":[1,2]}`},
+		{contentType: "application/json", body: `{"name":`, want: `{"name":`},
+		{contentType: "text/plain", body: `{"name":"foo"}`, want: `{"name":"foo"}`},
+		{contentType: "application/json", body: `{"name":"` + strings.Repeat("x", 64) + `"}`, want: `{"name":"` + strings.Repeat("x", 64) + `"}`},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(test.body))
+		req.Header.Set("Content-Type", test.contentType)
+		req.ContentLength = -1
+		if _, err := next.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		if received != test.want {
+			t.Errorf("%s: want %s but got %s", test.body, test.want, received)
+		}
+	}
+}
+
+func TestBodyRewriteDecompress(t *testing.T) {
+	for _, recompress := range []bool{false, true} {
+		v, err := anypb.New(&v1.BodyRewrite{
+			Decompress: true,
+			Recompress: recompress,
+			Operations: []*v1.Operation{{Operation: &v1.Operation_Wrap{Wrap: "data"}}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := Middleware(&config.Middleware{Options: v})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			received        []byte
+			contentEncoding string
+		)
+		next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			received, _ = io.ReadAll(req.Body)
+			contentEncoding = req.Header.Get("Content-Encoding")
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		}))
+		body, err := middleware.EncodeBody("gzip", []byte(`{"name":"foo"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("POST", "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+		if _, err := next.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		if recompress {
+			if contentEncoding != "gzip" {
+				t.Fatalf("want the recompressed body but got encoding %q", contentEncoding)
+			}
+			if received, err = middleware.DecodeBody("gzip", received, 1024); err != nil {
+				t.Fatal(err)
+			}
+		} else if contentEncoding != "" {
+			t.Fatalf("want the decompressed body without encoding but got %q", contentEncoding)
+		}
+		if string(received) != `{"data":{"name":"foo"}}` {
+			t.Fatalf("unexpected rewritten body: %s", received)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package cache
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/cache/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultTTL          = time.Minute
+	defaultMaxEntries   = 1024
+	defaultMaxBodyBytes = 1 << 20
+
+	// see https://www.rfc-editor.org/rfc/rfc7234#section-5.5.1
+	staleWarning = `110 - "Response is Stale"`
+
+	cacheStatusHeader = "X-Cache"
+	cacheHit          = "HIT"
+	cacheMiss         = "MISS"
+	cacheStale        = "STALE"
+	cacheBypass       = "BYPASS"
+)
+
+var (
+	// _stores are shared by the same options, so that the cached responses are neither dropped on the reloads
+	// nor separated by the endpoints of the global middleware.
+	_stores = struct {
+		sync.Mutex
+		m map[string]*store
+	}{m: make(map[string]*store)}
+
+	_metricStaleServedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_cache_stale_served_total",
+		Help:      "The total number of stale responses served on upstream failure",
+	}, []string{"method", "path"})
+	_metricNotModifiedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_cache_not_modified_total",
+		Help:      "The total number of not modified responses served from cache",
+	}, []string{"method", "path"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricStaleServedTotal)
+	prometheus.MustRegister(_metricNotModifiedTotal)
+	middleware.Register("cache", Middleware)
+}
+
+func cacheKey(req *http.Request) string {
+	return req.Method + " " + req.Host + req.URL.RequestURI()
+}
+
+// varyKey appends the request values of the Vary headers to the base key,
+// see https://www.rfc-editor.org/rfc/rfc7234#section-4.1
+func varyKey(base string, names []string, header http.Header) string {
+	if len(names) == 0 {
+		return base
+	}
+	var b strings.Builder
+	b.WriteString(base)
+	for _, name := range names {
+		b.WriteString("\n")
+		b.WriteString(name)
+		b.WriteString(": ")
+		b.WriteString(strings.Join(header.Values(name), ","))
+	}
+	return b.String()
+}
+
+// varyNames returns the sorted canonical names of the Vary headers, it's false for "Vary: *" which is never matched.
+func varyNames(header http.Header) ([]string, bool) {
+	var names []string
+	for _, v := range header.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if name == "*" {
+				return nil, false
+			}
+			if name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, true
+}
+
+// cacheControl returns the directives of the Cache-Control headers by the lower case names,
+// see https://www.rfc-editor.org/rfc/rfc7234#section-5.2
+func cacheControl(header http.Header) map[string]string {
+	directives := make(map[string]string)
+	for _, v := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(v, ",") {
+			kv := strings.SplitN(strings.TrimSpace(directive), "=", 2)
+			if kv[0] == "" {
+				continue
+			}
+			var value string
+			if len(kv) == 2 {
+				value = strings.Trim(kv[1], `"`)
+			}
+			directives[strings.ToLower(kv[0])] = value
+		}
+	}
+	return directives
+}
+
+func isCacheableRequest(req *http.Request) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	_, noStore := cacheControl(req.Header)["no-store"]
+	return !noStore
+}
+
+// isRevalidateRequest reports whether the request asks for the response validated by the upstream
+// rather than the cached one, the response is still stored.
+func isRevalidateRequest(req *http.Request) bool {
+	cc := cacheControl(req.Header)
+	if _, ok := cc["no-cache"]; ok || cc["max-age"] == "0" {
+		return true
+	}
+	return len(cc) == 0 && strings.EqualFold(req.Header.Get("Pragma"), "no-cache")
+}
+
+// isCacheableResponse reports whether the response is stored in the shared cache, the responses of the requests
+// with the credentials are stored only if they're public, see https://www.rfc-editor.org/rfc/rfc7234#section-3.2
+// the no-cache responses are never stored, since they must be validated by the upstream before each use.
+func isCacheableResponse(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+	if _, ok := resp.Header["Set-Cookie"]; ok {
+		return false
+	}
+	cc := cacheControl(resp.Header)
+	for _, name := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := cc[name]; ok {
+			return false
+		}
+	}
+	if req.Header.Get("Authorization") != "" || req.Header.Get("Cookie") != "" {
+		_, public := cc["public"]
+		return public
+	}
+	return true
+}
+
+// freshness returns the freshness lifetime of the response capped to the ttl,
+// s-maxage takes precedence over max-age and Expires in the shared cache,
+// see https://www.rfc-editor.org/rfc/rfc7234#section-4.2.1
+func freshness(header http.Header, ttl time.Duration, now time.Time) time.Duration {
+	cc := cacheControl(header)
+	for _, name := range []string{"s-maxage", "max-age"} {
+		if v, ok := cc[name]; ok {
+			seconds, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || seconds <= 0 {
+				return 0
+			}
+			if lifetime := time.Duration(seconds) * time.Second; lifetime < ttl {
+				return lifetime
+			}
+			return ttl
+		}
+	}
+	if v := header.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			// the invalid Expires means already expired
+			return 0
+		}
+		date := now
+		if d, err := http.ParseTime(header.Get("Date")); err == nil {
+			date = d
+		}
+		if lifetime := expires.Sub(date); lifetime < ttl {
+			return lifetime
+		}
+	}
+	return ttl
+}
+
+func isUpstreamFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func newResponse(req *http.Request, e *entry) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(e.statusCode) + " " + http.StatusText(e.statusCode),
+		StatusCode:    e.statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.header.Clone(),
+		ContentLength: int64(len(e.body)),
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		Request:       req,
+	}
+}
+
+// setStatusHeaders sets the cache status and the age of cached responses as the CDNs do.
+func setStatusHeaders(resp *http.Response, status string, cached *entry) {
+	if resp == nil {
+		return
+	}
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	resp.Header.Set(cacheStatusHeader, status)
+	if cached != nil {
+		// see https://www.rfc-editor.org/rfc/rfc7234#section-5.1
+		resp.Header.Set("Age", strconv.FormatInt(int64(cached.age(time.Now())/time.Second), 10))
+	}
+}
+
+func loadStore(options *v1.Cache, maxEntries int) (*store, error) {
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	_stores.Lock()
+	defer _stores.Unlock()
+	if s, ok := _stores.m[string(key)]; ok {
+		return s, nil
+	}
+	s := newStore(maxEntries)
+	_stores.m[string(key)] = s
+	return s, nil
+}
+
+// Middleware caches upstream responses in memory.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Cache{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	ttl := defaultTTL
+	if options.Ttl != nil && options.Ttl.AsDuration() > 0 {
+		ttl = options.Ttl.AsDuration()
+	}
+	var maxStale time.Duration
+	if options.MaxStale != nil {
+		maxStale = options.MaxStale.AsDuration()
+	}
+	maxEntries := defaultMaxEntries
+	if options.MaxEntries > 0 {
+		maxEntries = int(options.MaxEntries)
+	}
+	maxBodyBytes := int64(defaultMaxBodyBytes)
+	if options.MaxBodyBytes > 0 {
+		maxBodyBytes = options.MaxBodyBytes
+	}
+	responses, err := loadStore(options, maxEntries)
+	if err != nil {
+		return nil, err
+	}
+	annotate := func(resp *http.Response, status string, cached *entry) {
+		if options.StatusHeaders {
+			setStatusHeaders(resp, status, cached)
+		}
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if !isCacheableRequest(req) {
+				resp, err := next.RoundTrip(req)
+				annotate(resp, cacheBypass, nil)
+				return resp, err
+			}
+			base := cacheKey(req)
+			key := varyKey(base, responses.varyOf(base), req.Header)
+			cached, ok := responses.get(key)
+			if ok && cached.age(time.Now()) < cached.ttl && !isRevalidateRequest(req) {
+				var hit *http.Response
+				if isNotModified(req, cached.header) {
+					_metricNotModifiedTotal.WithLabelValues(req.Method, middleware.PathLabel(req)).Inc()
+					hit = newNotModifiedResponse(req, cached)
+				} else {
+					hit = newResponse(req, cached)
+				}
+				annotate(hit, cacheHit, cached)
+				return hit, nil
+			}
+			resp, err := next.RoundTrip(req)
+			if isUpstreamFailure(resp, err) {
+				if ok && cached.age(time.Now()) < cached.ttl+maxStale {
+					if resp != nil && resp.Body != nil {
+						resp.Body.Close()
+					}
+					_metricStaleServedTotal.WithLabelValues(req.Method, middleware.PathLabel(req)).Inc()
+					stale := newResponse(req, cached)
+					stale.Header.Add("Warning", staleWarning)
+					annotate(stale, cacheStale, cached)
+					return stale, nil
+				}
+				if err == nil {
+					annotate(resp, cacheMiss, nil)
+				}
+				return resp, err
+			}
+			annotate(resp, cacheMiss, nil)
+			now := time.Now()
+			fresh := freshness(resp.Header, ttl, now)
+			vary, ok := varyNames(resp.Header)
+			if !ok || fresh <= 0 || !isCacheableResponse(req, resp) || resp.ContentLength > maxBodyBytes {
+				return resp, nil
+			}
+			body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodyBytes+1))
+			if err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			if int64(len(body)) > maxBodyBytes {
+				// too large to cache, stitch the read part back to the body
+				resp.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+				return resp, nil
+			}
+			resp.Body.Close()
+			header := resp.Header.Clone()
+			header.Del(cacheStatusHeader)
+			responses.set(&entry{
+				key:        varyKey(base, vary, req.Header),
+				base:       base,
+				vary:       vary,
+				statusCode: resp.StatusCode,
+				header:     header,
+				body:       body,
+				storedAt:   now,
+				ttl:        fresh,
+			})
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			return resp, nil
+		})
+	}, nil
+}
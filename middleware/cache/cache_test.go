@@ -0,0 +1,338 @@
+package cache
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/cache/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// newMiddleware builds the middleware with the empty caches, since they're shared by the same options.
+func newMiddleware(t *testing.T, options *v1.Cache) middleware.Middleware {
+	_stores.Lock()
+	_stores.m = make(map[string]*store)
+	_stores.Unlock()
+	v, err := anypb.New(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestCacheStaleIfError(t *testing.T) {
+	m := newMiddleware(t, &v1.Cache{
+		Ttl:      durationpb.New(time.Millisecond * 10),
+		MaxStale: durationpb.New(time.Hour),
+	})
+	calls := 0
+	var upstreamErr error
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if upstreamErr != nil {
+			return nil, upstreamErr
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("hello")),
+		}, nil
+	}))
+	for i := 0; i < 2; i++ {
+		resp, err := next.RoundTrip(httptest.NewRequest("GET", "/hello", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); body != "hello" {
+			t.Fatalf("want hello but got %s", body)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("want 1 upstream call but got %d", calls)
+	}
+
+	time.Sleep(time.Millisecond * 20)
+	upstreamErr = errors.New("connection refused")
+	resp, err := next.RoundTrip(httptest.NewRequest("GET", "/hello", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := readBody(t, resp); body != "hello" {
+		t.Fatalf("want hello but got %s", body)
+	}
+	if resp.Header.Get("Warning") != staleWarning {
+		t.Fatalf("want stale warning but got %q", resp.Header.Get("Warning"))
+	}
+	if _, err := next.RoundTrip(httptest.NewRequest("GET", "/other", nil)); err == nil {
+		t.Fatal("want error without stale response")
+	}
+}
+
+func TestCacheNotModified(t *testing.T) {
+	m := newMiddleware(t, &v1.Cache{})
+	calls := 0
+	lastModified := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		header := http.Header{}
+		header.Set("ETag", `"v1"`)
+		header.Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		header.Set("Content-Type", "text/plain")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader("hello")),
+		}, nil
+	}))
+	if _, err := next.RoundTrip(httptest.NewRequest("GET", "/hello", nil)); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		header string
+		value  string
+		want   int
+	}{
+		{"etag matched", "If-None-Match", `W/"v0", "v1"`, http.StatusNotModified},
+		{"etag mismatched", "If-None-Match", `"v0"`, http.StatusOK},
+		{"not modified since", "If-Modified-Since", lastModified.Format(http.TimeFormat), http.StatusNotModified},
+		{"modified since", "If-Modified-Since", lastModified.Add(-time.Second).Format(http.TimeFormat), http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/hello", nil)
+			req.Header.Set(tt.header, tt.value)
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Fatalf("want status %d, got: %d", tt.want, resp.StatusCode)
+			}
+			if resp.StatusCode == http.StatusNotModified {
+				if resp.Header.Get("ETag") != `"v1"` || resp.Header.Get("Content-Type") != "" {
+					t.Fatalf("unexpected 304 headers: %v", resp.Header)
+				}
+			}
+		})
+	}
+	if calls != 1 {
+		t.Fatalf("want 1 upstream call, got: %d", calls)
+	}
+}
+
+func TestCacheStatusHeaders(t *testing.T) {
+	m := newMiddleware(t, &v1.Cache{StatusHeaders: true})
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader("hello")),
+		}, nil
+	}))
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{method: "GET", want: cacheMiss},
+		{method: "GET", want: cacheHit},
+		{method: "POST", want: cacheBypass},
+	}
+	for _, tt := range tests {
+		resp, err := next.RoundTrip(httptest.NewRequest(tt.method, "/hello", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resp.Header.Get("X-Cache"); got != tt.want {
+			t.Fatalf("want X-Cache %s, got: %s", tt.want, got)
+		}
+		if age := resp.Header.Get("Age"); (tt.want == cacheHit) != (age == "0") {
+			t.Fatalf("unexpected Age header of %s: %q", tt.want, age)
+		}
+	}
+}
+
+func TestCacheVary(t *testing.T) {
+	m := newMiddleware(t, &v1.Cache{})
+	calls := 0
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		header := http.Header{}
+		header.Set("Vary", "accept-encoding")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader("encoding:" + req.Header.Get("Accept-Encoding"))),
+		}, nil
+	}))
+	do := func(encoding string) string {
+		req := httptest.NewRequest("GET", "/hello", nil)
+		if encoding != "" {
+			req.Header.Set("Accept-Encoding", encoding)
+		}
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return readBody(t, resp)
+	}
+	for i := 0; i < 2; i++ {
+		if body := do("gzip"); body != "encoding:gzip" {
+			t.Fatalf("want the gzip variant but got %s", body)
+		}
+		if body := do(""); body != "encoding:" {
+			t.Fatalf("want the identity variant but got %s", body)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("want 2 upstream calls of the variants but got %d", calls)
+	}
+}
+
+func TestCacheCredentials(t *testing.T) {
+	m := newMiddleware(t, &v1.Cache{})
+	calls := 0
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		header := http.Header{}
+		if req.URL.Path == "/public" {
+			header.Set("Cache-Control", "public, max-age=60")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     header,
+			Body:       ioutil.NopCloser(strings.NewReader("user:" + req.Header.Get("Authorization"))),
+		}, nil
+	}))
+	do := func(path, authorization string) string {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", authorization)
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return readBody(t, resp)
+	}
+	do("/private", "alice")
+	if body := do("/private", "bob"); body != "user:bob" {
+		t.Fatalf("want the response of bob but got %s", body)
+	}
+	do("/public", "alice")
+	do("/public", "bob")
+	if calls != 3 {
+		t.Fatalf("want only the public response cached but got %d upstream calls", calls)
+	}
+}
+
+func TestCacheShared(t *testing.T) {
+	v, err := anypb.New(&v1.Cache{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls int
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader("hello"))}, nil
+	})
+	// the builds of the endpoints and the reloads share the cached responses
+	for i := 0; i < 2; i++ {
+		m, err := Middleware(&config.Middleware{Options: v})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := m(next).RoundTrip(httptest.NewRequest("GET", "/shared", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if body := readBody(t, resp); body != "hello" {
+			t.Fatalf("want hello but got %s", body)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("want the upstream called once but got %d", calls)
+	}
+}
+
+func TestCacheFreshness(t *testing.T) {
+	tests := []struct {
+		cacheControl string
+		expires      string
+		calls        int
+	}{
+		{calls: 1},
+		{cacheControl: "max-age=60", calls: 1},
+		{cacheControl: "max-age=0", calls: 2},
+		{cacheControl: "s-maxage=0, max-age=60", calls: 2},
+		{cacheControl: "no-cache", calls: 2},
+		{cacheControl: `no-cache="Set-Cookie"`, calls: 2},
+		{expires: "Thu, 01 Jan 1970 00:00:00 GMT", calls: 2},
+		{expires: "invalid", calls: 2},
+	}
+	for _, test := range tests {
+		m := newMiddleware(t, &v1.Cache{})
+		var calls int
+		next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			header := http.Header{}
+			if test.cacheControl != "" {
+				header.Set("Cache-Control", test.cacheControl)
+			}
+			if test.expires != "" {
+				header.Set("Expires", test.expires)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: ioutil.NopCloser(strings.NewReader("hello"))}, nil
+		}))
+		for i := 0; i < 2; i++ {
+			resp, err := next.RoundTrip(httptest.NewRequest("GET", "/hello", nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+			readBody(t, resp)
+		}
+		if calls != test.calls {
+			t.Errorf("%q %q: want %d upstream calls but got %d", test.cacheControl, test.expires, test.calls, calls)
+		}
+	}
+
+	// the requests of no-cache are validated by the upstream
+	m := newMiddleware(t, &v1.Cache{})
+	var calls int
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader("hello"))}, nil
+	}))
+	for _, cacheControl := range []string{"", "no-cache", "max-age=0", ""} {
+		req := httptest.NewRequest("GET", "/hello", nil)
+		if cacheControl != "" {
+			req.Header.Set("Cache-Control", cacheControl)
+		}
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		readBody(t, resp)
+	}
+	if calls != 3 {
+		t.Fatalf("want 3 upstream calls but got %d", calls)
+	}
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// notModifiedHeaders are the headers kept in 304 responses,
+// see https://www.rfc-editor.org/rfc/rfc7232#section-4.1
+var notModifiedHeaders = []string{"Cache-Control", "Content-Location", "Date", "ETag", "Expires", "Last-Modified", "Vary"}
+
+// isNotModified evaluates the conditional request against the validators of the cached response,
+// If-None-Match takes precedence over If-Modified-Since as described in RFC 7232 section 6.
+func isNotModified(req *http.Request, header http.Header) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		etag := header.Get("ETag")
+		if etag == "" {
+			return false
+		}
+		return matchETag(inm, etag)
+	}
+	ims := req.Header.Get("If-Modified-Since")
+	lm := header.Get("Last-Modified")
+	if ims == "" || lm == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lm)
+	if err != nil {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
+
+// matchETag uses the weak comparison for If-None-Match.
+func matchETag(inm, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(inm, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func newNotModifiedResponse(req *http.Request, e *entry) *http.Response {
+	header := make(http.Header, len(notModifiedHeaders))
+	for _, key := range notModifiedHeaders {
+		for _, v := range e.header.Values(key) {
+			header.Add(key, v)
+		}
+	}
+	return &http.Response{
+		Status:     "304 " + http.StatusText(http.StatusNotModified),
+		StatusCode: http.StatusNotModified,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+		Body:       ioutil.NopCloser(&bytes.Buffer{}),
+		Request:    req,
+	}
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	key string
+	// base is the key without the values of the Vary headers, and vary is the names of them.
+	base       string
+	vary       []string
+	statusCode int
+	header     http.Header
+	body       []byte
+	storedAt   time.Time
+	// ttl is the configured one capped to the freshness lifetime of the response.
+	ttl time.Duration
+}
+
+func (e *entry) age(now time.Time) time.Duration {
+	return now.Sub(e.storedAt)
+}
+
+// store is a LRU store of cached responses.
+type store struct {
+	lock       sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	varies     map[string]*variants
+}
+
+// variants is the Vary names of the latest response of a base key, and the number of the cached ones of it.
+type variants struct {
+	names []string
+	refs  int
+}
+
+func newStore(maxEntries int) *store {
+	return &store{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		varies:     make(map[string]*variants),
+	}
+}
+
+func (s *store) get(key string) (*entry, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	el, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+	s.ll.MoveToFront(el)
+	return el.Value.(*entry), true
+}
+
+// varyOf returns the Vary names of the cached responses of the base key.
+func (s *store) varyOf(base string) []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if v, ok := s.varies[base]; ok {
+		return v.names
+	}
+	return nil
+}
+
+func (s *store) set(e *entry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	v, ok := s.varies[e.base]
+	if !ok {
+		v = &variants{}
+		s.varies[e.base] = v
+	}
+	v.names = e.vary
+	if el, ok := s.items[e.key]; ok {
+		el.Value = e
+		s.ll.MoveToFront(el)
+		return
+	}
+	v.refs++
+	s.items[e.key] = s.ll.PushFront(e)
+	for s.ll.Len() > s.maxEntries {
+		oldest := s.ll.Back().Value.(*entry)
+		s.ll.Remove(s.ll.Back())
+		delete(s.items, oldest.key)
+		if v := s.varies[oldest.base]; v != nil {
+			if v.refs--; v.refs <= 0 {
+				delete(s.varies, oldest.base)
+			}
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package checksum
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/checksum/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultMD5Header    = "Content-MD5"
+	defaultSHA256Header = "X-Checksum-SHA256"
+	defaultMaxBodyBytes = 10 << 20
+	// _verifiedMetadata marks the request verified, so that the retries are not verified again.
+	_verifiedMetadata = "checksum.verified"
+)
+
+var _metricChecksumFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_checksum_failures_total",
+	Help:      "The total number of the requests failed the checksum validation by the algorithm and reason",
+}, []string{"algorithm", "reason"})
+
+func init() {
+	prometheus.MustRegister(_metricChecksumFailures)
+	middleware.Register("checksum", Middleware)
+}
+
+type algorithm struct {
+	name    string
+	header  string
+	newHash func() hash.Hash
+}
+
+// digest returns the digest of the header value, it's hex or base64 encoded.
+func (a *algorithm) digest(value string) ([]byte, bool) {
+	size := a.newHash().Size()
+	if len(value) == hex.EncodedLen(size) {
+		if b, err := hex.DecodeString(value); err == nil {
+			return b, true
+		}
+	}
+	b, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || len(b) != size {
+		return nil, false
+	}
+	return b, true
+}
+
+// Middleware validates the request body against the MD5 or SHA-256 checksum of the client.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Checksum{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.MaxBodyBytes < 0 {
+		return nil, errors.New("checksum: max body bytes must not be negative")
+	}
+	algorithms := []*algorithm{
+		{name: "md5", header: defaultMD5Header, newHash: md5.New},
+		{name: "sha256", header: defaultSHA256Header, newHash: sha256.New},
+	}
+	if options.Md5Header != "" {
+		algorithms[0].header = options.Md5Header
+	}
+	if options.Sha256Header != "" {
+		algorithms[1].header = options.Sha256Header
+	}
+	maxBodyBytes := int64(defaultMaxBodyBytes)
+	if options.MaxBodyBytes > 0 {
+		maxBodyBytes = options.MaxBodyBytes
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			reqOpt, ok := middleware.FromRequestContext(req.Context())
+			if ok {
+				if _, verified := reqOpt.Metadata[_verifiedMetadata]; verified {
+					return next.RoundTrip(req)
+				}
+			}
+			var checked []*algorithm
+			for _, a := range algorithms {
+				if req.Header.Get(a.header) != "" {
+					checked = append(checked, a)
+				}
+			}
+			if len(checked) == 0 {
+				if options.Required {
+					_metricChecksumFailures.WithLabelValues("none", "missing").Inc()
+					return newResponse(http.StatusBadRequest, "checksum required"), nil
+				}
+				return next.RoundTrip(req)
+			}
+			body, err := openBody(req, maxBodyBytes)
+			if err != nil {
+				if errors.Is(err, errBodyTooLarge) {
+					_metricChecksumFailures.WithLabelValues(checked[0].name, "too_large").Inc()
+					return newResponse(http.StatusRequestEntityTooLarge, err.Error()), nil
+				}
+				return nil, err
+			}
+			hashes := make([]hash.Hash, len(checked))
+			writers := make([]io.Writer, len(checked))
+			for i, a := range checked {
+				hashes[i] = a.newHash()
+				writers[i] = hashes[i]
+			}
+			_, err = io.Copy(io.MultiWriter(writers...), body)
+			body.Close()
+			if err != nil {
+				return nil, err
+			}
+			for i, a := range checked {
+				want, ok := a.digest(strings.TrimSpace(req.Header.Get(a.header)))
+				if !ok {
+					_metricChecksumFailures.WithLabelValues(a.name, "invalid").Inc()
+					return newResponse(http.StatusBadRequest, "invalid checksum: "+a.header), nil
+				}
+				if subtle.ConstantTimeCompare(want, hashes[i].Sum(nil)) != 1 {
+					_metricChecksumFailures.WithLabelValues(a.name, "mismatch").Inc()
+					return newResponse(http.StatusBadRequest, "checksum mismatch: "+a.header), nil
+				}
+			}
+			if ok {
+				reqOpt.Metadata[_verifiedMetadata] = "true"
+			}
+			return next.RoundTrip(req)
+		})
+	}, nil
+}
+
+var errBodyTooLarge = errors.New("request body too large")
+
+// openBody returns a reader of the body to be validated, it's the copy of the body buffered for the retries,
+// or the streamed body is buffered up to the limit and replayed for the upstream.
+func openBody(req *http.Request, maxBytes int64) (io.ReadCloser, error) {
+	if req.GetBody != nil {
+		return req.GetBody()
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		return ioutil.NopCloser(&bytes.Buffer{}), nil
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(req.Body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, errBodyTooLarge
+	}
+	req.Body.Close()
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func newResponse(statusCode int, message string) *http.Response {
+	return &http.Response{
+		Status:        http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Header:        http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+		ContentLength: int64(len(message)),
+		Body:          ioutil.NopCloser(strings.NewReader(message)),
+	}
+}
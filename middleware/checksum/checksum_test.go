@@ -0,0 +1,69 @@
+package checksum
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/checksum/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestChecksum(t *testing.T) {
+	options, err := anypb.New(&v1.Checksum{Required: true, MaxBodyBytes: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		// the upstream receives the whole body
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if string(b) != "hello" {
+			return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	tests := []struct {
+		body       string
+		header     http.Header
+		buffered   bool
+		statusCode int
+	}{
+		{body: "hello", header: http.Header{"Content-Md5": []string{"XUFAKrxLKna5cZ2REBfFkg=="}}, statusCode: 200},
+		{body: "hello", header: http.Header{"Content-Md5": []string{"XUFAKrxLKna5cZ2REBfFkg=="}}, buffered: true, statusCode: 200},
+		{body: "hello", header: http.Header{"X-Checksum-Sha256": []string{"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}}, statusCode: 200},
+		{body: "hellO", header: http.Header{"X-Checksum-Sha256": []string{"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"}}, statusCode: 400},
+		{body: "hello", header: http.Header{"Content-Md5": []string{"invalid"}}, statusCode: 400},
+		{body: "hello", statusCode: 400},
+		{body: strings.Repeat("a", 17), header: http.Header{"Content-Md5": []string{"XUFAKrxLKna5cZ2REBfFkg=="}}, statusCode: 413},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", "/upload", strings.NewReader(test.body))
+		if test.buffered {
+			body := test.body
+			req.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(body)), nil
+			}
+		}
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+		resp, err := m(next).RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != test.statusCode {
+			t.Errorf("%s %v: want %d but got %d", test.body, test.header, test.statusCode, resp.StatusCode)
+		}
+	}
+}
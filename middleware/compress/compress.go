@@ -0,0 +1,216 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/compress/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultLevel   = 6
+	defaultMinSize = 1024
+	hintFast       = "fast"
+	hintBest       = "best"
+)
+
+var (
+	_defaultContentTypes = []string{"text/", "application/json", "application/javascript", "application/xml"}
+
+	_metricCompressSeconds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_compress_seconds_total",
+		Help:      "The total time spent on compressing the responses",
+	}, []string{"level"})
+	_metricCompressedBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_compress_bytes_total",
+		Help:      "The total bytes of the responses before and after the compression",
+	}, []string{"level", "stage"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricCompressSeconds)
+	prometheus.MustRegister(_metricCompressedBytes)
+	middleware.Register("compress", Middleware)
+}
+
+type compressor struct {
+	level          int
+	minSize        int64
+	largeThreshold int64
+	largeLevel     int
+	hintHeader     string
+	contentTypes   []string
+}
+
+func newCompressor(options *v1.Compress) (*compressor, error) {
+	c := &compressor{
+		level:          defaultLevel,
+		minSize:        defaultMinSize,
+		largeThreshold: options.LargeBodyThreshold,
+		largeLevel:     gzip.BestSpeed,
+		hintHeader:     options.HintHeader,
+		contentTypes:   _defaultContentTypes,
+	}
+	if options.Level != 0 {
+		c.level = int(options.Level)
+	}
+	if options.LargeBodyLevel != 0 {
+		c.largeLevel = int(options.LargeBodyLevel)
+	}
+	for _, level := range []int{c.level, c.largeLevel} {
+		if level < gzip.BestSpeed || level > gzip.BestCompression {
+			return nil, fmt.Errorf("compress: invalid gzip level: %d", level)
+		}
+	}
+	if options.MinSize > 0 {
+		c.minSize = options.MinSize
+	}
+	if len(options.ContentTypes) > 0 {
+		c.contentTypes = options.ContentTypes
+	}
+	return c, nil
+}
+
+// acceptsGzip reports whether the gzip is acceptable, see https://www.rfc-editor.org/rfc/rfc7231#section-5.3.4
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(params[0]))
+		if coding != "gzip" && coding != "x-gzip" && coding != "*" {
+			continue
+		}
+		if len(params) > 1 {
+			q := strings.TrimSpace(params[1])
+			if strings.HasPrefix(q, "q=") {
+				if v, err := strconv.ParseFloat(q[2:], 64); err == nil && v == 0 {
+					continue
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (c *compressor) compressible(resp *http.Response) bool {
+	if resp.Body == nil || resp.Header.Get("Content-Encoding") != "" || resp.StatusCode < http.StatusOK ||
+		resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusNotModified {
+		return false
+	}
+	if resp.ContentLength >= 0 && resp.ContentLength < c.minSize {
+		return false
+	}
+	contentType := resp.Header.Get("Content-Type")
+	for _, prefix := range c.contentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// chooseLevel returns the level by the request hint, or by the response size.
+func (c *compressor) chooseLevel(req *http.Request, resp *http.Response) int {
+	if c.hintHeader != "" {
+		switch strings.ToLower(req.Header.Get(c.hintHeader)) {
+		case hintFast:
+			return gzip.BestSpeed
+		case hintBest:
+			return gzip.BestCompression
+		}
+	}
+	if c.largeThreshold > 0 && resp.ContentLength > c.largeThreshold {
+		return c.largeLevel
+	}
+	return c.level
+}
+
+// isEventStream reports whether the response is the server-sent events, which is compressed as it's
+// streamed instead of being read ahead.
+func isEventStream(resp *http.Response) bool {
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream")
+}
+
+// replayBody is the body of which the head is read ahead.
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// probeBody reads ahead up to the size of the body with unknown length, it reports whether the body
+// is smaller than the size, and returns the body replaying the read bytes.
+func probeBody(body io.ReadCloser, size int64) (io.ReadCloser, bool, error) {
+	var head bytes.Buffer
+	if _, err := io.CopyN(&head, body, size); err != nil {
+		if err != io.EOF {
+			body.Close()
+			return nil, false, err
+		}
+		return &replayBody{Reader: &head, Closer: body}, true, nil
+	}
+	return &replayBody{Reader: io.MultiReader(&head, body), Closer: body}, false, nil
+}
+
+// Middleware compresses the responses with gzip.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Compress{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	compressor, err := newCompressor(options)
+	if err != nil {
+		return nil, err
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			accepted := req.Method != http.MethodHead && acceptsGzip(req.Header.Get("Accept-Encoding"))
+			resp, err := next.RoundTrip(req)
+			if err != nil || !accepted {
+				return resp, err
+			}
+			resp.Header.Add("Vary", "Accept-Encoding")
+			if !compressor.compressible(resp) {
+				return resp, nil
+			}
+			if resp.ContentLength < 0 && !isEventStream(resp) {
+				var small bool
+				if resp.Body, small, err = probeBody(resp.Body, compressor.minSize); err != nil {
+					return nil, err
+				}
+				if small {
+					return resp, nil
+				}
+			}
+			level := compressor.chooseLevel(req, resp)
+			body, err := newGzipBody(resp.Body, level)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = body
+			resp.ContentLength = -1
+			resp.Header.Del("Content-Length")
+			resp.Header.Set("Content-Encoding", "gzip")
+			// the compressed representation is not byte-for-byte identical
+			if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+				resp.Header.Set("ETag", "W/"+etag)
+			}
+			return resp, nil
+		})
+	}, nil
+}
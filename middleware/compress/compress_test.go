@@ -0,0 +1,121 @@
+package compress
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/compress/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := map[string]bool{
+		"":                  false,
+		"gzip":              true,
+		"br, x-gzip;q=0.5":  true,
+		"gzip;q=0":          false,
+		"*":                 true,
+		"identity, deflate": false,
+	}
+	for acceptEncoding, want := range tests {
+		if got := acceptsGzip(acceptEncoding); got != want {
+			t.Errorf("%q: want %v but got %v", acceptEncoding, want, got)
+		}
+	}
+}
+
+func TestChooseLevel(t *testing.T) {
+	c, err := newCompressor(&v1.Compress{Level: 9, LargeBodyThreshold: 1 << 20, HintHeader: "X-Compression-Level"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		hint   string
+		length int64
+		want   int
+	}{
+		{length: 1 << 10, want: 9},
+		{length: 2 << 20, want: gzip.BestSpeed},
+		{length: -1, want: 9},
+		{hint: "best", length: 2 << 20, want: gzip.BestCompression},
+		{hint: "fast", length: 1 << 10, want: gzip.BestSpeed},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Compression-Level", test.hint)
+		if got := c.chooseLevel(req, &http.Response{ContentLength: test.length}); got != test.want {
+			t.Errorf("%q %d: want level %d but got %d", test.hint, test.length, test.want, got)
+		}
+	}
+	if _, err := newCompressor(&v1.Compress{Level: 10}); err == nil {
+		t.Fatal("want error for the invalid level")
+	}
+}
+
+func TestGzipBody(t *testing.T) {
+	payload := strings.Repeat("hello gateway ", 10000)
+	body, err := newGzipBody(ioutil.NopCloser(strings.NewReader(payload)), gzip.BestSpeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gr, err := gzip.NewReader(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != payload {
+		t.Fatal("want the payload decompressed")
+	}
+}
+
+func TestMinSize(t *testing.T) {
+	m, err := Middleware(&config.Middleware{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		body       string
+		length     int64
+		compressed bool
+	}{
+		{body: "small", length: 5},
+		{body: "small", length: -1},
+		{body: strings.Repeat("a", 2048), length: 2048, compressed: true},
+		{body: strings.Repeat("a", 2048), length: -1, compressed: true},
+	} {
+		next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode:    http.StatusOK,
+				Header:        http.Header{"Content-Type": []string{"text/plain"}},
+				ContentLength: test.length,
+				Body:          ioutil.NopCloser(strings.NewReader(test.body)),
+			}, nil
+		})
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		resp, err := m(next).RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body io.Reader = resp.Body
+		if compressed := resp.Header.Get("Content-Encoding") == "gzip"; compressed != test.compressed {
+			t.Fatalf("%d %d: want compressed %v but got %v", len(test.body), test.length, test.compressed, compressed)
+		} else if compressed {
+			if body, err = gzip.NewReader(resp.Body); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if data, err := io.ReadAll(body); err != nil || string(data) != test.body {
+			t.Fatalf("%d %d: want the body replayed but got %d bytes: %v", len(test.body), test.length, len(data), err)
+		}
+	}
+}
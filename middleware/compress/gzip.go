@@ -0,0 +1,80 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const _readSize = 32 << 10
+
+// gzipBody compresses the upstream body as it's read, so that the response is streamed.
+type gzipBody struct {
+	src     io.ReadCloser
+	buf     bytes.Buffer
+	gw      *gzip.Writer
+	chunk   []byte
+	eof     bool
+	seconds prometheus.Counter
+	in      prometheus.Counter
+	out     prometheus.Counter
+}
+
+func newGzipBody(src io.ReadCloser, level int) (*gzipBody, error) {
+	label := strconv.Itoa(level)
+	b := &gzipBody{
+		src:     src,
+		chunk:   make([]byte, _readSize),
+		seconds: _metricCompressSeconds.WithLabelValues(label),
+		in:      _metricCompressedBytes.WithLabelValues(label, "in"),
+		out:     _metricCompressedBytes.WithLabelValues(label, "out"),
+	}
+	gw, err := gzip.NewWriterLevel(&b.buf, level)
+	if err != nil {
+		return nil, err
+	}
+	b.gw = gw
+	return b, nil
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	for b.buf.Len() == 0 && !b.eof {
+		n, err := b.src.Read(b.chunk)
+		startTime := time.Now()
+		if n > 0 {
+			b.in.Add(float64(n))
+			if _, werr := b.gw.Write(b.chunk[:n]); werr != nil {
+				return 0, werr
+			}
+		}
+		if err == io.EOF {
+			b.eof = true
+			if cerr := b.gw.Close(); cerr != nil {
+				return 0, cerr
+			}
+		} else if err == nil && n > 0 {
+			// flush the compressed bytes so that the client isn't stalled by a slow upstream
+			if ferr := b.gw.Flush(); ferr != nil {
+				return 0, ferr
+			}
+		}
+		b.seconds.Add(time.Since(startTime).Seconds())
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+	}
+	if b.buf.Len() == 0 {
+		return 0, io.EOF
+	}
+	n, _ := b.buf.Read(p)
+	b.out.Add(float64(n))
+	return n, nil
+}
+
+func (b *gzipBody) Close() error {
+	return b.src.Close()
+}
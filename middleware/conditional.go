@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	configv1 "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+// Matcher reports whether the request is matched.
+type Matcher func(*http.Request) bool
+
+// NewMatcher returns the matcher of the config, it matches the requests by all of the path, methods and headers.
+func NewMatcher(c *configv1.MiddlewareMatch) (Matcher, error) {
+	var path *regexp.Regexp
+	if c.Path != "" {
+		expr, err := regexp.Compile(c.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid middleware match path: %w", err)
+		}
+		path = expr
+	}
+	methods := make(map[string]struct{}, len(c.Methods))
+	for _, m := range c.Methods {
+		methods[strings.ToUpper(m)] = struct{}{}
+	}
+	headers := make([]string, 0, len(c.Headers))
+	for _, h := range c.Headers {
+		headers = append(headers, http.CanonicalHeaderKey(h))
+	}
+	invert := c.Invert
+	return func(req *http.Request) bool {
+		return matchRequest(req, path, methods, headers) != invert
+	}, nil
+}
+
+func matchRequest(req *http.Request, path *regexp.Regexp, methods map[string]struct{}, headers []string) bool {
+	if path != nil && !path.MatchString(req.URL.Path) {
+		return false
+	}
+	if len(methods) > 0 {
+		if _, ok := methods[req.Method]; !ok {
+			return false
+		}
+	}
+	for _, h := range headers {
+		if _, ok := req.Header[h]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Conditional wraps the middleware to run only for the matched requests, the others skip to the next.
+func Conditional(match Matcher, m Middleware) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		inner := m(next)
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if match(req) {
+				return inner.RoundTrip(req)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
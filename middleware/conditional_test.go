@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+func TestConditional(t *testing.T) {
+	match, err := NewMatcher(&config.MiddlewareMatch{Path: "^/public/", Methods: []string{"get"}, Invert: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deny := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusUnauthorized}, nil
+		})
+	}
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	tripper := Conditional(match, deny)(next)
+	tests := []struct {
+		method, path string
+		statusCode   int
+	}{
+		{method: "GET", path: "/public/docs", statusCode: 200},
+		{method: "POST", path: "/public/docs", statusCode: 401},
+		{method: "GET", path: "/private", statusCode: 401},
+	}
+	for _, test := range tests {
+		resp, err := tripper.RoundTrip(httptest.NewRequest(test.method, test.path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != test.statusCode {
+			t.Errorf("%s %s: want %d but got %d", test.method, test.path, test.statusCode, resp.StatusCode)
+		}
+	}
+
+	match, err = NewMatcher(&config.MiddlewareMatch{Headers: []string{"authorization"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("GET", "/", nil)
+	if match(req) {
+		t.Fatal("want the request without the header unmatched")
+	}
+	req.Header.Set("Authorization", "Bearer token")
+	if !match(req) {
+		t.Fatal("want the request with the header matched")
+	}
+	if _, err := NewMatcher(&config.MiddlewareMatch{Path: "("}); err == nil {
+		t.Fatal("want error on invalid path")
+	}
+}
@@ -0,0 +1,106 @@
+package encoding
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/encoding/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const identity = "identity"
+
+func init() {
+	middleware.Register("encoding", Middleware)
+}
+
+func normalizeCoding(coding string) string {
+	coding = strings.ToLower(strings.TrimSpace(coding))
+	if coding == "x-gzip" {
+		return "gzip"
+	}
+	return coding
+}
+
+// strip keeps the supported codings of the Accept-Encoding with their q-values,
+// the malformed ones are dropped, see https://www.rfc-editor.org/rfc/rfc7231#section-5.3.4
+func strip(acceptEncoding string, supported map[string]struct{}) string {
+	var out []string
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		coding := normalizeCoding(params[0])
+		if coding == "" {
+			continue
+		}
+		if _, ok := supported[coding]; !ok {
+			continue
+		}
+		value := coding
+		if len(params) > 1 {
+			q, ok := parseQValue(params[1])
+			if !ok {
+				continue
+			}
+			value += ";q=" + q
+		}
+		out = append(out, value)
+	}
+	if len(out) == 0 {
+		return identity
+	}
+	return strings.Join(out, ", ")
+}
+
+func parseQValue(param string) (string, bool) {
+	name, value := param, ""
+	if i := strings.IndexByte(param, '='); i >= 0 {
+		name, value = param[:i], param[i+1:]
+	}
+	if strings.ToLower(strings.TrimSpace(name)) != "q" {
+		return "", false
+	}
+	value = strings.TrimSpace(value)
+	q, err := strconv.ParseFloat(value, 64)
+	if err != nil || q < 0 || q > 1 {
+		return "", false
+	}
+	return value, true
+}
+
+// Middleware normalizes the Accept-Encoding header of the requests.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Encoding{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	var normalize func(string) string
+	switch policy := options.Policy.(type) {
+	case *v1.Encoding_Set:
+		if policy.Set == "" {
+			return nil, errors.New("encoding: the value to set must be specified")
+		}
+		normalize = func(string) string { return policy.Set }
+	case *v1.Encoding_Strip:
+		supported := make(map[string]struct{}, len(policy.Strip.Supported))
+		for _, coding := range policy.Strip.Supported {
+			supported[normalizeCoding(coding)] = struct{}{}
+		}
+		normalize = func(acceptEncoding string) string { return strip(acceptEncoding, supported) }
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		if normalize == nil {
+			return next
+		}
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("Accept-Encoding", normalize(strings.Join(req.Header.Values("Accept-Encoding"), ",")))
+			return next.RoundTrip(req)
+		})
+	}, nil
+}
@@ -0,0 +1,22 @@
+package encoding
+
+import "testing"
+
+func TestStrip(t *testing.T) {
+	supported := map[string]struct{}{"gzip": {}, "br": {}}
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "gzip, deflate, br", want: "gzip, br"},
+		{in: "GZIP;q=0.8 , x-gzip ; q=0.5, zstd", want: "gzip;q=0.8, gzip;q=0.5"},
+		{in: "br;q=2, gzip;level=1, gzip;q=0", want: "gzip;q=0"},
+		{in: "deflate", want: "identity"},
+		{in: "", want: "identity"},
+	}
+	for _, test := range tests {
+		if got := strip(test.in, supported); got != test.want {
+			t.Errorf("%q: want %q but got %q", test.in, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package featureflag
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/featureflag/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const defaultHeaderPrefix = "X-Feature-"
+
+var (
+	_metricEvaluationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_featureflag_evaluation_seconds",
+		Help:      "The latency of the feature flag evaluations",
+		Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1},
+	}, []string{"provider"})
+	_metricEvaluationErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_featureflag_evaluation_errors_total",
+		Help:      "The total number of the feature flag evaluation errors",
+	}, []string{"provider"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricEvaluationDuration)
+	prometheus.MustRegister(_metricEvaluationErrorsTotal)
+	middleware.Register("featureflag", Middleware)
+}
+
+func subject(options *v1.FeatureFlag, req *http.Request) string {
+	switch s := options.Subject.(type) {
+	case *v1.FeatureFlag_ByClientIp:
+		if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			return host
+		}
+		return req.RemoteAddr
+	case *v1.FeatureFlag_ByHeader:
+		return req.Header.Get(s.ByHeader)
+	default:
+		return ""
+	}
+}
+
+// Middleware injects the feature flags evaluated by the config as the request headers.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.FeatureFlag{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	return newMiddleware(options, NewStaticProvider(options.Flags), "static"), nil
+}
+
+func newMiddleware(options *v1.FeatureFlag, provider Provider, providerName string) middleware.Middleware {
+	prefix := http.CanonicalHeaderKey(defaultHeaderPrefix)
+	if options.HeaderPrefix != "" {
+		prefix = http.CanonicalHeaderKey(options.HeaderPrefix)
+	}
+	if ttl := options.CacheTtl.AsDuration(); ttl > 0 {
+		provider = newCachingProvider(provider, ttl)
+	}
+	duration := _metricEvaluationDuration.WithLabelValues(providerName)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// the flags can't be claimed by the clients
+			for name := range req.Header {
+				if strings.HasPrefix(name, prefix) {
+					req.Header.Del(name)
+				}
+			}
+			startTime := time.Now()
+			flags, err := provider.Evaluate(req.Context(), subject(options, req))
+			duration.Observe(time.Since(startTime).Seconds())
+			if err != nil {
+				_metricEvaluationErrorsTotal.WithLabelValues(providerName).Inc()
+				log.Errorf("Failed to evaluate feature flags: %s: %+v", providerName, err)
+				return next.RoundTrip(req)
+			}
+			for name, on := range flags {
+				value := "off"
+				if on {
+					value = "on"
+				}
+				req.Header.Set(prefix+name, value)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
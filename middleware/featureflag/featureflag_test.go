@@ -0,0 +1,98 @@
+package featureflag
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/featureflag/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestFeatureFlag(t *testing.T) {
+	flags := []*v1.Flag{
+		{Name: "all", Percentage: 100},
+		{Name: "none", Percentage: 0, Subjects: []string{"beta"}},
+	}
+	m := newMiddleware(&v1.FeatureFlag{
+		Flags:   flags,
+		Subject: &v1.FeatureFlag_ByHeader{ByHeader: "X-User"},
+	}, NewStaticProvider(flags), "static")
+	var got http.Header
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+	}))
+	tests := []struct {
+		user string
+		all  string
+		none string
+	}{
+		{user: "alice", all: "on", none: "off"},
+		{user: "beta", all: "on", none: "on"},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-User", test.user)
+		req.Header.Set("X-Feature-Spoofed", "on")
+		if _, err := next.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+		if got.Get("X-Feature-All") != test.all || got.Get("X-Feature-None") != test.none {
+			t.Errorf("%s: want %s %s but got %v", test.user, test.all, test.none, got)
+		}
+		if got.Get("X-Feature-Spoofed") != "" {
+			t.Errorf("want the inbound flag header removed, got: %v", got)
+		}
+	}
+}
+
+func TestBucketStable(t *testing.T) {
+	p := NewStaticProvider([]*v1.Flag{{Name: "half", Percentage: 50}})
+	on := 0
+	for i := 0; i < 1000; i++ {
+		subject := time.Duration(i).String()
+		a, _ := p.Evaluate(context.Background(), subject)
+		b, _ := p.Evaluate(context.Background(), subject)
+		if a["half"] != b["half"] {
+			t.Fatalf("%s: want stable evaluation", subject)
+		}
+		if a["half"] {
+			on++
+		}
+	}
+	if on < 400 || on > 600 {
+		t.Fatalf("want about half of the subjects on, got: %d", on)
+	}
+}
+
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) Evaluate(context.Context, string) (map[string]bool, error) {
+	p.calls++
+	return map[string]bool{"foo": true}, nil
+}
+
+func TestCachingProvider(t *testing.T) {
+	now := time.Unix(0, 0)
+	counting := &countingProvider{}
+	p := newCachingProvider(counting, time.Second)
+	p.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		if _, err := p.Evaluate(context.Background(), "alice"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if counting.calls != 1 {
+		t.Fatalf("want the cached flags, got %d calls", counting.calls)
+	}
+	now = now.Add(time.Second)
+	p.Evaluate(context.Background(), "alice")
+	if counting.calls != 2 {
+		t.Fatalf("want the expired flags evaluated, got %d calls", counting.calls)
+	}
+}
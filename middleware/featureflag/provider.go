@@ -0,0 +1,113 @@
+package featureflag
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/featureflag/v1"
+)
+
+// Provider evaluates the flags of a subject, the flags not returned are off.
+type Provider interface {
+	Evaluate(ctx context.Context, subject string) (map[string]bool, error)
+}
+
+type staticProvider struct {
+	flags []*v1.Flag
+	// subjects are the subjects the flag is always on, by flag name.
+	subjects map[string]map[string]struct{}
+}
+
+// NewStaticProvider returns a provider evaluating the flags of config,
+// the subjects are bucketed by the hash of the flag name and subject.
+func NewStaticProvider(flags []*v1.Flag) Provider {
+	p := &staticProvider{
+		flags:    flags,
+		subjects: make(map[string]map[string]struct{}, len(flags)),
+	}
+	for _, f := range flags {
+		subjects := make(map[string]struct{}, len(f.Subjects))
+		for _, s := range f.Subjects {
+			subjects[s] = struct{}{}
+		}
+		p.subjects[f.Name] = subjects
+	}
+	return p
+}
+
+func (p *staticProvider) Evaluate(_ context.Context, subject string) (map[string]bool, error) {
+	out := make(map[string]bool, len(p.flags))
+	for _, f := range p.flags {
+		if _, ok := p.subjects[f.Name][subject]; ok {
+			out[f.Name] = true
+			continue
+		}
+		out[f.Name] = bucket(f.Name, subject) < f.Percentage
+	}
+	return out, nil
+}
+
+// bucket returns the stable bucket 0-99 of the subject for the flag.
+func bucket(name, subject string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(subject))
+	return h.Sum32() % 100
+}
+
+const _sweepThreshold = 10000
+
+type cacheEntry struct {
+	flags    map[string]bool
+	expireAt time.Time
+}
+
+type cachingProvider struct {
+	Provider
+	ttl     time.Duration
+	lock    sync.Mutex
+	entries map[string]*cacheEntry
+	now     func() time.Time
+}
+
+// newCachingProvider caches the evaluated flags of each subject for the ttl.
+func newCachingProvider(p Provider, ttl time.Duration) *cachingProvider {
+	return &cachingProvider{
+		Provider: p,
+		ttl:      ttl,
+		entries:  make(map[string]*cacheEntry),
+		now:      time.Now,
+	}
+}
+
+func (p *cachingProvider) Evaluate(ctx context.Context, subject string) (map[string]bool, error) {
+	now := p.now()
+	p.lock.Lock()
+	if e, ok := p.entries[subject]; ok && now.Before(e.expireAt) {
+		p.lock.Unlock()
+		return e.flags, nil
+	}
+	p.lock.Unlock()
+	flags, err := p.Provider.Evaluate(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.entries) >= _sweepThreshold {
+		p.sweep(now)
+	}
+	p.entries[subject] = &cacheEntry{flags: flags, expireAt: now.Add(p.ttl)}
+	return flags, nil
+}
+
+func (p *cachingProvider) sweep(now time.Time) {
+	for subject, e := range p.entries {
+		if !now.Before(e.expireAt) {
+			delete(p.entries, subject)
+		}
+	}
+}
@@ -32,20 +32,21 @@ func Middleware(c *config.Middleware) (middleware.Middleware, error) {
 			ctx := req.Context()
 			// nodes, _ := middleware.RequestBackendsFromContext(ctx)
 			reqOpt, _ := middleware.FromRequestContext(ctx)
-			log.Context(ctx).Log(level,
+			keyvals := []interface{}{
 				"source", "accesslog",
 				"host", req.Host,
 				"method", req.Method,
 				"scheme", req.URL.Scheme,
-				"path", req.URL.Path,
-				"query", req.URL.RawQuery,
+				"path", reqOpt.Sanitizer.Path(req.URL.Path),
+				"query", reqOpt.Sanitizer.Query(req.URL.RawQuery),
 				"code", code,
 				"error", errMsg,
 				"latency", time.Since(startTime).Seconds(),
 				"backend", strings.Join(reqOpt.Backends, ","),
 				"backend_code", reqOpt.UpstreamStatusCode,
 				"backend_latency", reqOpt.UpstreamResponseTime,
-			)
+			}
+			log.Context(ctx).Log(level, append(keyvals, middleware.TraceFields(req)...)...)
 			return reply, err
 		})
 	}, nil
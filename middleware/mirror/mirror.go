@@ -0,0 +1,199 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/mirror/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultTriggerValue   = "true"
+	defaultTimeout        = time.Second
+	defaultMaxConcurrency = 100
+	// _mirroredMetadata marks the request mirrored, so that the retries are not mirrored again.
+	_mirroredMetadata = "mirror.mirrored"
+	// _maxBufferedBody is the max size of the body buffered by the mirror if it's not replayable.
+	_maxBufferedBody = 1 << 20
+)
+
+var _metricMirrorTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "requests_mirror_total",
+	Help:      "The total number of the mirrored requests by the trigger and result",
+}, []string{"trigger", "result"})
+
+func init() {
+	prometheus.MustRegister(_metricMirrorTotal)
+}
+
+// Init registers the mirror middleware with the client factory of the shadow endpoints.
+func Init(clientFactory client.Factory) {
+	middleware.Register("mirror", New(clientFactory))
+}
+
+// New returns the factory of the mirror middleware.
+func New(factory client.Factory) middleware.Factory {
+	return func(c *config.Middleware) (middleware.Middleware, error) {
+		options := &v1.Mirror{}
+		if c.Options != nil {
+			if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+				return nil, err
+			}
+		}
+		if options.Endpoint == nil {
+			return nil, errors.New("mirror: endpoint must be specified")
+		}
+		if options.Percentage < 0 || options.Percentage > 100 {
+			return nil, errors.New("mirror: percentage must be in 0-100")
+		}
+		shadow, err := factory(options.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		return newMiddleware(options, shadow), nil
+	}
+}
+
+type mirror struct {
+	endpoint      *config.Endpoint
+	shadow        http.RoundTripper
+	percentage    float64
+	triggerHeader string
+	triggerValue  string
+	timeout       time.Duration
+	slots         chan struct{}
+
+	lock sync.Mutex
+	rand *rand.Rand
+}
+
+func newMiddleware(options *v1.Mirror, shadow http.RoundTripper) middleware.Middleware {
+	m := &mirror{
+		endpoint:      options.Endpoint,
+		shadow:        shadow,
+		percentage:    options.Percentage,
+		triggerHeader: options.TriggerHeader,
+		triggerValue:  defaultTriggerValue,
+		timeout:       defaultTimeout,
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+	if options.TriggerValue != "" {
+		m.triggerValue = options.TriggerValue
+	}
+	if options.Timeout != nil && options.Timeout.AsDuration() > 0 {
+		m.timeout = options.Timeout.AsDuration()
+	}
+	maxConcurrency := defaultMaxConcurrency
+	if options.MaxConcurrency > 0 {
+		maxConcurrency = int(options.MaxConcurrency)
+	}
+	m.slots = make(chan struct{}, maxConcurrency)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if trigger, ok := m.trigger(req); ok {
+				m.mirror(req, trigger)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// trigger returns the trigger of the mirroring, the header trigger or the percentage sampling.
+func (m *mirror) trigger(req *http.Request) (string, bool) {
+	if reqOpt, ok := middleware.FromRequestContext(req.Context()); ok {
+		if _, mirrored := reqOpt.Metadata[_mirroredMetadata]; mirrored {
+			return "", false
+		}
+		reqOpt.Metadata[_mirroredMetadata] = "true"
+	}
+	if m.triggerHeader != "" && req.Header.Get(m.triggerHeader) == m.triggerValue {
+		return "header", true
+	}
+	if m.percentage <= 0 {
+		return "", false
+	}
+	m.lock.Lock()
+	sampled := m.rand.Float64()*100 < m.percentage
+	m.lock.Unlock()
+	return "percentage", sampled
+}
+
+// mirror sends the copy of the request to the shadow endpoint without waiting for it.
+func (m *mirror) mirror(req *http.Request, trigger string) {
+	body := io.ReadCloser(http.NoBody)
+	if req.GetBody != nil {
+		var err error
+		if body, err = req.GetBody(); err != nil {
+			_metricMirrorTotal.WithLabelValues(trigger, "failed").Inc()
+			return
+		}
+	} else if req.Body != nil && req.Body != http.NoBody {
+		// the body streamed to the upstream is buffered if the size is known and small enough,
+		// the streaming body can't be replayed.
+		if req.ContentLength <= 0 || req.ContentLength > _maxBufferedBody {
+			_metricMirrorTotal.WithLabelValues(trigger, "skipped").Inc()
+			return
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(req.Body, req.ContentLength))
+		req.Body = &replayedBody{Reader: io.MultiReader(bytes.NewReader(data), req.Body), Closer: req.Body}
+		if err != nil {
+			_metricMirrorTotal.WithLabelValues(trigger, "failed").Inc()
+			return
+		}
+		body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+	select {
+	case m.slots <- struct{}{}:
+	default:
+		body.Close()
+		_metricMirrorTotal.WithLabelValues(trigger, "dropped").Inc()
+		return
+	}
+	// the shadow request is not canceled with the request
+	ctx, cancel := context.WithTimeout(middleware.NewRequestContext(context.Background(), middleware.NewRequestOptions(m.endpoint)), m.timeout)
+	shadow := req.Clone(ctx)
+	path := middleware.SanitizedPath(req)
+	shadow.Body = body
+	shadow.GetBody = nil
+	go func() {
+		defer func() { <-m.slots }()
+		defer cancel()
+		resp, err := m.shadow.RoundTrip(shadow)
+		if err != nil {
+			_metricMirrorTotal.WithLabelValues(trigger, "failed").Inc()
+			// the error of the client carries the mirrored URL, which may have the sensitive query
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+			log.Debugf("Failed to mirror request: %s: %+v", path, err)
+			return
+		}
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		_metricMirrorTotal.WithLabelValues(trigger, "sent").Inc()
+	}()
+}
+
+// replayedBody is the body of which the head is buffered for the mirroring.
+type replayedBody struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,106 @@
+package mirror
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/mirror/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestMirror(t *testing.T) {
+	mirrored := make(chan string, 10)
+	shadow := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		mirrored <- req.URL.Path + ":" + string(b)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	m := newMiddleware(&v1.Mirror{Endpoint: &config.Endpoint{}, TriggerHeader: "X-Mirror"}, shadow)(next)
+
+	newRequest := func(path, body string) *http.Request {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(body)), nil
+		}
+		opts := middleware.NewRequestOptions(&config.Endpoint{})
+		return req.WithContext(middleware.NewRequestContext(req.Context(), opts))
+	}
+	// the percentage is zero, only the triggered requests are mirrored
+	if _, err := m.RoundTrip(newRequest("/sampled", "a")); err != nil {
+		t.Fatal(err)
+	}
+	req := newRequest("/triggered", "b")
+	req.Header.Set("X-Mirror", "true")
+	// the retries of the same request are not mirrored again
+	for i := 0; i < 2; i++ {
+		if _, err := m.RoundTrip(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case got := <-mirrored:
+		if got != "/triggered:b" {
+			t.Fatalf("want the triggered request mirrored but got %s", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("want the triggered request mirrored")
+	}
+	select {
+	case got := <-mirrored:
+		t.Fatalf("want only the triggered request mirrored once but got %s", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// all are sampled with 100 percent
+	m = newMiddleware(&v1.Mirror{Endpoint: &config.Endpoint{}, Percentage: 100}, shadow)(next)
+	if _, err := m.RoundTrip(newRequest("/sampled", "c")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case got := <-mirrored:
+		if got != "/sampled:c" {
+			t.Fatalf("want the sampled request mirrored but got %s", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("want the sampled request mirrored")
+	}
+
+	// the streamed body is buffered for both the shadow and upstream
+	received := make(chan string, 1)
+	next = middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		received <- string(b)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	m = newMiddleware(&v1.Mirror{Endpoint: &config.Endpoint{}, Percentage: 100}, shadow)(next)
+	req = newRequest("/streamed", "d")
+	req.GetBody = nil
+	if _, err := m.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-received; got != "d" {
+		t.Fatalf("want the upstream received the body but got %s", got)
+	}
+	select {
+	case got := <-mirrored:
+		if got != "/streamed:d" {
+			t.Fatalf("want the streamed request mirrored but got %s", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("want the streamed request mirrored")
+	}
+}
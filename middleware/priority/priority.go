@@ -0,0 +1,206 @@
+package priority
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/priority/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const defaultClassName = "default"
+
+var (
+	// _pools are the in-flight counters of the pools, they're kept across the reloads
+	// so that the requests in flight are still counted.
+	_pools     = make(map[string]*int64)
+	_poolsLock sync.Mutex
+
+	_metricRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_priority_total",
+		Help:      "The total number of requests by priority class and admission result",
+	}, []string{"class", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricRequestsTotal)
+	middleware.Register("priority", Middleware)
+}
+
+// inflightPool returns the in-flight counter of the pool, the unnamed pool is shared by the same options,
+// so that the global middleware built once per endpoint counts the requests of all endpoints.
+func inflightPool(options *v1.Priority) (*int64, error) {
+	key := "pool:" + options.Pool
+	if options.Pool == "" {
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+		if err != nil {
+			return nil, err
+		}
+		key = "options:" + string(b)
+	}
+	_poolsLock.Lock()
+	defer _poolsLock.Unlock()
+	inflight, ok := _pools[key]
+	if !ok {
+		inflight = new(int64)
+		_pools[key] = inflight
+	}
+	return inflight, nil
+}
+
+type class struct {
+	name     string
+	priority int32
+	fraction float64
+	// limit is the in-flight requests above which the class is rejected, it's never rejected if 0.
+	limit int64
+}
+
+type classifier struct {
+	header       string
+	byHeader     map[string]*class
+	byPath       []string
+	byPathClass  []*class
+	defaultClass *class
+}
+
+func newClassifier(options *v1.Priority) (*classifier, error) {
+	c := &classifier{
+		header:       options.Header,
+		byHeader:     make(map[string]*class),
+		defaultClass: &class{name: defaultClassName, priority: math.MinInt32},
+	}
+	classes := make(map[string]*class, len(options.Classes))
+	for _, in := range options.Classes {
+		if in.InflightFraction < 0 {
+			return nil, fmt.Errorf("priority: invalid inflight fraction of class %s: %v", in.Name, in.InflightFraction)
+		}
+		cls := &class{name: in.Name, priority: in.Priority, fraction: in.InflightFraction}
+		classes[in.Name] = cls
+		for _, v := range in.HeaderValues {
+			c.byHeader[v] = cls
+		}
+		for _, prefix := range in.PathPrefixes {
+			c.byPath = append(c.byPath, prefix)
+			c.byPathClass = append(c.byPathClass, cls)
+		}
+	}
+	if options.DefaultClass != "" {
+		cls, ok := classes[options.DefaultClass]
+		if !ok {
+			return nil, fmt.Errorf("priority: unknown default class: %s", options.DefaultClass)
+		}
+		c.defaultClass = cls
+	}
+	all := make([]*class, 0, len(classes)+1)
+	for _, cls := range classes {
+		all = append(all, cls)
+	}
+	if options.DefaultClass == "" {
+		all = append(all, c.defaultClass)
+	}
+	setLimits(all, options.MaxInflight)
+	return c, nil
+}
+
+// setLimits spreads the thresholds of the priorities below the highest one evenly up to max,
+// e.g. 1/3, 2/3 and 3/3 of max for four priorities, unless the fraction of the class is set.
+func setLimits(classes []*class, max int64) {
+	if max <= 0 {
+		return
+	}
+	levels := make(map[int32]int64, len(classes))
+	priorities := make([]int32, 0, len(classes))
+	for _, cls := range classes {
+		if _, ok := levels[cls.priority]; !ok {
+			levels[cls.priority] = 0
+			priorities = append(priorities, cls.priority)
+		}
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] < priorities[j] })
+	for i, p := range priorities {
+		levels[p] = int64(i)
+	}
+	lower := int64(len(priorities) - 1)
+	for _, cls := range classes {
+		level := levels[cls.priority]
+		switch {
+		case cls.fraction > 0:
+			cls.limit = int64(math.Ceil(cls.fraction * float64(max)))
+		case level < lower:
+			cls.limit = max * (level + 1) / lower
+		default:
+			continue
+		}
+		if cls.limit < 1 {
+			cls.limit = 1
+		}
+	}
+}
+
+func (c *classifier) classify(req *http.Request) *class {
+	if c.header != "" {
+		if cls, ok := c.byHeader[req.Header.Get(c.header)]; ok {
+			return cls
+		}
+	}
+	for i, prefix := range c.byPath {
+		if strings.HasPrefix(req.URL.Path, prefix) {
+			return c.byPathClass[i]
+		}
+	}
+	return c.defaultClass
+}
+
+func newRejectedResponse() *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(http.StatusServiceUnavailable),
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{},
+		Body:       io.NopCloser(&bytes.Buffer{}),
+	}
+}
+
+// Middleware sheds the lower priority requests first when the gateway is overloaded.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Priority{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	classifier, err := newClassifier(options)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := inflightPool(options)
+	if err != nil {
+		return nil, err
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			cls := classifier.classify(req)
+			inflight := atomic.AddInt64(pool, 1)
+			defer atomic.AddInt64(pool, -1)
+			if cls.limit > 0 && inflight > cls.limit {
+				_metricRequestsTotal.WithLabelValues(cls.name, "rejected").Inc()
+				return newRejectedResponse(), nil
+			}
+			_metricRequestsTotal.WithLabelValues(cls.name, "admitted").Inc()
+			return next.RoundTrip(req)
+		})
+	}, nil
+}
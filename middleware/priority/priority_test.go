@@ -0,0 +1,140 @@
+package priority
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/priority/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestPriority(t *testing.T) {
+	options, err := anypb.New(&v1.Priority{
+		MaxInflight: 1,
+		Header:      "X-Tier",
+		Classes: []*v1.Class{
+			{Name: "paid", Priority: 10, HeaderValues: []string{"paid"}},
+			{Name: "free", Priority: 1, HeaderValues: []string{"free"}, PathPrefixes: []string{"/public/"}},
+		},
+		DefaultClass: "free",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	entered, release := make(chan struct{}), make(chan struct{})
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/slow" {
+			entered <- struct{}{}
+			<-release
+		}
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		next.RoundTrip(httptest.NewRequest("GET", "/slow", nil))
+	}()
+	<-entered
+
+	tests := []struct {
+		path       string
+		tier       string
+		statusCode int
+	}{
+		{path: "/api", tier: "paid", statusCode: http.StatusOK},
+		{path: "/api", tier: "free", statusCode: http.StatusServiceUnavailable},
+		{path: "/public/index", statusCode: http.StatusServiceUnavailable},
+		{path: "/api", statusCode: http.StatusServiceUnavailable},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", test.path, nil)
+		req.Header.Set("X-Tier", test.tier)
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != test.statusCode {
+			t.Errorf("%s %s: want %d but got %d", test.path, test.tier, test.statusCode, resp.StatusCode)
+		}
+	}
+	close(release)
+	wg.Wait()
+
+	resp, _ := next.RoundTrip(httptest.NewRequest("GET", "/api", nil))
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("want ok under threshold but got %d", resp.StatusCode)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	c, err := newClassifier(&v1.Priority{
+		MaxInflight: 4,
+		Classes: []*v1.Class{
+			{Name: "gold", Priority: 3, PathPrefixes: []string{"/gold"}},
+			{Name: "silver", Priority: 2, PathPrefixes: []string{"/silver"}},
+			{Name: "bronze", Priority: 1, PathPrefixes: []string{"/bronze"}},
+			{Name: "batch", Priority: 2, PathPrefixes: []string{"/batch"}, InflightFraction: 0.1},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the lower classes are shed first, the highest class is never
+	tests := map[string]int64{"/gold": 0, "/silver": 4, "/bronze": 2, "/batch": 1, "/other": 1}
+	for path, limit := range tests {
+		if cls := c.classify(httptest.NewRequest("GET", path, nil)); cls.limit != limit {
+			t.Errorf("%s: want the limit %d of %s but got %d", path, limit, cls.name, cls.limit)
+		}
+	}
+
+	if _, err := newClassifier(&v1.Priority{Classes: []*v1.Class{{Name: "bad", InflightFraction: -1}}}); err == nil {
+		t.Error("want error for the negative fraction")
+	}
+}
+
+func TestPool(t *testing.T) {
+	build := func(options *v1.Priority) middleware.Middleware {
+		opts, err := anypb.New(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := Middleware(&config.Middleware{Options: opts})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	rejected := func(outer, inner *v1.Priority) bool {
+		// the request of the outer instance is in flight while the inner one is served
+		next := build(outer)(build(inner)(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK}, nil
+		})))
+		resp, err := next.RoundTrip(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode == http.StatusServiceUnavailable
+	}
+	global := &v1.Priority{MaxInflight: 1, Classes: []*v1.Class{{Name: "paid", Priority: 10}}}
+	if !rejected(global, global) {
+		t.Error("want the requests of the global middleware built for the endpoints counted together")
+	}
+	other := &v1.Priority{MaxInflight: 1, Classes: []*v1.Class{{Name: "paid", Priority: 20}}}
+	if rejected(global, other) {
+		t.Error("want the requests of the different options counted apart")
+	}
+	global = &v1.Priority{MaxInflight: 1, Pool: "shared", Classes: []*v1.Class{{Name: "paid", Priority: 10}}}
+	other = &v1.Priority{MaxInflight: 1, Pool: "shared", Classes: []*v1.Class{{Name: "paid", Priority: 20}}}
+	if !rejected(global, other) {
+		t.Error("want the requests of the pool counted together")
+	}
+}
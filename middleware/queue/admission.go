@@ -0,0 +1,99 @@
+package queue
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	errQueueFull = errors.New("queue: queue is full")
+	errStale     = errors.New("queue: request waited longer than max age")
+)
+
+type waiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// admission limits the concurrency, the slot released is handed over to a waiter directly.
+type admission struct {
+	lock           sync.Mutex
+	inflight       int64
+	maxConcurrency int64
+	maxQueue       int
+	maxAge         time.Duration
+	lifo           bool
+	waiters        *list.List
+}
+
+func newAdmission(maxConcurrency int64, maxQueue int, maxAge time.Duration, lifo bool) *admission {
+	return &admission{
+		maxConcurrency: maxConcurrency,
+		maxQueue:       maxQueue,
+		maxAge:         maxAge,
+		lifo:           lifo,
+		waiters:        list.New(),
+	}
+}
+
+func (a *admission) acquire(ctx context.Context) error {
+	a.lock.Lock()
+	if a.inflight < a.maxConcurrency && a.waiters.Len() == 0 {
+		a.inflight++
+		a.lock.Unlock()
+		return nil
+	}
+	if a.waiters.Len() >= a.maxQueue {
+		a.lock.Unlock()
+		return errQueueFull
+	}
+	w := &waiter{ready: make(chan struct{})}
+	elem := a.waiters.PushBack(w)
+	a.lock.Unlock()
+
+	timer := time.NewTimer(a.maxAge)
+	defer timer.Stop()
+	var err error
+	select {
+	case <-w.ready:
+		return nil
+	case <-timer.C:
+		err = errStale
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if w.granted {
+		// the slot is handed over just before giving up, pass it on
+		a.releaseLocked()
+		return err
+	}
+	a.waiters.Remove(elem)
+	return err
+}
+
+func (a *admission) release() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.releaseLocked()
+}
+
+func (a *admission) releaseLocked() {
+	var elem *list.Element
+	if a.lifo {
+		elem = a.waiters.Back()
+	} else {
+		elem = a.waiters.Front()
+	}
+	if elem == nil {
+		a.inflight--
+		return
+	}
+	w := a.waiters.Remove(elem).(*waiter)
+	w.granted = true
+	close(w.ready)
+}
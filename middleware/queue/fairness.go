@@ -0,0 +1,116 @@
+package queue
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/queue/v1"
+)
+
+const (
+	defaultMaxTrackedClients = 20
+	_otherClient             = "other"
+)
+
+// fairness caps the slots of each client, the slots are counted from entering the queue until the release.
+type fairness struct {
+	keyHeader  string
+	limit      int64
+	limits     map[string]int64
+	maxTracked int
+
+	lock    sync.Mutex
+	slots   map[string]int64
+	tracked map[string]struct{}
+}
+
+func shareLimit(share float64, maxConcurrency int64) (int64, error) {
+	if share <= 0 || share > 1 {
+		return 0, fmt.Errorf("queue: invalid fairness share: %v", share)
+	}
+	limit := int64(math.Floor(share * float64(maxConcurrency)))
+	if limit < 1 {
+		limit = 1
+	}
+	return limit, nil
+}
+
+func newFairness(c *v1.Fairness, maxConcurrency int64) (*fairness, error) {
+	if c == nil {
+		return nil, nil
+	}
+	limit, err := shareLimit(c.MaxShare, maxConcurrency)
+	if err != nil {
+		return nil, err
+	}
+	f := &fairness{
+		keyHeader:  c.KeyHeader,
+		limit:      limit,
+		limits:     make(map[string]int64, len(c.Shares)),
+		maxTracked: defaultMaxTrackedClients,
+		slots:      make(map[string]int64),
+		tracked:    make(map[string]struct{}),
+	}
+	for key, share := range c.Shares {
+		if f.limits[key], err = shareLimit(share, maxConcurrency); err != nil {
+			return nil, err
+		}
+	}
+	if c.MaxTrackedClients > 0 {
+		f.maxTracked = int(c.MaxTrackedClients)
+	}
+	return f, nil
+}
+
+// key returns the client key of the request.
+func (f *fairness) key(req *http.Request) string {
+	if f.keyHeader != "" {
+		if key := req.Header.Get(f.keyHeader); key != "" {
+			return key
+		}
+	}
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		return host
+	}
+	return req.RemoteAddr
+}
+
+// enter takes a slot of the client, it's false if the client has used up its share.
+func (f *fairness) enter(key string) bool {
+	limit, ok := f.limits[key]
+	if !ok {
+		limit = f.limit
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.slots[key] >= limit {
+		return false
+	}
+	f.slots[key]++
+	return true
+}
+
+func (f *fairness) leave(key string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.slots[key]--; f.slots[key] <= 0 {
+		delete(f.slots, key)
+	}
+}
+
+// label returns the metrics label of the client, the clients beyond the max tracked ones share the label "other".
+func (f *fairness) label(key string) string {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, ok := f.tracked[key]; ok {
+		return key
+	}
+	if len(f.tracked) < f.maxTracked {
+		f.tracked[key] = struct{}{}
+		return key
+	}
+	return _otherClient
+}
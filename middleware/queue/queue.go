@@ -0,0 +1,168 @@
+package queue
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/queue/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const defaultMaxAge = time.Second
+
+var (
+	// _queues are shared by the same options, so that the requests in flight are still counted after the reloads,
+	// and the endpoints of the global middleware share the concurrency and the slots of the clients.
+	_queues = struct {
+		sync.Mutex
+		m map[string]*queue
+	}{m: make(map[string]*queue)}
+
+	_metricRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_queue_rejected_total",
+		Help:      "The total number of requests rejected by the admission queue",
+	}, []string{"method", "path", "reason"})
+	_metricClientConcurrency = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_queue_client_concurrency",
+		Help:      "The concurrent requests of each client admitted by the admission queue with the fairness",
+	}, []string{"method", "path", "client"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricRejectedTotal)
+	prometheus.MustRegister(_metricClientConcurrency)
+	middleware.Register("queue", Middleware)
+}
+
+func newRejectedResponse() *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(http.StatusServiceUnavailable),
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{},
+		Body:       io.NopCloser(&bytes.Buffer{}),
+	}
+}
+
+// queue is the admission and the fairness of the options, the fairness is nil if it's not configured.
+type queue struct {
+	admission *admission
+	fairness  *fairness
+}
+
+func loadQueue(options *v1.Queue, maxQueue int, maxAge time.Duration) (*queue, error) {
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	_queues.Lock()
+	defer _queues.Unlock()
+	if q, ok := _queues.m[string(key)]; ok {
+		return q, nil
+	}
+	fair, err := newFairness(options.Fairness, options.MaxConcurrency)
+	if err != nil {
+		return nil, err
+	}
+	q := &queue{
+		admission: newAdmission(options.MaxConcurrency, maxQueue, maxAge, options.Discipline == v1.Discipline_LIFO),
+		fairness:  fair,
+	}
+	_queues.m[string(key)] = q
+	return q, nil
+}
+
+// releaseBody releases the slot once the body is closed, since the response is in flight until then.
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+// Middleware queues the requests beyond the max concurrency.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Queue{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.MaxConcurrency <= 0 {
+		return nil, errors.New("queue: max concurrency must be greater than zero")
+	}
+	maxQueue := int(options.MaxConcurrency)
+	if options.MaxQueue > 0 {
+		maxQueue = int(options.MaxQueue)
+	}
+	maxAge := defaultMaxAge
+	if options.MaxAge != nil && options.MaxAge.AsDuration() > 0 {
+		maxAge = options.MaxAge.AsDuration()
+	}
+	q, err := loadQueue(options, maxQueue, maxAge)
+	if err != nil {
+		return nil, err
+	}
+	a, fair := q.admission, q.fairness
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			var key string
+			if fair != nil {
+				key = fair.key(req)
+				if !fair.enter(key) {
+					_metricRejectedTotal.WithLabelValues(req.Method, middleware.PathLabel(req), "share").Inc()
+					return newRejectedResponse(), nil
+				}
+			}
+			if err := a.acquire(req.Context()); err != nil {
+				if fair != nil {
+					fair.leave(key)
+				}
+				switch {
+				case errors.Is(err, errQueueFull):
+					_metricRejectedTotal.WithLabelValues(req.Method, middleware.PathLabel(req), "full").Inc()
+				case errors.Is(err, errStale):
+					_metricRejectedTotal.WithLabelValues(req.Method, middleware.PathLabel(req), "stale").Inc()
+				default:
+					return nil, err
+				}
+				return newRejectedResponse(), nil
+			}
+			var concurrency prometheus.Gauge
+			if fair != nil {
+				concurrency = _metricClientConcurrency.WithLabelValues(req.Method, middleware.PathLabel(req), fair.label(key))
+				concurrency.Inc()
+			}
+			release := func() {
+				a.release()
+				if fair != nil {
+					concurrency.Dec()
+					fair.leave(key)
+				}
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil || resp.Body == nil {
+				release()
+				return resp, err
+			}
+			resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+			return resp, nil
+		})
+	}, nil
+}
@@ -0,0 +1,223 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/queue/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func waitQueued(a *admission, n int) {
+	for {
+		a.lock.Lock()
+		queued := a.waiters.Len()
+		a.lock.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func testDiscipline(t *testing.T, lifo bool, want []int) {
+	a := newAdmission(1, 8, time.Second, lifo)
+	if err := a.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	served := make(chan int, len(want))
+	for i := range want {
+		go func(i int) {
+			if err := a.acquire(context.Background()); err != nil {
+				t.Error(err)
+				return
+			}
+			served <- i
+			a.release()
+		}(i)
+		// make sure the waiters are queued in order
+		waitQueued(a, i+1)
+	}
+	a.release()
+	for _, w := range want {
+		if got := <-served; got != w {
+			t.Fatalf("lifo %v: want %d served but got %d", lifo, w, got)
+		}
+	}
+}
+
+func TestDiscipline(t *testing.T) {
+	testDiscipline(t, false, []int{0, 1, 2})
+	testDiscipline(t, true, []int{2, 1, 0})
+}
+
+func TestAdmissionRejects(t *testing.T) {
+	a := newAdmission(1, 1, 50*time.Millisecond, false)
+	if err := a.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- a.acquire(context.Background()) }()
+	waitQueued(a, 1)
+	if err := a.acquire(context.Background()); !errors.Is(err, errQueueFull) {
+		t.Fatalf("want queue full but got %v", err)
+	}
+	if err := <-errc; !errors.Is(err, errStale) {
+		t.Fatalf("want stale but got %v", err)
+	}
+	a.release()
+	if err := a.acquire(context.Background()); err != nil {
+		t.Fatalf("want the slot released but got %v", err)
+	}
+}
+
+func TestFairness(t *testing.T) {
+	if _, err := newFairness(&v1.Fairness{MaxShare: 1.5}, 10); err == nil {
+		t.Fatal("want the invalid share rejected")
+	}
+	options, err := anypb.New(&v1.Queue{
+		MaxConcurrency: 4,
+		Fairness: &v1.Fairness{
+			KeyHeader:         "X-Tenant",
+			MaxShare:          0.5,
+			Shares:            map[string]float64{"batch": 0.1},
+			MaxTrackedClients: 1,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Name: "queue", Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	entered := make(chan struct{}, 8)
+	tripper := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		entered <- struct{}{}
+		<-release
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+	}))
+	// the metrics are labeled by the path template of the endpoint rather than the request path
+	ctx := middleware.NewRequestContext(context.Background(), middleware.NewRequestOptions(&config.Endpoint{Path: "/fair/*"}))
+	do := func(tenant string) int {
+		req := httptest.NewRequest("GET", "/fair/"+tenant, nil).WithContext(ctx)
+		req.Header.Set("X-Tenant", tenant)
+		resp, err := tripper.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	codes := make(chan int, 8)
+	// the noisy client holds its share of 2 slots, and the batch one holds its single slot
+	for _, tenant := range []string{"noisy", "noisy", "batch"} {
+		go func(tenant string) { codes <- do(tenant) }(tenant)
+		<-entered
+	}
+	if code := do("noisy"); code != http.StatusServiceUnavailable {
+		t.Fatalf("want the noisy client beyond its share rejected but got %d", code)
+	}
+	if code := do("batch"); code != http.StatusServiceUnavailable {
+		t.Fatalf("want the batch client beyond its share rejected but got %d", code)
+	}
+	// the other clients are still served
+	go func() { codes <- do("quiet") }()
+	<-entered
+	if got := testutil.ToFloat64(_metricClientConcurrency.WithLabelValues("GET", "/fair/*", "noisy")); got != 2 {
+		t.Fatalf("want the noisy client concurrency 2 but got %v", got)
+	}
+	if got := testutil.ToFloat64(_metricClientConcurrency.WithLabelValues("GET", "/fair/*", _otherClient)); got != 2 {
+		t.Fatalf("want the untracked clients labeled other but got %v", got)
+	}
+	close(release)
+	for i := 0; i < 4; i++ {
+		if code := <-codes; code != http.StatusOK {
+			t.Fatalf("want 200 but got %d", code)
+		}
+	}
+	if code := do("noisy"); code != http.StatusOK {
+		t.Fatalf("want the share released but got %d", code)
+	}
+}
+
+func TestAdmissionShared(t *testing.T) {
+	options, err := anypb.New(&v1.Queue{MaxConcurrency: 1, MaxQueue: 1, MaxAge: durationpb.New(20 * time.Millisecond)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+	})
+	// the builds of the endpoints and the reloads share the admission
+	var trippers []http.RoundTripper
+	for i := 0; i < 2; i++ {
+		m, err := Middleware(&config.Middleware{Name: "queue", Options: options})
+		if err != nil {
+			t.Fatal(err)
+		}
+		trippers = append(trippers, m(next))
+	}
+	held, err := trippers[0].RoundTrip(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the slot is held until the body is closed
+	resp, err := trippers[1].RoundTrip(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("want 503 while the body is open but got %d", resp.StatusCode)
+	}
+	held.Body.Close()
+	resp, err = trippers[1].RoundTrip(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("want 200 after the body is closed but got %d", resp.StatusCode)
+	}
+	resp.Body.Close()
+}
+
+func TestFairnessShared(t *testing.T) {
+	options, err := anypb.New(&v1.Queue{MaxConcurrency: 4, Fairness: &v1.Fairness{KeyHeader: "X-Shared-Tenant", MaxShare: 0.25}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+	})
+	do := func() *http.Response {
+		// the builds of the endpoints and the reloads share the slots of the clients
+		m, err := Middleware(&config.Middleware{Name: "queue", Options: options})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Shared-Tenant", "noisy")
+		resp, err := m(next).RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	held := do()
+	if resp := do(); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("want the client beyond its share rejected but got %d", resp.StatusCode)
+	}
+	held.Body.Close()
+	if resp := do(); resp.StatusCode != http.StatusOK {
+		t.Fatalf("want the share released but got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,186 @@
+package quota
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/quota/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultWindow = 24 * time.Hour
+	_anonymousKey = "-"
+	// _countedMetadata marks the request counted by the middleware with the remaining quota,
+	// so that the retries are not counted again. it's suffixed by the instance, since a request may pass many quotas.
+	_countedMetadata = "quota.counted."
+)
+
+var _instances int64
+
+var (
+	// _stores are shared by the same options, so that the counters neither reset on the reloads
+	// nor are separated by the endpoints of the global middleware.
+	_stores = struct {
+		sync.Mutex
+		m map[string]Store
+	}{m: make(map[string]Store)}
+
+	_metricExhaustedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_quota_exhausted_total",
+		Help:      "The total number of requests rejected by exhausted quota",
+	}, []string{"method", "path"})
+	_metricStoreErrorsTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_quota_store_errors_total",
+		Help:      "The total number of quota store errors",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(_metricExhaustedTotal)
+	prometheus.MustRegister(_metricStoreErrorsTotal)
+	middleware.Register("quota", Middleware)
+}
+
+func quotaKey(options *v1.Quota, req *http.Request) string {
+	var key string
+	switch k := options.Key.(type) {
+	case *v1.Quota_ByClientIp:
+		key = req.RemoteAddr
+		if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			key = host
+		}
+	case *v1.Quota_ByHeader:
+		key = req.Header.Get(k.ByHeader)
+	}
+	if key == "" {
+		return _anonymousKey
+	}
+	return key
+}
+
+func setQuotaHeaders(header http.Header, limit, remaining int64, reset time.Time) {
+	if remaining < 0 {
+		remaining = 0
+	}
+	header.Set("X-RateLimit-Limit", strconv.FormatInt(limit, 10))
+	header.Set("X-RateLimit-Remaining", strconv.FormatInt(remaining, 10))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+}
+
+func newExhaustedResponse(statusCode int) *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(statusCode),
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(&bytes.Buffer{}),
+	}
+}
+
+func loadStore(options *v1.Quota) (Store, error) {
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	_stores.Lock()
+	defer _stores.Unlock()
+	if s, ok := _stores.m[string(key)]; ok {
+		return s, nil
+	}
+	s := NewMemoryStore()
+	_stores.m[string(key)] = s
+	return s, nil
+}
+
+// Middleware limits the number of requests of each user per window.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Quota{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.Limit <= 0 {
+		return nil, errors.New("quota: limit must be greater than zero")
+	}
+	store, err := loadStore(options)
+	if err != nil {
+		return nil, err
+	}
+	return newMiddleware(options, store, time.Now), nil
+}
+
+func newMiddleware(options *v1.Quota, store Store, now func() time.Time) middleware.Middleware {
+	window := defaultWindow
+	if options.Window != nil && options.Window.AsDuration() > 0 {
+		window = options.Window.AsDuration()
+	}
+	statusCode := http.StatusTooManyRequests
+	if options.StatusCode != 0 {
+		statusCode = int(options.StatusCode)
+	}
+	counted := _countedMetadata + strconv.FormatInt(atomic.AddInt64(&_instances, 1), 10)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ts := now().UnixNano()
+			start := time.Unix(0, ts-ts%int64(window))
+			reset := start.Add(window)
+			reqOpt, hasOpt := middleware.FromRequestContext(req.Context())
+			var (
+				remaining int64
+				v         string
+				isCounted bool
+			)
+			if hasOpt {
+				v, isCounted = reqOpt.Metadata[counted]
+			}
+			if isCounted {
+				remaining, _ = strconv.ParseInt(v, 10, 64)
+			} else {
+				count, err := store.Incr(req.Context(), quotaKey(options, req), start, window)
+				if err != nil {
+					// fail open, the quota is not as critical as the availability
+					_metricStoreErrorsTotal.Inc()
+					log.Errorf("Failed to increase quota counter: %+v", err)
+					return next.RoundTrip(req)
+				}
+				remaining = options.Limit - count
+				if hasOpt {
+					reqOpt.Metadata[counted] = strconv.FormatInt(remaining, 10)
+				}
+			}
+			if remaining < 0 {
+				_metricExhaustedTotal.WithLabelValues(req.Method, middleware.PathLabel(req)).Inc()
+				resp := newExhaustedResponse(statusCode)
+				setQuotaHeaders(resp.Header, options.Limit, remaining, reset)
+				resp.Header.Set("Retry-After", strconv.FormatInt(int64(reset.Sub(now())/time.Second)+1, 10))
+				return resp, nil
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			if resp.Header == nil {
+				resp.Header = http.Header{}
+			}
+			setQuotaHeaders(resp.Header, options.Limit, remaining, reset)
+			return resp, nil
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package quota
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/quota/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/gateway/proxy"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestQuota(t *testing.T) {
+	now := time.Date(2022, 1, 1, 23, 59, 0, 0, time.UTC)
+	m := newMiddleware(&v1.Quota{
+		Limit:  2,
+		Window: durationpb.New(24 * time.Hour),
+		Key:    &v1.Quota_ByHeader{ByHeader: "X-User"},
+	}, NewMemoryStore(), func() time.Time { return now })
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+	}))
+	do := func(user string) *http.Response {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-User", user)
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	reset := strconv.FormatInt(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC).Unix(), 10)
+	for i, want := range []struct {
+		code      int
+		remaining string
+	}{
+		{http.StatusOK, "1"},
+		{http.StatusOK, "0"},
+		{http.StatusTooManyRequests, "0"},
+	} {
+		resp := do("alice")
+		if resp.StatusCode != want.code {
+			t.Fatalf("request %d: want %d but got %d", i, want.code, resp.StatusCode)
+		}
+		if got := resp.Header.Get("X-RateLimit-Remaining"); got != want.remaining {
+			t.Fatalf("request %d: want remaining %s but got %s", i, want.remaining, got)
+		}
+		if got := resp.Header.Get("X-RateLimit-Reset"); got != reset {
+			t.Fatalf("request %d: want reset %s but got %s", i, reset, got)
+		}
+	}
+	if resp := do("bob"); resp.StatusCode != http.StatusOK {
+		t.Fatal("the quotas should be separated by user")
+	}
+	now = now.Add(time.Minute)
+	if resp := do("alice"); resp.StatusCode != http.StatusOK {
+		t.Fatal("the quota should be reset at the window boundary")
+	}
+}
+
+func TestQuotaReload(t *testing.T) {
+	p, err := proxy.New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options, err := anypb.New(&v1.Quota{Limit: 1, Key: &v1.Quota_ByHeader{ByHeader: "X-Reload-User"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &config.Gateway{
+		Middlewares: []*config.Middleware{{Name: "quota", Options: options}},
+		Endpoints: []*config.Endpoint{
+			{Protocol: config.Protocol_HTTP, Path: "/a"},
+			{Protocol: config.Protocol_HTTP, Path: "/b"},
+		},
+	}
+	do := func(path string) int {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("X-Reload-User", "alice")
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, req)
+		return w.Code
+	}
+	if err := p.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	if code := do("/a"); code != http.StatusOK {
+		t.Fatalf("want 200 but got %d", code)
+	}
+	// the count survives the reload and is shared by the endpoints of the global middleware
+	if err := p.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/a", "/b"} {
+		if code := do(path); code != http.StatusTooManyRequests {
+			t.Fatalf("%s: want 429 after the reload but got %d", path, code)
+		}
+	}
+}
+
+func TestQuotaRetry(t *testing.T) {
+	var attempts int
+	p, err := proxy.New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options, err := anypb.New(&v1.Quota{Limit: 2, Key: &v1.Quota_ByHeader{ByHeader: "X-Retry-User"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol:    config.Protocol_HTTP,
+		Path:        "/retry",
+		Middlewares: []*config.Middleware{{Name: "quota", Options: options}},
+		Retry: &config.Retry{
+			Attempts:   3,
+			Conditions: []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}},
+		},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	// the attempts of a request take one unit of the quota
+	for i, want := range []string{"1", "0"} {
+		req := httptest.NewRequest("GET", "/retry", nil)
+		req.Header.Set("X-Retry-User", "alice")
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, req)
+		if got := w.Header().Get("X-RateLimit-Remaining"); got != want {
+			t.Fatalf("request %d: want remaining %s but got %s", i, want, got)
+		}
+	}
+	if attempts != 6 {
+		t.Fatalf("want 6 attempts but got %d", attempts)
+	}
+}
@@ -0,0 +1,60 @@
+package quota
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store is the storage of the quota counters.
+type Store interface {
+	// Incr increases the counter of the key in the window starting at start,
+	// returns the number of requests in the window including this one.
+	Incr(ctx context.Context, key string, start time.Time, window time.Duration) (int64, error)
+}
+
+const _sweepThreshold = 10000
+
+type counter struct {
+	start time.Time
+	count int64
+}
+
+type memoryStore struct {
+	lock     sync.Mutex
+	counters map[string]*counter
+}
+
+// NewMemoryStore returns a store which counts requests in the local process.
+func NewMemoryStore() Store {
+	return &memoryStore{counters: make(map[string]*counter)}
+}
+
+func (s *memoryStore) Incr(_ context.Context, key string, start time.Time, window time.Duration) (int64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	c, ok := s.counters[key]
+	if !ok {
+		if len(s.counters) >= _sweepThreshold {
+			s.sweep(start)
+		}
+		c = &counter{start: start}
+		s.counters[key] = c
+	}
+	if !c.start.Equal(start) {
+		// reset at the window boundary
+		c.start = start
+		c.count = 0
+	}
+	c.count++
+	return c.count, nil
+}
+
+// sweep removes the counters of the past windows, they are equal to new ones.
+func (s *memoryStore) sweep(start time.Time) {
+	for key, c := range s.counters {
+		if c.start.Before(start) {
+			delete(s.counters, key)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const _globalKey = "*"
+
+var (
+	// _stores are shared by the same options, so that the reloads neither leak the redis clients
+	// nor reset the buckets, and the limit of the global middleware is shared by the endpoints.
+	_stores = struct {
+		sync.Mutex
+		m map[string]Store
+	}{m: make(map[string]Store)}
+
+	_metricLimitedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_ratelimit_limited_total",
+		Help:      "The total number of rate limited requests",
+	}, []string{"method", "path"})
+	_metricStoreErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_ratelimit_store_errors_total",
+		Help:      "The total number of rate limit store errors",
+	}, []string{"store"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricLimitedTotal)
+	prometheus.MustRegister(_metricStoreErrorsTotal)
+	middleware.Register("ratelimit", Middleware)
+}
+
+// loadStore returns the store of the options, the redis store is shared by the same redis options,
+// and the memory store by the same limit options since its buckets are of the limit.
+func loadStore(options *v1.RateLimit) (Store, string, error) {
+	name, keyed := "memory", proto.Message(options)
+	r, isRedis := options.Store.(*v1.RateLimit_Redis)
+	if isRedis {
+		name, keyed = "redis", r.Redis
+	}
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(keyed)
+	if err != nil {
+		return nil, "", err
+	}
+	key = append([]byte(name+":"), key...)
+	_stores.Lock()
+	defer _stores.Unlock()
+	if s, ok := _stores.m[string(key)]; ok {
+		return s, name, nil
+	}
+	s := &watchedStore{name: name}
+	if isRedis {
+		s.Store = NewRedisStore(r.Redis)
+	} else {
+		s.Store = NewMemoryStore()
+	}
+	_stores.m[string(key)] = s
+	return s, name, nil
+}
+
+// watchedStore logs the store switching between healthy and failing instead of every failed take,
+// the failed takes are counted by the metric.
+type watchedStore struct {
+	Store
+	name    string
+	failing int32
+}
+
+func (s *watchedStore) Take(ctx context.Context, key string, limit Limit) (bool, error) {
+	allowed, err := s.Store.Take(ctx, key, limit)
+	if err != nil {
+		if atomic.CompareAndSwapInt32(&s.failing, 0, 1) {
+			log.Errorf("Rate limit store is failing: %s: %+v", s.name, err)
+		}
+		return allowed, err
+	}
+	if atomic.CompareAndSwapInt32(&s.failing, 1, 0) {
+		log.Infof("Rate limit store is recovered: %s", s.name)
+	}
+	return allowed, nil
+}
+
+// limitScope returns the scope of the buckets of the options, which is the hash of the options
+// but the store, so that the limiters with the different limits never share a bucket of the shared store.
+func limitScope(options *v1.RateLimit) (string, error) {
+	scoped := proto.Clone(options).(*v1.RateLimit)
+	scoped.Store = nil
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(scoped)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+func limitKey(options *v1.RateLimit, req *http.Request) string {
+	switch key := options.Key.(type) {
+	case *v1.RateLimit_ByClientIp:
+		if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			return host
+		}
+		return req.RemoteAddr
+	case *v1.RateLimit_ByHeader:
+		return req.Header.Get(key.ByHeader)
+	default:
+		return _globalKey
+	}
+}
+
+func newLimitedResponse(statusCode int) *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(statusCode),
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(&bytes.Buffer{}),
+	}
+}
+
+// Middleware is a token bucket rate limiter backed by a pluggable store.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.RateLimit{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.Rate <= 0 {
+		return nil, errors.New("ratelimit: rate must be greater than zero")
+	}
+	store, storeName, err := loadStore(options)
+	if err != nil {
+		return nil, err
+	}
+	scope, err := limitScope(options)
+	if err != nil {
+		return nil, err
+	}
+	return newMiddleware(options, scope, store, storeName), nil
+}
+
+func newMiddleware(options *v1.RateLimit, scope string, store Store, storeName string) middleware.Middleware {
+	limit := Limit{Rate: options.Rate, Burst: options.Burst}
+	if limit.Burst <= 0 {
+		limit.Burst = int64(options.Rate)
+		if limit.Burst < 1 {
+			limit.Burst = 1
+		}
+	}
+	statusCode := http.StatusTooManyRequests
+	if options.StatusCode != 0 {
+		statusCode = int(options.StatusCode)
+	}
+	failClosed := options.FailurePolicy == v1.FailurePolicy_FAIL_CLOSED
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			allowed, err := store.Take(req.Context(), scope+":"+limitKey(options, req), limit)
+			if err != nil {
+				_metricStoreErrorsTotal.WithLabelValues(storeName).Inc()
+				allowed = !failClosed
+			}
+			if !allowed {
+				_metricLimitedTotal.WithLabelValues(req.Method, middleware.PathLabel(req)).Inc()
+				return newLimitedResponse(statusCode), nil
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
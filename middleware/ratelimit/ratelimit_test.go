@@ -0,0 +1,152 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Now()
+	s := &memoryStore{
+		buckets: make(map[string]*bucket),
+		now:     func() time.Time { return now },
+	}
+	limit := Limit{Rate: 10, Burst: 2}
+	for i, want := range []bool{true, true, false} {
+		if allowed, _ := s.Take(context.Background(), "a", limit); allowed != want {
+			t.Fatalf("take %d: want %v but got %v", i, want, allowed)
+		}
+	}
+	if allowed, _ := s.Take(context.Background(), "b", limit); !allowed {
+		t.Fatal("the buckets should be separated by key")
+	}
+	now = now.Add(100 * time.Millisecond)
+	if allowed, _ := s.Take(context.Background(), "a", limit); !allowed {
+		t.Fatal("the bucket should be refilled")
+	}
+}
+
+type errStore struct{}
+
+func (errStore) Take(context.Context, string, Limit) (bool, error) {
+	return false, errors.New("unavailable")
+}
+
+func TestFailurePolicy(t *testing.T) {
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	tests := []struct {
+		policy     v1.FailurePolicy
+		statusCode int
+	}{
+		{policy: v1.FailurePolicy_FAIL_OPEN, statusCode: http.StatusOK},
+		{policy: v1.FailurePolicy_FAIL_CLOSED, statusCode: http.StatusTooManyRequests},
+	}
+	for _, test := range tests {
+		m := newMiddleware(&v1.RateLimit{Rate: 1, FailurePolicy: test.policy}, "", errStore{}, "err")
+		resp, err := m(next).RoundTrip(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != test.statusCode {
+			t.Errorf("%s: want %d but got %d", test.policy, test.statusCode, resp.StatusCode)
+		}
+	}
+}
+
+type flakyStore struct {
+	err error
+}
+
+func (s *flakyStore) Take(context.Context, string, Limit) (bool, error) {
+	return s.err == nil, s.err
+}
+
+func TestWatchedStore(t *testing.T) {
+	flaky := &flakyStore{}
+	s := &watchedStore{Store: flaky, name: "flaky"}
+	for _, test := range []struct {
+		err     error
+		failing int32
+	}{
+		{failing: 0},
+		{err: errors.New("unavailable"), failing: 1},
+		{err: errors.New("unavailable"), failing: 1},
+		{failing: 0},
+	} {
+		flaky.err = test.err
+		if _, err := s.Take(context.Background(), "a", Limit{Rate: 1, Burst: 1}); err != test.err {
+			t.Fatalf("want error %v but got %v", test.err, err)
+		}
+		if s.failing != test.failing {
+			t.Fatalf("want failing %d but got %d", test.failing, s.failing)
+		}
+	}
+}
+
+func TestSharedStore(t *testing.T) {
+	options, err := anypb.New(&v1.RateLimit{Rate: 0.001, Burst: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	// the builds of the endpoints and the reloads take from the same bucket
+	for i, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		m, err := Middleware(&config.Middleware{Options: options})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := m(next).RoundTrip(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != want {
+			t.Fatalf("build %d: want %d but got %d", i, want, resp.StatusCode)
+		}
+	}
+
+	redis := &v1.RateLimit{Rate: 1, Store: &v1.RateLimit_Redis{Redis: &v1.Redis{Addr: "127.0.0.1:6379"}}}
+	a, _, _ := loadStore(redis)
+	redis.Rate = 2
+	if b, _, _ := loadStore(redis); a != b {
+		t.Fatal("want the redis store shared by the same redis options")
+	}
+}
+
+func TestScopedStore(t *testing.T) {
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	// the limiters of the different limits on the same store never share a bucket
+	store := NewMemoryStore()
+	for _, options := range []*v1.RateLimit{{Rate: 0.001, Burst: 1}, {Rate: 0.002, Burst: 1}} {
+		scope, err := limitScope(options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := newMiddleware(options, scope, store, "memory")(next).RoundTrip(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("rate %v: want %d but got %d", options.Rate, http.StatusOK, resp.StatusCode)
+		}
+	}
+
+	a, _ := limitScope(&v1.RateLimit{Rate: 1, Store: &v1.RateLimit_Redis{Redis: &v1.Redis{Addr: "127.0.0.1:6379"}}})
+	if b, _ := limitScope(&v1.RateLimit{Rate: 1}); a != b {
+		t.Fatal("want the scope regardless of the store")
+	}
+}
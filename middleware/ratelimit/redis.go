@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/ratelimit/v1"
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	defaultRedisKeyPrefix = "gateway:ratelimit:"
+	defaultRedisTimeout   = 100 * time.Millisecond
+)
+
+// _tokenBucketScript takes a token from the bucket atomically,
+// the redis server time is used so that the gateway replicas share the same clock.
+var _tokenBucketScript = redis.NewScript(`
+redis.replicate_commands()
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = redis.call("TIME")
+now = tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000)
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(bucket[1]) or burst
+local last = tonumber(bucket[2]) or now
+if now > last then
+	tokens = math.min(burst, tokens + (now - last) * rate / 1000)
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tokens, "last", now)
+redis.call("PEXPIRE", KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return allowed
+`)
+
+type redisStore struct {
+	client    *redis.Client
+	keyPrefix string
+	timeout   time.Duration
+}
+
+// NewRedisStore returns a store which shares the limits across the gateway replicas.
+func NewRedisStore(c *v1.Redis) Store {
+	s := &redisStore{
+		keyPrefix: defaultRedisKeyPrefix,
+		timeout:   defaultRedisTimeout,
+	}
+	if c.KeyPrefix != "" {
+		s.keyPrefix = c.KeyPrefix
+	}
+	if c.Timeout != nil && c.Timeout.AsDuration() > 0 {
+		s.timeout = c.Timeout.AsDuration()
+	}
+	s.client = redis.NewClient(&redis.Options{
+		Addr:         c.Addr,
+		Username:     c.Username,
+		Password:     c.Password,
+		DB:           int(c.Db),
+		DialTimeout:  s.timeout,
+		ReadTimeout:  s.timeout,
+		WriteTimeout: s.timeout,
+	})
+	return s
+}
+
+func (s *redisStore) Take(ctx context.Context, key string, limit Limit) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	allowed, err := _tokenBucketScript.Run(ctx, s.client, []string{s.keyPrefix + key}, limit.Rate, limit.Burst).Int64()
+	if err != nil {
+		return false, err
+	}
+	return allowed == 1, nil
+}
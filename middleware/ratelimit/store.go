@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limit is the token bucket limit.
+type Limit struct {
+	// Rate is the tokens refilled per second.
+	Rate float64
+	// Burst is the bucket capacity.
+	Burst int64
+}
+
+// Store is the storage of the token buckets.
+type Store interface {
+	// Take takes a token from the bucket of the key, reports whether the request is allowed.
+	Take(ctx context.Context, key string, limit Limit) (bool, error)
+}
+
+const _sweepThreshold = 10000
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type memoryStore struct {
+	lock    sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// NewMemoryStore returns a store which limits requests in the local process.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, limit Limit) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	b, ok := s.buckets[key]
+	if !ok {
+		if len(s.buckets) >= _sweepThreshold {
+			s.sweep(now, limit)
+		}
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		s.buckets[key] = b
+	}
+	b.tokens = refill(b.tokens, now.Sub(b.last), limit)
+	b.last = now
+	if b.tokens < 1 {
+		return false, nil
+	}
+	b.tokens--
+	return true, nil
+}
+
+// sweep removes the buckets which are full again, they are equal to new ones.
+func (s *memoryStore) sweep(now time.Time, limit Limit) {
+	for key, b := range s.buckets {
+		if refill(b.tokens, now.Sub(b.last), limit) >= float64(limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func refill(tokens float64, elapsed time.Duration, limit Limit) float64 {
+	if elapsed <= 0 {
+		return tokens
+	}
+	return math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate)
+}
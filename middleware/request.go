@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"net/http"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
 	"github.com/go-kratos/kratos/v2/selector"
@@ -17,6 +18,7 @@ type RequestOptions struct {
 	Metadata             map[string]string
 	UpstreamStatusCode   []int
 	UpstreamResponseTime []float64
+	Sanitizer            *Sanitizer
 }
 
 // NewRequestOptions new a request options with retry filter.
@@ -106,3 +108,20 @@ func WithSelectorFitler(ctx context.Context, fn selector.Filter) context.Context
 	}
 	return ctx
 }
+
+// PathLabel returns the path label of the middleware metrics of the request, it's the path template of the endpoint
+// rather than the request path, so that the cardinality is bounded by the routes.
+func PathLabel(req *http.Request) string {
+	if e, ok := EndpointFromContext(req.Context()); ok && e != nil {
+		return e.Path
+	}
+	return ""
+}
+
+// SanitizedPath returns the request path redacted by the sanitizer of the request, e.g. for the logs.
+func SanitizedPath(req *http.Request) string {
+	if o, ok := FromRequestContext(req.Context()); ok {
+		return o.Sanitizer.Path(req.URL.Path)
+	}
+	return req.URL.Path
+}
@@ -0,0 +1,100 @@
+package requestid
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	_crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	_base62    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	_base62Len = 22
+)
+
+// randPool reads the random bytes from the buffer refilled by crypto/rand, so that the ids don't read
+// the system source per request.
+type randPool struct {
+	lock   sync.Mutex
+	reader *bufio.Reader
+}
+
+func (p *randPool) Read(b []byte) (int, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return io.ReadFull(p.reader, b)
+}
+
+var _rand = &randPool{reader: bufio.NewReaderSize(rand.Reader, 4096)}
+
+type generator func(now time.Time) (string, error)
+
+func uuidV4(time.Time) (string, error) {
+	id, err := uuid.NewRandomFromReader(_rand)
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// putMillis puts the 48 bits unix milliseconds in big endian.
+func putMillis(b []byte, now time.Time) {
+	ms := uint64(now.UnixNano() / int64(time.Millisecond))
+	b[0], b[1], b[2], b[3], b[4], b[5] = byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+}
+
+func uuidV7(now time.Time) (string, error) {
+	var id uuid.UUID
+	putMillis(id[:6], now)
+	if _, err := _rand.Read(id[6:]); err != nil {
+		return "", err
+	}
+	id[6] = id[6]&0x0f | 0x70 // version 7
+	id[8] = id[8]&0x3f | 0x80 // variant RFC 4122
+	return id.String(), nil
+}
+
+func ulid(now time.Time) (string, error) {
+	var b [16]byte
+	putMillis(b[:6], now)
+	if _, err := _rand.Read(b[6:]); err != nil {
+		return "", err
+	}
+	// the 128 bits are encoded in 26 chars of 5 bits, the first char has the top 3 bits only
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = _crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:]), nil
+}
+
+func base62(time.Time) (string, error) {
+	var (
+		out [_base62Len]byte
+		buf [32]byte
+	)
+	for n := 0; n < _base62Len; {
+		if _, err := _rand.Read(buf[:]); err != nil {
+			return "", err
+		}
+		for _, c := range buf {
+			// the bytes beyond the multiple of 62 are rejected to keep the chars uniform
+			if c >= 248 {
+				continue
+			}
+			out[n] = _base62[c%62]
+			if n++; n == _base62Len {
+				break
+			}
+		}
+	}
+	return string(out[:]), nil
+}
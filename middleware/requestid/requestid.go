@@ -0,0 +1,73 @@
+package requestid
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/requestid/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultHeader = "X-Request-Id"
+	// _idMetadata is the metadata key of the generated id, so that the attempts of a request share it.
+	_idMetadata = "requestid.id"
+)
+
+func init() {
+	middleware.Register("requestid", Middleware)
+}
+
+var _generators = map[v1.Format]generator{
+	v1.Format_UUID_V4: uuidV4,
+	v1.Format_UUID_V7: uuidV7,
+	v1.Format_ULID:    ulid,
+	v1.Format_BASE62:  base62,
+}
+
+// Middleware generates the request id of the requests without one in the format.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.RequestID{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	generate, ok := _generators[options.Format]
+	if !ok {
+		return nil, fmt.Errorf("requestid: unknown format: %s", options.Format)
+	}
+	header := defaultHeader
+	if options.Header != "" {
+		header = options.Header
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			reqOpt, ok := middleware.FromRequestContext(req.Context())
+			id := req.Header.Get(header)
+			if ok && reqOpt.Metadata[_idMetadata] != "" {
+				id = reqOpt.Metadata[_idMetadata]
+				req.Header.Set(header, id)
+			} else if id == "" || options.Override {
+				var err error
+				if id, err = generate(time.Now()); err != nil {
+					return nil, err
+				}
+				req.Header.Set(header, id)
+				if ok {
+					reqOpt.Metadata[_idMetadata] = id
+				}
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			resp.Header.Set(header, id)
+			return resp, nil
+		})
+	}, nil
+}
@@ -0,0 +1,117 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/requestid/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		format v1.Format
+		re     *regexp.Regexp
+	}{
+		{format: v1.Format_UUID_V4, re: regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{format: v1.Format_UUID_V7, re: regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)},
+		{format: v1.Format_ULID, re: regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)},
+		{format: v1.Format_BASE62, re: regexp.MustCompile(`^[0-9A-Za-z]{22}$`)},
+	}
+	for _, tt := range tests {
+		generate := _generators[tt.format]
+		seen := make(map[string]struct{})
+		for i := 0; i < 100; i++ {
+			id, err := generate(time.Now())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.re.MatchString(id) {
+				t.Fatalf("want the %s id but got %q", tt.format, id)
+			}
+			if _, ok := seen[id]; ok {
+				t.Fatalf("want the unique %s ids but got %q twice", tt.format, id)
+			}
+			seen[id] = struct{}{}
+		}
+	}
+
+	// the time-sortable ones are ordered by the milliseconds
+	now := time.Now()
+	for _, generate := range []generator{uuidV7, ulid} {
+		prev, err := generate(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		next, err := generate(now.Add(time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prev >= next {
+			t.Fatalf("want %q sorted before %q", prev, next)
+		}
+	}
+	if id, _ := ulid(time.Unix(0, 0)); id[:10] != "0000000000" {
+		t.Fatalf("want the zero time in the ulid but got %q", id)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	v, err := anypb.New(&v1.RequestID{Format: v1.Format_ULID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var forwarded []string
+	next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		forwarded = append(forwarded, req.Header.Get("X-Request-Id"))
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+	}))
+
+	// the incoming id is kept
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-Id", "incoming")
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.Header.Get("X-Request-Id"); got != "incoming" || forwarded[0] != "incoming" {
+		t.Fatalf("want the incoming id kept but got %q %q", got, forwarded[0])
+	}
+
+	// the attempts of a request share the generated id
+	ctx := middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{}))
+	forwarded = nil
+	for i := 0; i < 2; i++ {
+		resp, err := next.RoundTrip(httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := resp.Header.Get("X-Request-Id"); got != forwarded[i] || len(got) != 26 {
+			t.Fatalf("want the generated id responded but got %q %q", got, forwarded[i])
+		}
+	}
+	if forwarded[0] != forwarded[1] {
+		t.Fatalf("want the same id of the attempts but got %v", forwarded)
+	}
+
+	if _, err := Middleware(&config.Middleware{Options: mustAny(t, &v1.RequestID{Format: 42})}); err == nil {
+		t.Fatal("want error for the unknown format")
+	}
+}
+
+func mustAny(t *testing.T, m *v1.RequestID) *anypb.Any {
+	v, err := anypb.New(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
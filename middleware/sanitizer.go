@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"regexp"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+const defaultReplacement = "REDACTED"
+
+// Sanitizer redacts the sensitive parts of path and query before they are recorded,
+// a nil sanitizer returns the input as is.
+type Sanitizer struct {
+	queryParams  map[string]struct{}
+	pathPatterns []*regexp.Regexp
+	replacement  string
+}
+
+// NewSanitizer new a sanitizer, it returns nil if nothing to redact.
+func NewSanitizer(c *config.Sanitize) (*Sanitizer, error) {
+	if c == nil || (len(c.QueryParams) == 0 && len(c.PathPatterns) == 0) {
+		return nil, nil
+	}
+	s := &Sanitizer{
+		queryParams:  make(map[string]struct{}, len(c.QueryParams)),
+		pathPatterns: make([]*regexp.Regexp, 0, len(c.PathPatterns)),
+		replacement:  defaultReplacement,
+	}
+	if c.Replacement != "" {
+		s.replacement = c.Replacement
+	}
+	for _, name := range c.QueryParams {
+		s.queryParams[strings.ToLower(name)] = struct{}{}
+	}
+	for _, pattern := range c.PathPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		s.pathPatterns = append(s.pathPatterns, re)
+	}
+	return s, nil
+}
+
+// Path redacts the path parts matched the patterns.
+func (s *Sanitizer) Path(path string) string {
+	if s == nil {
+		return path
+	}
+	for _, re := range s.pathPatterns {
+		path = re.ReplaceAllLiteralString(path, s.replacement)
+	}
+	return path
+}
+
+// Query redacts the values of the query params, the raw query is kept in order.
+func (s *Sanitizer) Query(rawQuery string) string {
+	if s == nil || len(s.queryParams) == 0 || rawQuery == "" {
+		return rawQuery
+	}
+	pairs := strings.Split(rawQuery, "&")
+	for i, pair := range pairs {
+		name := pair
+		if idx := strings.IndexByte(pair, '='); idx >= 0 {
+			name = pair[:idx]
+		}
+		if _, ok := s.queryParams[strings.ToLower(name)]; ok {
+			pairs[i] = name + "=" + s.replacement
+		}
+	}
+	return strings.Join(pairs, "&")
+}
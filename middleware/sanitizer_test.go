@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+func TestSanitizer(t *testing.T) {
+	s, err := NewSanitizer(&config.Sanitize{
+		QueryParams:  []string{"token", "Secret"},
+		PathPatterns: []string{"[0-9a-f]{32}", "[0-9]+$"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.Path("/files/0123456789abcdef0123456789abcdef/users/42"), "/files/REDACTED/users/REDACTED"; got != want {
+		t.Errorf("want %s but got %s", want, got)
+	}
+	if got, want := s.Query("a=1&token=abc&secret=x&token&b=2"), "a=1&token=REDACTED&secret=REDACTED&token=REDACTED&b=2"; got != want {
+		t.Errorf("want %s but got %s", want, got)
+	}
+	var nop *Sanitizer
+	if got := nop.Query("token=abc"); got != "token=abc" {
+		t.Errorf("nil sanitizer should keep the query but got %s", got)
+	}
+	if _, err := NewSanitizer(&config.Sanitize{PathPatterns: []string{"("}}); err == nil {
+		t.Error("want error on invalid pattern")
+	}
+}
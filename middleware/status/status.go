@@ -0,0 +1,130 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/status/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const defaultMaxBodyBytes = 64 << 10
+
+func init() {
+	middleware.Register("status", Middleware)
+}
+
+type rule struct {
+	from    int
+	to      int
+	headers map[string]*regexp.Regexp
+	body    *regexp.Regexp
+}
+
+func newRule(in *v1.Rule) (*rule, error) {
+	if in.From == 0 || in.To == 0 {
+		return nil, fmt.Errorf("status: invalid rule: %d -> %d", in.From, in.To)
+	}
+	r := &rule{
+		from:    int(in.From),
+		to:      int(in.To),
+		headers: make(map[string]*regexp.Regexp, len(in.Headers)),
+	}
+	for name, pattern := range in.Headers {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		r.headers[name] = re
+	}
+	if in.Body != "" {
+		re, err := regexp.Compile(in.Body)
+		if err != nil {
+			return nil, err
+		}
+		r.body = re
+	}
+	return r, nil
+}
+
+func (r *rule) match(resp *http.Response, body func() []byte) bool {
+	if resp.StatusCode != r.from {
+		return false
+	}
+	for name, re := range r.headers {
+		if !re.MatchString(resp.Header.Get(name)) {
+			return false
+		}
+	}
+	return r.body == nil || r.body.Match(body())
+}
+
+// Middleware remaps the upstream status codes to the client facing ones.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Status{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	rules := make([]*rule, 0, len(options.Rules))
+	for _, in := range options.Rules {
+		r, err := newRule(in)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	maxBodyBytes := int64(defaultMaxBodyBytes)
+	if options.MaxBodyBytes > 0 {
+		maxBodyBytes = options.MaxBodyBytes
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+			var (
+				prefix  []byte
+				readErr error
+				read    bool
+			)
+			// the body prefix is read once and only if a body pattern is evaluated
+			body := func() []byte {
+				if !read {
+					read = true
+					prefix, readErr = io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+					resp.Body = struct {
+						io.Reader
+						io.Closer
+					}{io.MultiReader(bytes.NewReader(prefix), resp.Body), resp.Body}
+				}
+				return prefix
+			}
+			for _, r := range rules {
+				if !r.match(resp, body) {
+					continue
+				}
+				if readErr != nil {
+					resp.Body.Close()
+					return nil, readErr
+				}
+				if options.OriginalHeader != "" {
+					resp.Header.Set(options.OriginalHeader, strconv.Itoa(resp.StatusCode))
+				}
+				resp.StatusCode = r.to
+				resp.Status = strconv.Itoa(r.to) + " " + http.StatusText(r.to)
+				break
+			}
+			return resp, nil
+		})
+	}, nil
+}
@@ -0,0 +1,67 @@
+package status
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/status/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestStatus(t *testing.T) {
+	v, err := anypb.New(&v1.Status{
+		OriginalHeader: "X-Original-Status",
+		Rules: []*v1.Rule{
+			{From: 418, To: 400},
+			{From: 200, To: 502, Body: `"error"`},
+			{From: 200, To: 503, Headers: map[string]string{"X-Error": "^busy$"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: v})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		code     int
+		header   http.Header
+		body     string
+		want     int
+		original string
+	}{
+		{code: 418, header: http.Header{}, body: "teapot", want: 400, original: "418"},
+		{code: 200, header: http.Header{}, body: `{"error": "oops"}`, want: 502, original: "200"},
+		{code: 200, header: http.Header{"X-Error": []string{"busy"}}, body: "{}", want: 503, original: "200"},
+		{code: 200, header: http.Header{}, body: "{}", want: 200},
+	}
+	for _, tt := range tests {
+		next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: tt.code,
+				Header:     tt.header,
+				Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+			}, nil
+		}))
+		resp, err := next.RoundTrip(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Fatalf("want status %d, got: %d", tt.want, resp.StatusCode)
+		}
+		if got := resp.Header.Get("X-Original-Status"); got != tt.original {
+			t.Fatalf("want original status %q, got: %q", tt.original, got)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		if string(body) != tt.body {
+			t.Fatalf("want body %q, got: %q", tt.body, body)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package tee
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const (
+	defaultBufferSize    = 1024
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+)
+
+// publisher buffers the events and sends them in batches from a single goroutine.
+type publisher struct {
+	sink          Sink
+	events        chan *Event
+	batchSize     int
+	flushInterval time.Duration
+}
+
+func newPublisher(sink Sink, bufferSize, batchSize int, flushInterval time.Duration) *publisher {
+	p := &publisher{
+		sink:          sink,
+		events:        make(chan *Event, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+	}
+	go p.run()
+	return p
+}
+
+// publish never blocks, it returns false if the buffer is full.
+func (p *publisher) publish(e *Event) bool {
+	select {
+	case p.events <- e:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *publisher) run() {
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+	batch := make([]*Event, 0, p.batchSize)
+	for {
+		select {
+		case e := <-p.events:
+			batch = append(batch, e)
+			if len(batch) < p.batchSize {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		p.send(batch)
+		batch = make([]*Event, 0, p.batchSize)
+	}
+}
+
+func (p *publisher) send(batch []*Event) {
+	if err := p.sink.Send(context.Background(), batch); err != nil {
+		_metricEventsTotal.WithLabelValues("failed").Add(float64(len(batch)))
+		log.Errorf("Failed to send %d events to tee sink: %+v", len(batch), err)
+		return
+	}
+	_metricEventsTotal.WithLabelValues("sent").Add(float64(len(batch)))
+}
@@ -0,0 +1,81 @@
+package tee
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/tee/v1"
+)
+
+const defaultHTTPTimeout = 5 * time.Second
+
+// Event is the metadata of a request, the bodies are never recorded.
+type Event struct {
+	Time          time.Time         `json:"time"`
+	Method        string            `json:"method"`
+	Host          string            `json:"host"`
+	Path          string            `json:"path"`
+	StatusCode    int               `json:"status_code"`
+	Latency       float64           `json:"latency"`
+	RequestBytes  int64             `json:"request_bytes"`
+	ResponseBytes int64             `json:"response_bytes"`
+	Tags          map[string]string `json:"tags,omitempty"`
+	Error         string            `json:"error,omitempty"`
+	// Attempt is the number of the retry attempt of the request starting from 1, each attempt is an event.
+	Attempt int `json:"attempt"`
+}
+
+// Sink receives the batches of events from the publisher goroutine.
+type Sink interface {
+	Send(ctx context.Context, events []*Event) error
+}
+
+type httpSink struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+// NewHTTPSink returns a sink which posts the events as a JSON array.
+func NewHTTPSink(c *v1.HTTP) Sink {
+	timeout := defaultHTTPTimeout
+	if c.Timeout != nil && c.Timeout.AsDuration() > 0 {
+		timeout = c.Timeout.AsDuration()
+	}
+	return &httpSink{
+		client:  &http.Client{Timeout: timeout},
+		url:     c.Url,
+		headers: c.Headers,
+	}
+}
+
+func (s *httpSink) Send(ctx context.Context, events []*Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("tee: unexpected sink status code: %d", resp.StatusCode)
+	}
+	return nil
+}
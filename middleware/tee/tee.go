@@ -0,0 +1,180 @@
+package tee
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/tee/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var (
+	// _publishers are shared by the same options, so that the reloads don't leak the goroutines.
+	_publishers = struct {
+		sync.Mutex
+		m map[string]*publisher
+	}{m: make(map[string]*publisher)}
+
+	_metricEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "tee_events_total",
+		Help:      "The total number of tee events by result",
+	}, []string{"result"})
+)
+
+// _attemptsMetadata counts the attempts of the request published by the middleware,
+// it's suffixed by the instance, since a request may pass many tees.
+const _attemptsMetadata = "tee.attempts."
+
+var _instances int64
+
+func init() {
+	prometheus.MustRegister(_metricEventsTotal)
+	middleware.Register("tee", Middleware)
+}
+
+func newSink(options *v1.Tee) (Sink, error) {
+	switch sink := options.Sink.(type) {
+	case *v1.Tee_Http:
+		if sink.Http.Url == "" {
+			return nil, errors.New("tee: http sink url must be specified")
+		}
+		return NewHTTPSink(sink.Http), nil
+	default:
+		return nil, errors.New("tee: sink must be specified")
+	}
+}
+
+func loadPublisher(options *v1.Tee) (*publisher, error) {
+	key, err := proto.MarshalOptions{Deterministic: true}.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	_publishers.Lock()
+	defer _publishers.Unlock()
+	if p, ok := _publishers.m[string(key)]; ok {
+		return p, nil
+	}
+	sink, err := newSink(options)
+	if err != nil {
+		return nil, err
+	}
+	bufferSize := defaultBufferSize
+	if options.BufferSize > 0 {
+		bufferSize = int(options.BufferSize)
+	}
+	batchSize := defaultBatchSize
+	if options.BatchSize > 0 {
+		batchSize = int(options.BatchSize)
+	}
+	flushInterval := defaultFlushInterval
+	if options.FlushInterval != nil && options.FlushInterval.AsDuration() > 0 {
+		flushInterval = options.FlushInterval.AsDuration()
+	}
+	p := newPublisher(sink, bufferSize, batchSize, flushInterval)
+	_publishers.m[string(key)] = p
+	return p, nil
+}
+
+// Middleware publishes the metadata of requests to the analytics sink.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Tee{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	p, err := loadPublisher(options)
+	if err != nil {
+		return nil, err
+	}
+	return newMiddleware(p, options.TagHeaders), nil
+}
+
+func newMiddleware(p *publisher, tagHeaders []string) middleware.Middleware {
+	publish := func(e *Event) {
+		if !p.publish(e) {
+			_metricEventsTotal.WithLabelValues("dropped").Inc()
+		}
+	}
+	attempts := _attemptsMetadata + strconv.FormatInt(atomic.AddInt64(&_instances, 1), 10)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			startTime := time.Now()
+			e := &Event{
+				Time:         startTime,
+				Method:       req.Method,
+				Host:         req.Host,
+				Path:         req.URL.Path,
+				RequestBytes: req.ContentLength,
+				Attempt:      1,
+			}
+			if reqOpt, ok := middleware.FromRequestContext(req.Context()); ok {
+				e.Path = reqOpt.Sanitizer.Path(req.URL.Path)
+				if n, err := strconv.Atoi(reqOpt.Metadata[attempts]); err == nil {
+					e.Attempt = n + 1
+				}
+				reqOpt.Metadata[attempts] = strconv.Itoa(e.Attempt)
+			}
+			for _, name := range tagHeaders {
+				if v := req.Header.Get(name); v != "" {
+					if e.Tags == nil {
+						e.Tags = make(map[string]string, len(tagHeaders))
+					}
+					e.Tags[name] = v
+				}
+			}
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				e.StatusCode = http.StatusBadGateway
+				e.Error = err.Error()
+				e.Latency = time.Since(startTime).Seconds()
+				publish(e)
+				return nil, err
+			}
+			e.StatusCode = resp.StatusCode
+			// the body of the upgraded connection is the io.ReadWriteCloser of the tunnel, it's kept as is
+			if resp.StatusCode == http.StatusSwitchingProtocols {
+				e.Latency = time.Since(startTime).Seconds()
+				publish(e)
+				return resp, nil
+			}
+			// the event is published once the body is consumed by the client
+			resp.Body = &countingBody{ReadCloser: resp.Body, onClose: func(n int64) {
+				e.ResponseBytes = n
+				e.Latency = time.Since(startTime).Seconds()
+				publish(e)
+			}}
+			return resp, nil
+		})
+	}
+}
+
+type countingBody struct {
+	io.ReadCloser
+	n       int64
+	once    sync.Once
+	onClose func(int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	atomic.AddInt64(&b.n, int64(n))
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onClose(atomic.LoadInt64(&b.n)) })
+	return err
+}
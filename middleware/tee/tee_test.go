@@ -0,0 +1,142 @@
+package tee
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+type sinkFunc func(ctx context.Context, events []*Event) error
+
+func (f sinkFunc) Send(ctx context.Context, events []*Event) error { return f(ctx, events) }
+
+func TestTee(t *testing.T) {
+	received := make(chan []*Event, 1)
+	p := newPublisher(sinkFunc(func(ctx context.Context, events []*Event) error {
+		received <- events
+		return nil
+	}), 8, 1, time.Hour)
+	next := newMiddleware(p, []string{"X-Client"})(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusCreated,
+			Body:       ioutil.NopCloser(strings.NewReader("hello")),
+		}, nil
+	}))
+	req := httptest.NewRequest("POST", "/users", strings.NewReader("abc"))
+	req.Header.Set("X-Client", "ios")
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-received:
+		t.Fatal("event must be published after the body is closed")
+	case <-time.After(10 * time.Millisecond):
+	}
+	_, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	select {
+	case events := <-received:
+		e := events[0]
+		if e.Method != "POST" || e.Path != "/users" || e.StatusCode != http.StatusCreated {
+			t.Fatalf("unexpected event: %+v", e)
+		}
+		if e.RequestBytes != 3 || e.ResponseBytes != 5 || e.Tags["X-Client"] != "ios" {
+			t.Fatalf("unexpected event: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("event is not published")
+	}
+}
+
+func TestTeeAttempts(t *testing.T) {
+	received := make(chan []*Event, 3)
+	p := newPublisher(sinkFunc(func(ctx context.Context, events []*Event) error {
+		received <- events
+		return nil
+	}), 8, 1, time.Hour)
+	next := newMiddleware(p, nil)(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+	}))
+	req := httptest.NewRequest("GET", "/retry", nil)
+	req = req.WithContext(middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(&config.Endpoint{})))
+	// the attempts of the retry loop share the request options
+	for i := 1; i <= 3; i++ {
+		resp, err := next.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		select {
+		case events := <-received:
+			if events[0].Attempt != i {
+				t.Fatalf("want attempt %d but got %d", i, events[0].Attempt)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("event is not published")
+		}
+	}
+}
+
+type tunnelBody struct {
+	io.Reader
+}
+
+func (tunnelBody) Write(p []byte) (int, error) { return len(p), nil }
+func (tunnelBody) Close() error                { return nil }
+
+func TestTeeUpgrade(t *testing.T) {
+	received := make(chan []*Event, 1)
+	p := newPublisher(sinkFunc(func(ctx context.Context, events []*Event) error {
+		received <- events
+		return nil
+	}), 8, 1, time.Hour)
+	next := newMiddleware(p, nil)(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusSwitchingProtocols,
+			Body:       tunnelBody{strings.NewReader("")},
+		}, nil
+	}))
+	resp, err := next.RoundTrip(httptest.NewRequest("GET", "/ws", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := resp.Body.(io.ReadWriteCloser); !ok {
+		t.Fatal("want the body of the upgraded connection writable")
+	}
+	select {
+	case events := <-received:
+		if e := events[0]; e.Path != "/ws" || e.StatusCode != http.StatusSwitchingProtocols {
+			t.Fatalf("unexpected event: %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("event is not published")
+	}
+}
+
+func TestPublisherDropsWhenFull(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	p := newPublisher(sinkFunc(func(ctx context.Context, events []*Event) error {
+		<-block
+		return nil
+	}), 1, 1, time.Hour)
+	dropped := 0
+	for i := 0; i < 10; i++ {
+		if !p.publish(&Event{}) {
+			dropped++
+		}
+	}
+	// one in the sending batch at most and one in the buffer
+	if dropped < 8 {
+		t.Fatalf("want the events dropped when the buffer is full, dropped: %d", dropped)
+	}
+}
@@ -0,0 +1,64 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+const _sweepThreshold = 10000
+
+// window counts the requests of one tenant in the current and the previous second.
+type window struct {
+	start    time.Time
+	current  float64
+	previous float64
+}
+
+// meter measures the request rate of each tenant with the sliding window approximation.
+type meter struct {
+	lock    sync.Mutex
+	windows map[string]*window
+	now     func() time.Time
+}
+
+func newMeter() *meter {
+	return &meter{
+		windows: make(map[string]*window),
+		now:     time.Now,
+	}
+}
+
+// mark records a request of the tenant and returns its rate per second.
+func (m *meter) mark(tenant string) float64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	now := m.now()
+	start := now.Truncate(time.Second)
+	w, ok := m.windows[tenant]
+	if !ok {
+		if len(m.windows) >= _sweepThreshold {
+			m.sweep(start)
+		}
+		w = &window{start: start}
+		m.windows[tenant] = w
+	}
+	switch elapsed := start.Sub(w.start); {
+	case elapsed == time.Second:
+		w.previous, w.current = w.current, 0
+	case elapsed > time.Second:
+		w.previous, w.current = 0, 0
+	}
+	w.start = start
+	w.current++
+	weight := 1 - float64(now.Sub(start))/float64(time.Second)
+	return w.previous*weight + w.current
+}
+
+// sweep removes the idle windows, they are equal to new ones.
+func (m *meter) sweep(start time.Time) {
+	for tenant, w := range m.windows {
+		if start.Sub(w.start) > time.Second {
+			delete(m.windows, tenant)
+		}
+	}
+}
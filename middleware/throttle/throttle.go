@@ -0,0 +1,118 @@
+package throttle
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/throttle/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultMaxDelay  = time.Second
+	defaultClassName = "default"
+	// _throttledMetadata marks the request measured by the middleware, so that the retries are neither
+	// measured nor delayed again. it's suffixed by the instance, since a request may pass many throttles.
+	_throttledMetadata = "throttle.throttled."
+)
+
+var _instances int64
+
+var (
+	_metricInjectedDelay = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_throttle_delay_seconds",
+		Help:      "Injected delay(sec) of the throttled requests",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+	}, []string{"class"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricInjectedDelay)
+	middleware.Register("throttle", Middleware)
+}
+
+func tenantKey(options *v1.Throttle, req *http.Request) string {
+	switch key := options.Tenant.(type) {
+	case *v1.Throttle_ByClientIp:
+		if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			return host
+		}
+		return req.RemoteAddr
+	case *v1.Throttle_ByHeader:
+		return req.Header.Get(key.ByHeader)
+	default:
+		return ""
+	}
+}
+
+// calcDelay returns the delay in proportion to the excess rate, up to max.
+func calcDelay(rate, threshold float64, max time.Duration) time.Duration {
+	if rate <= threshold {
+		return 0
+	}
+	excess := (rate - threshold) / threshold
+	if excess >= 1 {
+		return max
+	}
+	return time.Duration(excess * float64(max))
+}
+
+// Middleware delays the requests of the noisy tenants instead of rejecting them.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Throttle{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.Threshold <= 0 {
+		return nil, errors.New("throttle: threshold must be greater than zero")
+	}
+	maxDelay := defaultMaxDelay
+	if options.MaxDelay != nil && options.MaxDelay.AsDuration() > 0 {
+		maxDelay = options.MaxDelay.AsDuration()
+	}
+	meter := newMeter()
+	throttled := _throttledMetadata + strconv.FormatInt(atomic.AddInt64(&_instances, 1), 10)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			tenant := tenantKey(options, req)
+			if tenant == "" {
+				return next.RoundTrip(req)
+			}
+			if reqOpt, ok := middleware.FromRequestContext(req.Context()); ok {
+				if _, ok := reqOpt.Metadata[throttled]; ok {
+					return next.RoundTrip(req)
+				}
+				reqOpt.Metadata[throttled] = "true"
+			}
+			delay := calcDelay(meter.mark(tenant), options.Threshold, maxDelay)
+			if delay <= 0 {
+				return next.RoundTrip(req)
+			}
+			class, ok := options.TenantClasses[tenant]
+			if !ok {
+				class = defaultClassName
+			}
+			_metricInjectedDelay.WithLabelValues(class).Observe(delay.Seconds())
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-timer.C:
+			}
+			return next.RoundTrip(req)
+		})
+	}, nil
+}
@@ -0,0 +1,101 @@
+package throttle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/throttle/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/gateway/proxy"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestCalcDelay(t *testing.T) {
+	tests := []struct {
+		rate float64
+		want time.Duration
+	}{
+		{rate: 5, want: 0},
+		{rate: 10, want: 0},
+		{rate: 15, want: 500 * time.Millisecond},
+		{rate: 20, want: time.Second},
+		{rate: 100, want: time.Second},
+	}
+	for _, test := range tests {
+		if got := calcDelay(test.rate, 10, time.Second); got != test.want {
+			t.Errorf("rate %v: want %v but got %v", test.rate, test.want, got)
+		}
+	}
+}
+
+func TestMeter(t *testing.T) {
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := newMeter()
+	m.now = func() time.Time { return now }
+	var rate float64
+	for i := 0; i < 10; i++ {
+		rate = m.mark("a")
+	}
+	if rate != 10 {
+		t.Fatalf("want rate 10 but got %v", rate)
+	}
+	if rate = m.mark("b"); rate != 1 {
+		t.Fatalf("the tenants should be measured separately, got %v", rate)
+	}
+	// half of the previous window is weighted
+	now = now.Add(1500 * time.Millisecond)
+	if rate = m.mark("a"); rate != 6 {
+		t.Fatalf("want rate 6 but got %v", rate)
+	}
+	now = now.Add(5 * time.Second)
+	if rate = m.mark("a"); rate != 1 {
+		t.Fatalf("want rate 1 after idle but got %v", rate)
+	}
+}
+
+func TestThrottleRetry(t *testing.T) {
+	var attempts int
+	p, err := proxy.New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options, err := anypb.New(&v1.Throttle{
+		Threshold: 0.5,
+		MaxDelay:  durationpb.New(50 * time.Millisecond),
+		Tenant:    &v1.Throttle_ByHeader{ByHeader: "X-Tenant"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol:    config.Protocol_HTTP,
+		Path:        "/retry",
+		Middlewares: []*config.Middleware{{Name: "throttle", Options: options}},
+		Retry: &config.Retry{
+			Attempts:   3,
+			Conditions: []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}},
+		},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	// the attempts of a request are delayed once
+	req := httptest.NewRequest("GET", "/retry", nil)
+	req.Header.Set("X-Tenant", "noisy")
+	start := time.Now()
+	p.ServeHTTP(httptest.NewRecorder(), req)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed >= 100*time.Millisecond {
+		t.Fatalf("want the request delayed once but took %v", elapsed)
+	}
+	if attempts != 3 {
+		t.Fatalf("want 3 attempts but got %d", attempts)
+	}
+}
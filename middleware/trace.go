@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceIDMetadata is the request metadata key of the trace id of the sampled span started by the tracing middleware.
+const TraceIDMetadata = "tracing.trace_id"
+
+// TraceID returns the trace id of the sampled request, it's the one of the span started by the tracing middleware,
+// or the span of the request, or empty if the request is not traced.
+func TraceID(req *http.Request) string {
+	if reqOpt, ok := FromRequestContext(req.Context()); ok {
+		if traceID := reqOpt.Metadata[TraceIDMetadata]; traceID != "" {
+			return traceID
+		}
+	}
+	if sc := SpanContext(req); sc.IsValid() && sc.IsSampled() {
+		return sc.TraceID().String()
+	}
+	return ""
+}
+
+// SpanContext returns the span context of the request, it's the active span of the request context,
+// or the remote span propagated by the request headers, it's invalid if the tracing is disabled.
+func SpanContext(req *http.Request) trace.SpanContext {
+	ctx := req.Context()
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		return sc
+	}
+	return trace.SpanContextFromContext(otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(req.Header)))
+}
+
+// TraceFields returns the trace_id and span_id of the request as the log key values,
+// or nothing if the request is not traced.
+func TraceFields(req *http.Request) []interface{} {
+	sc := SpanContext(req)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []interface{}{"trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String()}
+}
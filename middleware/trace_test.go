@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceFields(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	// the tracing is disabled
+	if fields := TraceFields(req); fields != nil {
+		t.Fatalf("want no fields but got %v", fields)
+	}
+
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(prev)
+	fields := TraceFields(req)
+	if len(fields) != 4 || fields[1] != "4bf92f3577b34da6a3ce929d0e0e4736" || fields[3] != "00f067aa0ba902b7" {
+		t.Fatalf("want the propagated span but got %v", fields)
+	}
+
+	// the active span takes precedence over the propagated one
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req = req.WithContext(trace.ContextWithSpanContext(req.Context(), sc))
+	fields = TraceFields(req)
+	if len(fields) != 4 || fields[1] != sc.TraceID().String() || fields[3] != sc.SpanID().String() {
+		t.Fatalf("want the active span but got %v", fields)
+	}
+}
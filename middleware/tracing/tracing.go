@@ -59,12 +59,18 @@ func Middleware(c *config.Middleware) (middleware.Middleware, error) {
 	tracer := otel.Tracer(defaultTracerName)
 	return func(next http.RoundTripper) http.RoundTripper {
 		return middleware.RoundTripperFunc(func(req *http.Request) (reply *http.Response, err error) {
-			_, span := tracer.Start(
+			ctx, span := tracer.Start(
 				req.Context(),
 				fmt.Sprintf("%s %s", req.Method, req.URL.Path),
 				trace.WithSpanKind(trace.SpanKindClient),
 			)
 
+			if sc := span.SpanContext(); sc.IsSampled() {
+				if reqOpt, ok := middleware.FromRequestContext(req.Context()); ok {
+					reqOpt.Metadata[middleware.TraceIDMetadata] = sc.TraceID().String()
+				}
+			}
+
 			// attributes for each request
 			span.SetAttributes(
 				semconv.HTTPMethodKey.String(req.Method),
@@ -84,7 +90,8 @@ func Middleware(c *config.Middleware) (middleware.Middleware, error) {
 				}
 				span.End()
 			}()
-			return next.RoundTrip(req)
+			// the span is active for the inner middlewares, e.g. the access logs
+			return next.RoundTrip(req.WithContext(ctx))
 		})
 	}, nil
 }
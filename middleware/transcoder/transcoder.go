@@ -4,15 +4,19 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
 	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/transport/http/status"
 	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -34,6 +38,94 @@ func newResponse(statusCode int, header http.Header, data []byte) (*http.Respons
 	}, nil
 }
 
+// errorDetail is the detail which can't be resolved to the JSON.
+type errorDetail struct {
+	Type  string `json:"@type"`
+	Value []byte `json:"value"`
+}
+
+// errorBody is the JSON error envelope of google.rpc.Status.
+type errorBody struct {
+	Code    int32             `json:"code"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details"`
+}
+
+// httpStatusToCode returns the code of the responses without grpc-status, e.g. from an intermediate proxy,
+// see https://github.com/grpc/grpc/blob/master/doc/http-grpc-status-mapping.md
+func httpStatusToCode(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return codes.Internal
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.Unimplemented
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
+
+// decodeMessage percent-decodes the grpc-message, the invalid one is kept as is.
+func decodeMessage(v string) string {
+	if msg, err := url.PathUnescape(v); err == nil {
+		return msg
+	}
+	return v
+}
+
+func parseStatus(grpcStatus string, statusCode int, header http.Header) (*spb.Status, error) {
+	if grpcStatus == "" {
+		code := httpStatusToCode(statusCode)
+		message := decodeMessage(header.Get("grpc-message"))
+		if message == "" {
+			message = "upstream responded " + strconv.Itoa(statusCode) + " without grpc-status"
+		}
+		return &spb.Status{Code: int32(code), Message: message}, nil
+	}
+	code, err := strconv.ParseInt(grpcStatus, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	st := &spb.Status{
+		Code:    int32(code),
+		Message: decodeMessage(header.Get("grpc-message")),
+	}
+	if grpcDetails := header.Get("grpc-status-details-bin"); grpcDetails != "" {
+		details, err := decodeBinHeader(grpcDetails)
+		if err != nil {
+			return nil, err
+		}
+		if err = proto.Unmarshal(details, st); err != nil {
+			return nil, err
+		}
+	}
+	return st, nil
+}
+
+// marshalStatus returns the JSON of the status, the details of the unknown types are kept as the base64 values.
+func marshalStatus(st *spb.Status) ([]byte, error) {
+	body := &errorBody{
+		Code:    st.Code,
+		Message: st.Message,
+		Details: make([]json.RawMessage, 0, len(st.Details)),
+	}
+	for _, detail := range st.Details {
+		b, err := protojson.Marshal(detail)
+		if err != nil {
+			if b, err = json.Marshal(&errorDetail{Type: detail.TypeUrl, Value: detail.Value}); err != nil {
+				return nil, err
+			}
+		}
+		body.Details = append(body.Details, b)
+	}
+	return json.Marshal(body)
+}
+
 func init() {
 	middleware.Register("transcoder", Middleware)
 }
@@ -67,6 +159,7 @@ func Middleware(c *config.Middleware) (middleware.Middleware, error) {
 				return nil, err
 			}
 			data, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
 			if err != nil {
 				return nil, err
 			}
@@ -78,28 +171,17 @@ func Middleware(c *config.Middleware) (middleware.Middleware, error) {
 			resp.Trailer = nil
 			resp.Header.Set("Content-Type", contentType)
 			if grpcStatus := resp.Header.Get("grpc-status"); grpcStatus != "0" {
-				code, err := strconv.ParseInt(grpcStatus, 10, 64)
+				st, err := parseStatus(grpcStatus, resp.StatusCode, resp.Header)
 				if err != nil {
 					return nil, err
 				}
-				st := &spb.Status{
-					Code:    int32(code),
-					Message: resp.Header.Get("grpc-message"),
-				}
-				if grpcDetails := resp.Header.Get("grpc-status-details-bin"); grpcDetails != "" {
-					details, err := decodeBinHeader(grpcDetails)
-					if err != nil {
-						return nil, err
-					}
-					if err = proto.Unmarshal(details, st); err != nil {
-						return nil, err
-					}
-				}
-				data, err := protojson.Marshal(st)
+				data, err := marshalStatus(st)
 				if err != nil {
 					return nil, err
 				}
-				return newResponse(200, resp.Header, data)
+				resp.Header.Set("Content-Type", "application/json")
+				resp.Header.Del("Content-Length")
+				return newResponse(status.FromGRPCCode(codes.Code(st.Code)), resp.Header, data)
 			}
 			resp.Body = ioutil.NopCloser(bytes.NewReader(data[5:]))
 			resp.ContentLength = int64(len(data) - 5)
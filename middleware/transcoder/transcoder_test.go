@@ -0,0 +1,123 @@
+package transcoder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestErrorResponse(t *testing.T) {
+	detail, err := anypb.New(durationpb.New(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	details, err := proto.Marshal(&spb.Status{
+		Code:    5,
+		Message: "not found",
+		Details: []*anypb.Any{detail, {TypeUrl: "type.googleapis.com/unknown.Detail", Value: []byte("x")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("Content-Type", "application/grpc")
+		header.Set("Grpc-Status", "5")
+		header.Set("Grpc-Message", "not%20found")
+		header.Set("Grpc-Status-Details-Bin", base64.RawStdEncoding.EncodeToString(details))
+		return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody}, nil
+	})
+	req := httptest.NewRequest("POST", "/helloworld.Greeter/SayHello", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	opts := middleware.NewRequestOptions(&config.Endpoint{Protocol: config.Protocol_GRPC})
+	req = req.WithContext(middleware.NewRequestContext(req.Context(), opts))
+	resp, err := m(next).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusNotFound || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("want the json error of 404 but got %d %v", resp.StatusCode, resp.Header)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Code    int32                    `json:"code"`
+		Message string                   `json:"message"`
+		Details []map[string]interface{} `json:"details"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Code != 5 || body.Message != "not found" || len(body.Details) != 2 {
+		t.Fatalf("unexpected error body: %s", b)
+	}
+	if body.Details[0]["@type"] != "type.googleapis.com/google.protobuf.Duration" ||
+		body.Details[1]["@type"] != "type.googleapis.com/unknown.Detail" || body.Details[1]["value"] != "eA==" {
+		t.Fatalf("unexpected error details: %s", b)
+	}
+}
+
+func TestMissingStatus(t *testing.T) {
+	m, err := Middleware(&config.Middleware{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := false
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		// e.g. from an intermediate proxy
+		header := http.Header{}
+		header.Set("Content-Type", "text/plain")
+		body := &closeRecorder{Reader: strings.NewReader("overloaded"), closed: &closed}
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: header, Body: body}, nil
+	})
+	req := httptest.NewRequest("POST", "/helloworld.Greeter/SayHello", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	opts := middleware.NewRequestOptions(&config.Endpoint{Protocol: config.Protocol_GRPC})
+	req = req.WithContext(middleware.NewRequestContext(req.Context(), opts))
+	resp, err := m(next).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !closed {
+		t.Fatal("want the upstream body closed")
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("want the json error of 503 but got %d %v", resp.StatusCode, resp.Header)
+	}
+	var body struct {
+		Code int32 `json:"code"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Code != 14 {
+		t.Fatalf("want the unavailable code but got %d", body.Code)
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed *bool
+}
+
+func (r *closeRecorder) Close() error {
+	*r.closed = true
+	return nil
+}
@@ -0,0 +1,194 @@
+package transform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/transform/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	defaultTimeout      = time.Second
+	defaultMaxBodyBytes = 1 << 20
+)
+
+var (
+	_metricTransformTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_transform_total",
+		Help:      "The total number of response transforms by result",
+	}, []string{"method", "path", "result"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricTransformTotal)
+	middleware.Register("transform", Middleware)
+}
+
+type transformer struct {
+	client         *http.Client
+	url            string
+	timeout        time.Duration
+	contentTypes   []string
+	maxBodyBytes   int64
+	forwardHeaders []string
+	copyHeaders    []string
+	useStatus      bool
+}
+
+func (t *transformer) transformable(resp *http.Response) bool {
+	if ce := resp.Header.Get("Content-Encoding"); ce != "" && ce != "identity" {
+		return false
+	}
+	if resp.ContentLength > t.maxBodyBytes {
+		return false
+	}
+	if len(t.contentTypes) == 0 {
+		return true
+	}
+	contentType := resp.Header.Get("Content-Type")
+	for _, prefix := range t.contentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// transform posts the body to the transform service with the metadata headers.
+func (t *transformer) transform(ctx context.Context, req *http.Request, resp *http.Response, body []byte) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	treq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	treq.Header.Set("Content-Type", resp.Header.Get("Content-Type"))
+	treq.Header.Set("X-Upstream-Status", strconv.Itoa(resp.StatusCode))
+	treq.Header.Set("X-Request-Method", req.Method)
+	treq.Header.Set("X-Request-Path", req.URL.Path)
+	for _, name := range t.forwardHeaders {
+		if v := resp.Header.Values(name); len(v) > 0 {
+			treq.Header[http.CanonicalHeaderKey(name)] = v
+		}
+	}
+	tresp, err := t.client.Do(treq)
+	if err != nil {
+		return nil, err
+	}
+	defer tresp.Body.Close()
+	transformed, err := io.ReadAll(io.LimitReader(tresp.Body, t.maxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(transformed)) > t.maxBodyBytes {
+		return nil, errors.New("transform: transformed body is too large")
+	}
+	if tresp.StatusCode >= http.StatusInternalServerError || (!t.useStatus && tresp.StatusCode >= http.StatusMultipleChoices) {
+		return nil, fmt.Errorf("transform: unexpected status code: %d", tresp.StatusCode)
+	}
+	if t.useStatus {
+		resp.StatusCode = tresp.StatusCode
+		resp.Status = strconv.Itoa(tresp.StatusCode) + " " + http.StatusText(tresp.StatusCode)
+	}
+	if contentType := tresp.Header.Get("Content-Type"); contentType != "" {
+		resp.Header.Set("Content-Type", contentType)
+	}
+	for _, name := range t.copyHeaders {
+		if v := tresp.Header.Values(name); len(v) > 0 {
+			resp.Header[http.CanonicalHeaderKey(name)] = v
+		}
+	}
+	resp.ContentLength = int64(len(transformed))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(transformed)))
+	resp.Body = io.NopCloser(bytes.NewReader(transformed))
+	return resp, nil
+}
+
+func newBadGatewayResponse() *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(http.StatusBadGateway),
+		StatusCode: http.StatusBadGateway,
+		Header:     http.Header{},
+		Body:       io.NopCloser(&bytes.Buffer{}),
+	}
+}
+
+// Middleware transforms the upstream response bodies by an external service.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Transform{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	if options.Url == "" {
+		return nil, errors.New("transform: url must be specified")
+	}
+	t := &transformer{
+		client:         &http.Client{},
+		url:            options.Url,
+		timeout:        defaultTimeout,
+		contentTypes:   options.ContentTypes,
+		maxBodyBytes:   defaultMaxBodyBytes,
+		forwardHeaders: options.ForwardHeaders,
+		copyHeaders:    options.CopyHeaders,
+		useStatus:      options.UseStatus,
+	}
+	if options.Timeout != nil && options.Timeout.AsDuration() > 0 {
+		t.timeout = options.Timeout.AsDuration()
+	}
+	if options.MaxBodyBytes > 0 {
+		t.maxBodyBytes = options.MaxBodyBytes
+	}
+	failClosed := options.FailurePolicy == v1.FailurePolicy_FAIL_CLOSED
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			if err != nil || !t.transformable(resp) {
+				return resp, err
+			}
+			body, err := io.ReadAll(io.LimitReader(resp.Body, t.maxBodyBytes+1))
+			if err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+			if int64(len(body)) > t.maxBodyBytes {
+				// too large to transform, stitch the read part back to the body
+				resp.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+				_metricTransformTotal.WithLabelValues(req.Method, middleware.PathLabel(req), "skipped").Inc()
+				return resp, nil
+			}
+			resp.Body.Close()
+			transformed, err := t.transform(req.Context(), req, resp, body)
+			if err != nil {
+				log.Errorf("Failed to transform response body: %s: %+v", middleware.SanitizedPath(req), err)
+				_metricTransformTotal.WithLabelValues(req.Method, middleware.PathLabel(req), "failed").Inc()
+				if failClosed {
+					return newBadGatewayResponse(), nil
+				}
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				return resp, nil
+			}
+			_metricTransformTotal.WithLabelValues(req.Method, middleware.PathLabel(req), "transformed").Inc()
+			return transformed, nil
+		})
+	}, nil
+}
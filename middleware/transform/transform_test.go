@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/transform/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestTransform(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Upstream-Status") != "200" || r.Header.Get("X-Request-Path") != "/users" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("X-Fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte(strings.ToUpper(string(body))))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		contentType string
+		fail        bool
+		policy      v1.FailurePolicy
+		code        int
+		body        string
+	}{
+		{contentType: "application/json", code: http.StatusOK, body: `{"NAME":"FOO"}`},
+		{contentType: "text/html", code: http.StatusOK, body: `{"name":"foo"}`},
+		{contentType: "application/json", fail: true, code: http.StatusOK, body: `{"name":"foo"}`},
+		{contentType: "application/json", fail: true, policy: v1.FailurePolicy_FAIL_CLOSED, code: http.StatusBadGateway},
+	}
+	for _, test := range tests {
+		v, err := anypb.New(&v1.Transform{
+			Url:            srv.URL,
+			ContentTypes:   []string{"application/json"},
+			ForwardHeaders: []string{"X-Fail"},
+			FailurePolicy:  test.policy,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := Middleware(&config.Middleware{Options: v})
+		if err != nil {
+			t.Fatal(err)
+		}
+		next := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{"Content-Type": []string{test.contentType}}
+			if test.fail {
+				header.Set("X-Fail", "1")
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     header,
+				Body:       io.NopCloser(strings.NewReader(`{"name":"foo"}`)),
+			}, nil
+		}))
+		resp, err := next.RoundTrip(httptest.NewRequest("GET", "/users", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != test.code || string(body) != test.body {
+			t.Errorf("%+v: want %d %q but got %d %q", test, test.code, test.body, resp.StatusCode, body)
+		}
+	}
+}
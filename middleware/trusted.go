@@ -0,0 +1,38 @@
+package middleware
+
+import "net"
+
+// ParseCIDRs parses the trusted CIDRs of the options.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// Trusted reports whether the remote address is in the trusted networks,
+// emptyTrusted is returned if no network is configured, since the options differ in the default.
+func Trusted(nets []*net.IPNet, remoteAddr string, emptyTrusted bool) bool {
+	if len(nets) == 0 {
+		return emptyTrusted
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range nets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import "testing"
+
+func TestTrusted(t *testing.T) {
+	nets, err := ParseCIDRs([]string{"10.0.0.0/8", "::1/128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		remoteAddr string
+		want       bool
+	}{
+		{remoteAddr: "10.1.2.3:1234", want: true},
+		{remoteAddr: "[::1]:1234", want: true},
+		{remoteAddr: "192.168.0.1:1234"},
+		{remoteAddr: "10.1.2.3"},
+	}
+	for _, test := range tests {
+		if got := Trusted(nets, test.remoteAddr, false); got != test.want {
+			t.Errorf("%s: want %v but got %v", test.remoteAddr, test.want, got)
+		}
+	}
+	if !Trusted(nil, "192.168.0.1:1234", true) || Trusted(nil, "192.168.0.1:1234", false) {
+		t.Error("want the empty networks trusted as configured")
+	}
+	if _, err := ParseCIDRs([]string{"10.0.0.0"}); err == nil {
+		t.Error("want error on invalid cidr")
+	}
+}
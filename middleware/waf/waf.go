@@ -0,0 +1,174 @@
+package waf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/waf/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const defaultStatusCode = http.StatusForbidden
+
+var (
+	_metricRuleMatchedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_waf_matched_total",
+		Help:      "The total number of requests matched by waf rules",
+	}, []string{"rule", "action"})
+)
+
+func init() {
+	prometheus.MustRegister(_metricRuleMatchedTotal)
+	middleware.Register("waf", Middleware)
+}
+
+type rule struct {
+	name       string
+	action     v1.Action
+	statusCode int
+	path       *regexp.Regexp
+	headers    map[string]*regexp.Regexp
+	queries    map[string]*regexp.Regexp
+	userAgents []*regexp.Regexp
+}
+
+func newRule(index int, in *v1.Rule, statusCode int) (*rule, error) {
+	r := &rule{
+		name:       in.Name,
+		action:     in.Action,
+		statusCode: statusCode,
+		headers:    make(map[string]*regexp.Regexp, len(in.Headers)),
+		queries:    make(map[string]*regexp.Regexp, len(in.Queries)),
+		userAgents: make([]*regexp.Regexp, 0, len(in.UserAgents)),
+	}
+	if r.name == "" {
+		r.name = strconv.Itoa(index)
+	}
+	if in.StatusCode != 0 {
+		r.statusCode = int(in.StatusCode)
+	}
+	var err error
+	if in.Path != "" {
+		if r.path, err = regexp.Compile(in.Path); err != nil {
+			return nil, fmt.Errorf("invalid waf rule %s path: %w", r.name, err)
+		}
+	}
+	for name, expr := range in.Headers {
+		if r.headers[name], err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("invalid waf rule %s header %s: %w", r.name, name, err)
+		}
+	}
+	for name, expr := range in.Queries {
+		if r.queries[name], err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("invalid waf rule %s query %s: %w", r.name, name, err)
+		}
+	}
+	for _, expr := range in.UserAgents {
+		ua, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid waf rule %s user agent: %w", r.name, err)
+		}
+		r.userAgents = append(r.userAgents, ua)
+	}
+	return r, nil
+}
+
+func (r *rule) match(req *http.Request) bool {
+	if r.path != nil && !r.path.MatchString(req.URL.Path) {
+		return false
+	}
+	for name, expr := range r.headers {
+		values, ok := req.Header[http.CanonicalHeaderKey(name)]
+		if !ok || !matchAny(expr, values) {
+			return false
+		}
+	}
+	if len(r.queries) > 0 {
+		query := req.URL.Query()
+		for name, expr := range r.queries {
+			values, ok := query[name]
+			if !ok || !matchAny(expr, values) {
+				return false
+			}
+		}
+	}
+	if len(r.userAgents) > 0 {
+		ua := req.Header.Get("User-Agent")
+		matched := false
+		for _, expr := range r.userAgents {
+			if expr.MatchString(ua) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func matchAny(expr *regexp.Regexp, values []string) bool {
+	for _, v := range values {
+		if expr.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func newDeniedResponse(statusCode int) *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(statusCode),
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(&bytes.Buffer{}),
+	}
+}
+
+// Middleware blocks requests matched the deny rules before calling upstream.
+func Middleware(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Waf{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	statusCode := defaultStatusCode
+	if options.StatusCode != 0 {
+		statusCode = int(options.StatusCode)
+	}
+	rules := make([]*rule, 0, len(options.Rules))
+	for i, in := range options.Rules {
+		r, err := newRule(i, in, statusCode)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			for _, r := range rules {
+				if !r.match(req) {
+					continue
+				}
+				_metricRuleMatchedTotal.WithLabelValues(r.name, r.action.String()).Inc()
+				if r.action == v1.Action_DENY {
+					return newDeniedResponse(r.statusCode), nil
+				}
+				break
+			}
+			return next.RoundTrip(req)
+		})
+	}, nil
+}
@@ -0,0 +1,69 @@
+package waf
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	v1 "github.com/go-kratos/gateway/api/gateway/middleware/waf/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func TestWaf(t *testing.T) {
+	options, err := anypb.New(&v1.Waf{
+		Rules: []*v1.Rule{
+			{Name: "internal", Action: v1.Action_ALLOW, Path: "^/internal/", Headers: map[string]string{"X-Token": "^secret$"}},
+			{Name: "internal-deny", Path: "^/internal/", StatusCode: 444},
+			{Name: "sqli", Queries: map[string]string{"id": "(?i)union\\s+select"}},
+			{Name: "bad-ua", UserAgents: []string{"(?i)sqlmap", "(?i)nikto"}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := Middleware(&config.Middleware{Options: options})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	tests := []struct {
+		path       string
+		header     http.Header
+		statusCode int
+	}{
+		{path: "/api/users", statusCode: 200},
+		{path: "/internal/metrics", header: http.Header{"X-Token": []string{"secret"}}, statusCode: 200},
+		{path: "/internal/metrics", statusCode: 444},
+		{path: "/api/users?id=1%20UNION%20SELECT%20password", statusCode: 403},
+		{path: "/api/users", header: http.Header{"User-Agent": []string{"sqlmap/1.0"}}, statusCode: 403},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", test.path, nil)
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+		resp, err := m(next).RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != test.statusCode {
+			t.Errorf("%s: want %d but got %d", test.path, test.statusCode, resp.StatusCode)
+		}
+	}
+}
+
+func TestWafInvalidRule(t *testing.T) {
+	options, err := anypb.New(&v1.Waf{
+		Rules: []*v1.Rule{{Path: "(["}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Middleware(&config.Middleware{Options: options}); err == nil {
+		t.Fatal("want error but got nil")
+	}
+}
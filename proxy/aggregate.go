@@ -0,0 +1,195 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+type subRequest struct {
+	endpoint *config.Endpoint
+	name     string
+	method   string
+	path     string
+	tripper  http.RoundTripper
+}
+
+// aggregateTripper fans out the request to the sub requests concurrently,
+// and responds a JSON object of their responses keyed by the names.
+type aggregateTripper struct {
+	requests      []*subRequest
+	timeout       time.Duration
+	includeErrors bool
+}
+
+// subResult is the response of a sub request, or the error if it failed.
+type subResult struct {
+	body   json.RawMessage
+	status int
+	err    error
+}
+
+type subError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func newAggregateTripper(e *config.Endpoint, factory client.Factory) (http.RoundTripper, error) {
+	c := e.Aggregate
+	if len(c.Requests) == 0 {
+		return nil, errors.New("aggregate requests must be specified")
+	}
+	t := &aggregateTripper{
+		requests:      make([]*subRequest, 0, len(c.Requests)),
+		includeErrors: c.PartialFailure == config.PartialFailure_INCLUDE_ERRORS,
+	}
+	if c.Timeout != nil {
+		t.timeout = c.Timeout.AsDuration()
+	}
+	names := make(map[string]struct{}, len(c.Requests))
+	for _, in := range c.Requests {
+		if in.Name == "" {
+			return nil, errors.New("aggregate request name must be specified")
+		}
+		if _, ok := names[in.Name]; ok {
+			return nil, fmt.Errorf("duplicate aggregate request name: %s", in.Name)
+		}
+		names[in.Name] = struct{}{}
+		endpoint := &config.Endpoint{
+			Path:     in.Path,
+			Method:   in.Method,
+			Protocol: config.Protocol_HTTP,
+			Backends: in.Backends,
+			Metadata: e.Metadata,
+		}
+		tripper, err := factory(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		t.requests = append(t.requests, &subRequest{
+			endpoint: endpoint,
+			name:     in.Name,
+			method:   in.Method,
+			path:     in.Path,
+			tripper:  tripper,
+		})
+	}
+	return t, nil
+}
+
+func (t *aggregateTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+	results := make([]*subResult, len(t.requests))
+	// each of the sub requests has its own request options, since they're not safe for the concurrent use.
+	reqOpts := make([]*middleware.RequestOptions, len(t.requests))
+	var wg sync.WaitGroup
+	for i, sub := range t.requests {
+		reqOpts[i] = middleware.NewRequestOptions(sub.endpoint)
+		wg.Add(1)
+		go func(i int, sub *subRequest) {
+			defer wg.Done()
+			results[i] = t.do(middleware.NewRequestContext(ctx, reqOpts[i]), req, sub)
+		}(i, sub)
+	}
+	wg.Wait()
+	if reqOpt, ok := middleware.FromRequestContext(req.Context()); ok {
+		for _, o := range reqOpts {
+			reqOpt.Backends = append(reqOpt.Backends, o.Backends...)
+			reqOpt.UpstreamStatusCode = append(reqOpt.UpstreamStatusCode, o.UpstreamStatusCode...)
+			reqOpt.UpstreamResponseTime = append(reqOpt.UpstreamResponseTime, o.UpstreamResponseTime...)
+		}
+	}
+
+	out := make(map[string]json.RawMessage, len(results))
+	for i, r := range results {
+		if r.err == nil {
+			out[t.requests[i].name] = r.body
+			continue
+		}
+		if !t.includeErrors {
+			return newAggregateResponse(http.StatusBadGateway, map[string]interface{}{
+				"error": &subError{Status: r.status, Message: t.requests[i].name + ": " + r.err.Error()},
+			})
+		}
+		b, _ := json.Marshal(map[string]interface{}{
+			"error": &subError{Status: r.status, Message: r.err.Error()},
+		})
+		out[t.requests[i].name] = b
+	}
+	return newAggregateResponse(http.StatusOK, out)
+}
+
+func (t *aggregateTripper) do(ctx context.Context, in *http.Request, sub *subRequest) *subResult {
+	req := in.Clone(ctx)
+	if sub.method != "" {
+		req.Method = sub.method
+	}
+	if sub.path != "" {
+		req.URL.Path = sub.path
+		req.URL.RawPath = ""
+	}
+	req.Body, req.ContentLength = http.NoBody, 0
+	if in.GetBody != nil && req.Method == in.Method {
+		body, err := in.GetBody()
+		if err != nil {
+			return &subResult{status: http.StatusBadGateway, err: err}
+		}
+		req.Body, req.ContentLength = body, in.ContentLength
+	}
+	req.Header.Del("Content-Length")
+	// the sub responses are decoded as JSON
+	req.Header.Del("Accept-Encoding")
+	resp, err := sub.tripper.RoundTrip(req)
+	if err != nil {
+		status := http.StatusBadGateway
+		if errors.Is(err, context.DeadlineExceeded) {
+			status = http.StatusGatewayTimeout
+		}
+		return &subResult{status: status, err: err}
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return &subResult{status: http.StatusBadGateway, err: err}
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &subResult{status: resp.StatusCode, err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
+	}
+	if !json.Valid(b) {
+		return &subResult{status: http.StatusBadGateway, err: errors.New("invalid JSON response")}
+	}
+	return &subResult{body: b, status: resp.StatusCode}
+}
+
+func newAggregateResponse(statusCode int, v interface{}) (*http.Response, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Content-Length", strconv.Itoa(len(b)))
+	return &http.Response{
+		Status:        http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Header:        header,
+		ContentLength: int64(len(b)),
+		Body:          io.NopCloser(bytes.NewReader(b)),
+	}, nil
+}
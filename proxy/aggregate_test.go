@@ -0,0 +1,93 @@
+package proxy
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestAggregateTripper(t *testing.T) {
+	factory := func(e *config.Endpoint) (http.RoundTripper, error) {
+		target := e.Backends[0].Target
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if target == "down" {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}, nil
+			}
+			body := `{"backend":"` + target + `","path":"` + req.URL.Path + `","query":"` + req.URL.RawQuery + `"}`
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}), nil
+	}
+	newTripper := func(partialFailure config.PartialFailure, backends ...string) http.RoundTripper {
+		c := &config.Aggregate{PartialFailure: partialFailure}
+		for _, b := range backends {
+			c.Requests = append(c.Requests, &config.SubRequest{
+				Name:     b,
+				Path:     "/" + b,
+				Backends: []*config.Backend{{Target: b}},
+			})
+		}
+		tripper, err := newAggregateTripper(&config.Endpoint{Aggregate: c}, factory)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tripper
+	}
+	do := func(tripper http.RoundTripper) (int, map[string]map[string]interface{}) {
+		resp, err := tripper.RoundTrip(httptest.NewRequest("GET", "/home?id=1", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := map[string]map[string]interface{}{}
+		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, out
+	}
+
+	code, out := do(newTripper(config.PartialFailure_FAIL_ALL, "users", "orders"))
+	if code != http.StatusOK || out["users"]["path"] != "/users" || out["orders"]["query"] != "id=1" {
+		t.Fatalf("unexpected aggregated response: %d %v", code, out)
+	}
+	code, out = do(newTripper(config.PartialFailure_FAIL_ALL, "users", "down"))
+	if code != http.StatusBadGateway {
+		t.Fatalf("want 502 for the partial failure, got: %d %v", code, out)
+	}
+	code, out = do(newTripper(config.PartialFailure_INCLUDE_ERRORS, "users", "down"))
+	if code != http.StatusOK || out["users"]["backend"] != "users" || out["down"]["error"] == nil {
+		t.Fatalf("want the errors included, got: %d %v", code, out)
+	}
+	if _, err := newAggregateTripper(&config.Endpoint{Aggregate: &config.Aggregate{}}, factory); err == nil {
+		t.Fatal("want error for no sub requests")
+	}
+}
+
+func TestAggregateExclusive(t *testing.T) {
+	p, err := New(func(e *config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggregate := &config.Aggregate{Requests: []*config.SubRequest{{Name: "users", Backends: []*config.Backend{{Target: "users"}}}}}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{Path: "/home", Aggregate: aggregate}}}); err != nil {
+		t.Fatal(err)
+	}
+	pools := []*config.Failover_Pool{{Name: "dr", Backends: []*config.Backend{{Target: "dr"}}}}
+	for _, e := range []*config.Endpoint{
+		{Path: "/home", Aggregate: aggregate, Backends: []*config.Backend{{Target: "home"}}},
+		{Path: "/home", Aggregate: aggregate, Split: &config.Split{}},
+		{Path: "/home", Aggregate: aggregate, Failover: &config.Failover{Pools: pools}},
+	} {
+		if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{e}}); err == nil {
+			t.Fatalf("want error for the aggregate with the other upstreams %v", e)
+		}
+	}
+}
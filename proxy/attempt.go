@@ -0,0 +1,31 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// attemptOutcome is the result of an attempt, they're logged together if the request failed after the retries.
+type attemptOutcome struct {
+	backend  string
+	status   int
+	err      error
+	duration time.Duration
+}
+
+func newAttemptOutcome(resp *http.Response, err error, duration time.Duration, backend string) attemptOutcome {
+	o := attemptOutcome{backend: backend, err: err, duration: duration}
+	if err == nil && resp != nil {
+		o.status = resp.StatusCode
+	}
+	return o
+}
+
+func (o attemptOutcome) String() string {
+	result := fmt.Sprintf("status=%d", o.status)
+	if o.err != nil {
+		result = fmt.Sprintf("error=%q", o.err.Error())
+	}
+	return fmt.Sprintf("{backend=%s %s duration=%s}", o.backend, result, o.duration)
+}
@@ -0,0 +1,20 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAttemptOutcome(t *testing.T) {
+	outcomes := []attemptOutcome{
+		newAttemptOutcome(nil, errors.New("connection refused"), time.Second, "10.0.0.1:80"),
+		newAttemptOutcome(&http.Response{StatusCode: http.StatusServiceUnavailable}, nil, 10*time.Millisecond, "10.0.0.2:80"),
+	}
+	want := `[{backend=10.0.0.1:80 error="connection refused" duration=1s} {backend=10.0.0.2:80 status=503 duration=10ms}]`
+	if got := fmt.Sprintf("%v", outcomes); got != want {
+		t.Fatalf("want %s but got %s", want, got)
+	}
+}
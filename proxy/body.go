@@ -0,0 +1,199 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// errBodyReadTimeout is returned if the request body is not read before the request timeout.
+var errBodyReadTimeout = errors.New("request body read timeout")
+
+// bufferedBody is the request body buffered for the attempts,
+// it's kept in memory unless the size exceeds the threshold, then it's spilled to a temp file.
+type bufferedBody struct {
+	data []byte
+	file *os.File
+	size int64
+	// closed is whether the size is released from the buffered body bytes.
+	closed int32
+}
+
+func newBufferedBody(data []byte, file *os.File, size int64) *bufferedBody {
+	b := &bufferedBody{data: data, file: file, size: size}
+	_metricBufferedBodyBytes.WithLabelValues(b.storage()).Add(float64(size))
+	return b
+}
+
+func (b *bufferedBody) storage() string {
+	if b.file != nil {
+		return "file"
+	}
+	return "memory"
+}
+
+// newReader returns a reader from the beginning of the body for each attempt.
+func (b *bufferedBody) newReader() io.ReadCloser {
+	if b.file != nil {
+		return ioutil.NopCloser(io.NewSectionReader(b.file, 0, b.size))
+	}
+	return ioutil.NopCloser(bytes.NewReader(b.data))
+}
+
+// Close releases the buffered body, and removes the temp file if the body is spilled.
+func (b *bufferedBody) Close() error {
+	if !atomic.CompareAndSwapInt32(&b.closed, 0, 1) {
+		return nil
+	}
+	_metricBufferedBodyBytes.WithLabelValues(b.storage()).Sub(float64(b.size))
+	if b.file == nil {
+		return nil
+	}
+	b.file.Close()
+	return os.Remove(b.file.Name())
+}
+
+// bufferBody reads the whole body, the body larger than the threshold is spilled to a temp file,
+// it's always kept in memory if the threshold is not positive.
+func bufferBody(body io.Reader, threshold int64) (*bufferedBody, error) {
+	if threshold <= 0 {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		return newBufferedBody(data, nil, int64(len(data))), nil
+	}
+	data, err := io.ReadAll(io.LimitReader(body, threshold+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) <= threshold {
+		return newBufferedBody(data, nil, int64(len(data))), nil
+	}
+	file, err := ioutil.TempFile("", "gateway-body-")
+	if err != nil {
+		return nil, err
+	}
+	size, err := io.Copy(file, io.MultiReader(bytes.NewReader(data), body))
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+	return newBufferedBody(nil, file, size), nil
+}
+
+// readBody reads the whole body within the request timeout, so that the slow clients can't hold the handler.
+func readBody(ctx context.Context, body io.Reader, threshold int64) (*bufferedBody, error) {
+	type result struct {
+		body *bufferedBody
+		err  error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		b, err := bufferBody(body, threshold)
+		ch <- result{body: b, err: err}
+	}()
+	select {
+	case r := <-ch:
+		return r.body, r.err
+	case <-ctx.Done():
+		// the temp file is removed once the reading is done
+		go func() {
+			if r := <-ch; r.body != nil {
+				r.body.Close()
+			}
+		}()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, errBodyReadTimeout
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// countedBody reports the size read from the body streamed to the upstream without buffering.
+type countedBody struct {
+	io.ReadCloser
+	onRead func(size int64)
+}
+
+func (b *countedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.onRead(int64(n))
+	}
+	return n, err
+}
+
+// expectsContinue reports whether the client waits for 100 Continue before sending the body.
+func expectsContinue(req *http.Request) bool {
+	return strings.EqualFold(req.Header.Get("Expect"), "100-continue")
+}
+
+// deferredBody buffers the body on the first read instead of ahead of the middlewares,
+// so that the request rejected by the middlewares is responded before 100 Continue is sent.
+type deferredBody struct {
+	once sync.Once
+	load func() (*bufferedBody, error)
+	body *bufferedBody
+	err  error
+	done int32
+}
+
+func (d *deferredBody) get() (*bufferedBody, error) {
+	d.once.Do(func() {
+		d.body, d.err = d.load()
+		atomic.StoreInt32(&d.done, 1)
+	})
+	return d.body, d.err
+}
+
+// loaded returns the buffered body, or nil if it's not read yet.
+func (d *deferredBody) loaded() *bufferedBody {
+	if atomic.LoadInt32(&d.done) == 0 {
+		return nil
+	}
+	return d.body
+}
+
+func (d *deferredBody) newReader() io.ReadCloser {
+	return &deferredReader{body: d}
+}
+
+// Close removes the temp file if the body is read and spilled.
+func (d *deferredBody) Close() error {
+	if b := d.loaded(); b != nil {
+		return b.Close()
+	}
+	return nil
+}
+
+type deferredReader struct {
+	body   *deferredBody
+	reader io.ReadCloser
+}
+
+func (r *deferredReader) Read(p []byte) (int, error) {
+	if r.reader == nil {
+		b, err := r.body.get()
+		if err != nil {
+			return 0, err
+		}
+		r.reader = b.newReader()
+	}
+	return r.reader.Read(p)
+}
+
+func (r *deferredReader) Close() error {
+	if r.reader != nil {
+		return r.reader.Close()
+	}
+	return nil
+}
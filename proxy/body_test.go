@@ -0,0 +1,96 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestBufferBody(t *testing.T) {
+	tests := []struct {
+		body      string
+		threshold int64
+		spilled   bool
+	}{
+		{body: "hello world", threshold: 0},
+		{body: "hello world", threshold: 11},
+		{body: "hello world", threshold: 5, spilled: true},
+	}
+	for _, test := range tests {
+		b, err := bufferBody(strings.NewReader(test.body), test.threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if spilled := b.file != nil; spilled != test.spilled {
+			t.Fatalf("threshold %d: want spilled %v but got %v", test.threshold, test.spilled, spilled)
+		}
+		if b.size != int64(len(test.body)) {
+			t.Fatalf("want size %d but got %d", len(test.body), b.size)
+		}
+		// each reader replays the body from the beginning
+		for i := 0; i < 2; i++ {
+			data, err := io.ReadAll(b.newReader())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != test.body {
+				t.Fatalf("want %q but got %q", test.body, data)
+			}
+		}
+		if err := b.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if b.file != nil {
+			if _, err := os.Stat(b.file.Name()); !os.IsNotExist(err) {
+				t.Fatalf("want the temp file removed, got: %v", err)
+			}
+		}
+	}
+}
+
+func TestBufferedBodyBytes(t *testing.T) {
+	memory := _metricBufferedBodyBytes.WithLabelValues("memory")
+	before := testutil.ToFloat64(memory)
+	var held float64
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			io.Copy(io.Discard, req.Body)
+			held = testutil.ToFloat64(memory) - before
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol: config.Protocol_HTTP,
+		Path:     "/upload",
+		Retry:    &config.Retry{Attempts: 2, RetryNonIdempotent: true},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/upload", strings.NewReader("hello world")))
+	if held != 11 {
+		t.Fatalf("want 11 bytes held while in flight but got %v", held)
+	}
+	if got := testutil.ToFloat64(memory) - before; got != 0 {
+		t.Fatalf("want the bytes released after the request but got %v", got)
+	}
+	// the body is released once though it's closed again
+	b, err := bufferBody(strings.NewReader("hello"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Close()
+	b.Close()
+	if got := testutil.ToFloat64(memory) - before; got != 0 {
+		t.Fatalf("want the bytes released once but got %v", got)
+	}
+}
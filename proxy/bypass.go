@@ -0,0 +1,36 @@
+package proxy
+
+import (
+	"net/http"
+	"regexp"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+// buildBypass builds the middlewares of the endpoint, the requests of the bypass paths are sent to the tripper directly.
+func (p *Proxy) buildBypass(e *config.Endpoint, tripper http.RoundTripper, opts *buildOptions) (http.RoundTripper, error) {
+	next, err := p.buildMiddleware(e, e.Middlewares, tripper, opts)
+	if err != nil || len(e.MiddlewareBypassPaths) == 0 {
+		return next, err
+	}
+	paths := make([]*regexp.Regexp, 0, len(e.MiddlewareBypassPaths))
+	for _, pattern := range e.MiddlewareBypassPaths {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, re)
+	}
+	return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		for _, re := range paths {
+			if re.MatchString(req.URL.Path) {
+				if d, ok := decisionFromContext(req.Context()); ok {
+					d.add("bypass", re.String())
+				}
+				return tripper.RoundTrip(req)
+			}
+		}
+		return next.RoundTrip(req)
+	}), nil
+}
@@ -0,0 +1,70 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestMiddlewareBypass(t *testing.T) {
+	clientFactory := func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			header.Set("X-Middlewares", strings.Join(req.Header.Values("X-Middleware"), ","))
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody}, nil
+		}), nil
+	}
+	middlewareFactory := func(c *config.Middleware) (middleware.Middleware, error) {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Add("X-Middleware", c.Name)
+				return next.RoundTrip(req)
+			})
+		}, nil
+	}
+	p, err := New(clientFactory, middlewareFactory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{
+		Middlewares: []*config.Middleware{{Name: "logging"}},
+		Endpoints: []*config.Endpoint{{
+			Protocol:              config.Protocol_HTTP,
+			Path:                  "/api/*",
+			Middlewares:           []*config.Middleware{{Name: "auth"}},
+			MiddlewareBypassPaths: []string{"^/api/health$", "^/api/public/"},
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/users", want: "logging,auth"},
+		{path: "/api/health", want: "logging"},
+		{path: "/api/healthz", want: "logging,auth"},
+		{path: "/api/public/docs", want: "logging"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("want %s proxied but got %d", tt.path, w.Code)
+		}
+		if got := w.Header().Get("X-Middlewares"); got != tt.want {
+			t.Fatalf("want the middlewares %q of %s but got %q", tt.want, tt.path, got)
+		}
+	}
+
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Path:                  "/api/*",
+		MiddlewareBypassPaths: []string{"("},
+	}}}); err == nil {
+		t.Fatal("want error for the invalid bypass path")
+	}
+}
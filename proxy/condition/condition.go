@@ -1,9 +1,12 @@
 package condition
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -51,13 +54,21 @@ func (c *byStatusCode) Judge(resp *http.Response) bool {
 type byHeader struct {
 	*config.Condition_ByHeader
 	parsed struct {
-		name   string
-		values map[string]struct{}
+		name    string
+		values  map[string]struct{}
+		pattern *regexp.Regexp
 	}
 }
 
 func (c *byHeader) Judge(resp *http.Response) bool {
-	v := resp.Header.Get(c.ByHeader.Name)
+	if c.parsed.pattern != nil {
+		for _, v := range resp.Header.Values(c.parsed.name) {
+			if c.parsed.pattern.MatchString(v) {
+				return true
+			}
+		}
+	}
+	v := resp.Header.Get(c.parsed.name)
 	if v == "" {
 		return false
 	}
@@ -68,6 +79,16 @@ func (c *byHeader) Judge(resp *http.Response) bool {
 func (c *byHeader) Prepare() error {
 	c.parsed.name = c.ByHeader.Name
 	c.parsed.values = map[string]struct{}{}
+	if c.ByHeader.Pattern != "" {
+		pattern, err := regexp.Compile(c.ByHeader.Pattern)
+		if err != nil {
+			return err
+		}
+		c.parsed.pattern = pattern
+		if c.ByHeader.Value == "" {
+			return nil
+		}
+	}
 	if strings.HasPrefix(c.ByHeader.Value, "[") {
 		values, err := parseAsStringList(c.ByHeader.Value)
 		if err != nil {
@@ -82,6 +103,45 @@ func (c *byHeader) Prepare() error {
 	return nil
 }
 
+type byEmptyBody struct {
+	*config.Condition_ByEmptyBody
+}
+
+func (c *byEmptyBody) Prepare() error {
+	return nil
+}
+
+// Judge peeks the first byte of the body if the length is unknown, the body is restored for the reading.
+// The responses of HEAD and of the statuses without body are never empty.
+func (c *byEmptyBody) Judge(resp *http.Response) bool {
+	if !c.ByEmptyBody || !BodyAllowed(resp.StatusCode) {
+		return false
+	}
+	if resp.Request != nil && resp.Request.Method == http.MethodHead {
+		return false
+	}
+	if resp.ContentLength >= 0 || resp.Body == nil {
+		return resp.ContentLength == 0
+	}
+	b := make([]byte, 1)
+	n, err := io.ReadFull(resp.Body, b)
+	if n == 0 && err == io.EOF {
+		return true
+	}
+	resp.Body = &peekedBody{Reader: io.MultiReader(bytes.NewReader(b[:n]), resp.Body), Closer: resp.Body}
+	return false
+}
+
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// BodyAllowed reports whether the response of the status code is expected to have a body.
+func BodyAllowed(statusCode int) bool {
+	return statusCode >= http.StatusOK && statusCode != http.StatusNoContent && statusCode != http.StatusNotModified
+}
+
 func parseAsStringList(in string) ([]string, error) {
 	var out []string
 	if err := json.Unmarshal([]byte(in), &out); err != nil {
@@ -110,6 +170,8 @@ func ParseConditon(in ...*config.Condition) ([]Condition, error) {
 				return nil, err
 			}
 			conditions = append(conditions, cond)
+		case *config.Condition_ByEmptyBody:
+			conditions = append(conditions, &byEmptyBody{Condition_ByEmptyBody: v})
 		default:
 			return nil, fmt.Errorf("unknown condition type: %T", v)
 		}
@@ -1,7 +1,10 @@
 package condition
 
 import (
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
@@ -153,6 +156,38 @@ func TestRetryByHeader(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			cond: &byHeader{
+				Condition_ByHeader: &config.Condition_ByHeader{
+					ByHeader: &config.ConditionHeader{
+						Name:    "X-Should-Retry",
+						Pattern: "^(?i)(true|1)$",
+					},
+				},
+			},
+			resp: &http.Response{
+				Header: http.Header{
+					"X-Should-Retry": []string{"TRUE"},
+				},
+			},
+			result: true,
+		},
+		{
+			cond: &byHeader{
+				Condition_ByHeader: &config.Condition_ByHeader{
+					ByHeader: &config.ConditionHeader{
+						Name:    "X-Should-Retry",
+						Pattern: "^(?i)(true|1)$",
+					},
+				},
+			},
+			resp: &http.Response{
+				Header: http.Header{
+					"X-Should-Retry": []string{"false"},
+				},
+			},
+			result: false,
+		},
 	}
 	for _, testCase := range testCases {
 		if err := testCase.cond.Prepare(); err != nil {
@@ -164,3 +199,36 @@ func TestRetryByHeader(t *testing.T) {
 		}
 	}
 }
+
+func TestRetryByEmptyBody(t *testing.T) {
+	testCases := []struct {
+		resp   *http.Response
+		body   string
+		result bool
+	}{
+		{resp: &http.Response{StatusCode: 200, ContentLength: 0}, result: true},
+		{resp: &http.Response{StatusCode: 200, ContentLength: 5}, result: false},
+		{resp: &http.Response{StatusCode: 204, ContentLength: 0}, result: false},
+		{resp: &http.Response{StatusCode: 304, ContentLength: 0}, result: false},
+		{resp: &http.Response{StatusCode: 204, ContentLength: -1}, result: false},
+		{resp: &http.Response{StatusCode: 101, ContentLength: -1}, result: false},
+		{resp: &http.Response{StatusCode: 200, ContentLength: -1, Body: http.NoBody, Request: httptest.NewRequest("HEAD", "/", nil)}, result: false},
+		{resp: &http.Response{StatusCode: 200, ContentLength: 0, Request: httptest.NewRequest("HEAD", "/", nil)}, result: false},
+		{resp: &http.Response{StatusCode: 200, ContentLength: -1, Body: io.NopCloser(strings.NewReader(""))}, result: true},
+		{resp: &http.Response{StatusCode: 200, ContentLength: -1, Body: io.NopCloser(strings.NewReader("hello"))}, body: "hello", result: false},
+	}
+	cond := &byEmptyBody{Condition_ByEmptyBody: &config.Condition_ByEmptyBody{ByEmptyBody: true}}
+	for _, testCase := range testCases {
+		if result := cond.Judge(testCase.resp); result != testCase.result {
+			t.Errorf("%d %d: expected %v, got %v", testCase.resp.StatusCode, testCase.resp.ContentLength, testCase.result, result)
+		}
+		if testCase.body == "" {
+			continue
+		}
+		// the peeked byte is restored
+		b, err := io.ReadAll(testCase.resp.Body)
+		if err != nil || string(b) != testCase.body {
+			t.Errorf("expected body %q, got %q %v", testCase.body, b, err)
+		}
+	}
+}
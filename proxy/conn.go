@@ -0,0 +1,122 @@
+package proxy
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// _connBucketsSweepInterval is the interval of evicting the idle buckets of client ips.
+const _connBucketsSweepInterval = time.Minute
+
+var _metricConnectionsRejected = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "go",
+	Subsystem: "gateway",
+	Name:      "connections_rejected_total",
+	Help:      "The total number of client connections rejected by the connection limit",
+})
+
+func init() {
+	prometheus.MustRegister(_metricConnectionsRejected)
+}
+
+type connBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// connLimiter is a token bucket of the new connections for each client ip.
+type connLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	buckets   map[string]*connBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newConnLimiter() *connLimiter {
+	return &connLimiter{
+		buckets: make(map[string]*connBucket),
+		now:     time.Now,
+	}
+}
+
+// setLimit resets the limit, it's unlimited if the config is nil or the rate is not positive.
+func (l *connLimiter) setLimit(c *config.ConnectionLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate, l.burst = 0, 0
+	if c != nil && c.Rate > 0 {
+		l.rate = c.Rate
+		l.burst = float64(c.Burst)
+		if l.burst <= 0 {
+			l.burst = math.Max(1, c.Rate)
+		}
+	}
+}
+
+// allow takes a token from the bucket of the client ip.
+func (l *connLimiter) allow(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return true
+	}
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[ip]
+	if !ok {
+		b = &connBucket{tokens: l.burst, last: now}
+		l.buckets[ip] = b
+	}
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed*l.rate)
+	}
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep evicts the buckets which have been refilled, they are the same as the new ones.
+func (l *connLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < _connBucketsSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for ip, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, ip)
+		}
+	}
+}
+
+// ConnState is the http.Server ConnState hook which closes the new connections
+// of the client ips exceeding the connection limit.
+func (p *Proxy) ConnState(conn net.Conn, state http.ConnState) {
+	if state != http.StateNew {
+		return
+	}
+	if p.AllowConn(conn.RemoteAddr()) {
+		return
+	}
+	_metricConnectionsRejected.Inc()
+	conn.Close()
+}
+
+// AllowConn reports whether a new connection from the remote address is allowed.
+func (p *Proxy) AllowConn(addr net.Addr) bool {
+	ip := addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return p.conns.allow(ip)
+}
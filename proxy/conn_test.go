@@ -0,0 +1,59 @@
+package proxy
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+func TestConnLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newConnLimiter()
+	l.now = func() time.Time { return now }
+	for i := 0; i < 10; i++ {
+		if !l.allow("10.0.0.1") {
+			t.Fatal("want unlimited without the config")
+		}
+	}
+
+	l.setLimit(&config.ConnectionLimit{Rate: 1, Burst: 2})
+	for i, want := range []bool{true, true, false} {
+		if got := l.allow("10.0.0.1"); got != want {
+			t.Fatalf("connection %d: want %v but got %v", i, want, got)
+		}
+	}
+	if !l.allow("10.0.0.2") {
+		t.Fatal("want the other client ip allowed")
+	}
+	now = now.Add(time.Second)
+	if !l.allow("10.0.0.1") {
+		t.Fatal("want allowed after the bucket refilled")
+	}
+	if l.allow("10.0.0.1") {
+		t.Fatal("want limited")
+	}
+
+	now = now.Add(_connBucketsSweepInterval)
+	l.allow("10.0.0.3")
+	if _, ok := l.buckets["10.0.0.1"]; ok {
+		t.Fatal("want the refilled bucket evicted")
+	}
+}
+
+func TestAllowConn(t *testing.T) {
+	p, err := New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.conns.setLimit(&config.ConnectionLimit{Rate: 1})
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 50000}
+	if !p.AllowConn(addr) {
+		t.Fatal("want the first connection allowed")
+	}
+	addr.Port = 50001
+	if p.AllowConn(addr) {
+		t.Fatal("want the connection from the same ip limited")
+	}
+}
@@ -0,0 +1,51 @@
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+const defaultDeadlineHeader = "X-Deadline"
+
+// clientDeadline is the absolute deadline supplied by the clients in unix milliseconds.
+type clientDeadline struct {
+	header  string
+	trusted []*net.IPNet
+}
+
+func newClientDeadline(in *config.ClientDeadline) (*clientDeadline, error) {
+	if in == nil || !in.Enabled {
+		return nil, nil
+	}
+	trusted, err := middleware.ParseCIDRs(in.TrustedCidrs)
+	if err != nil {
+		return nil, err
+	}
+	d := &clientDeadline{
+		header:  defaultDeadlineHeader,
+		trusted: trusted,
+	}
+	if in.Header != "" {
+		d.header = in.Header
+	}
+	return d, nil
+}
+
+// remaining returns the duration until the deadline of the request,
+// it's not ok if the deadline is absent, invalid or from the untrusted source.
+func (d *clientDeadline) remaining(req *http.Request, now time.Time) (time.Duration, bool) {
+	v := req.Header.Get(d.header)
+	if v == "" || !middleware.Trusted(d.trusted, req.RemoteAddr, true) {
+		return 0, false
+	}
+	ms, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || ms <= 0 {
+		return 0, false
+	}
+	return time.Unix(0, ms*int64(time.Millisecond)).Sub(now), true
+}
@@ -0,0 +1,71 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestClientDeadline(t *testing.T) {
+	var (
+		calls     int32
+		remaining atomic.Value
+	)
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			deadline, _ := req.Context().Deadline()
+			remaining.Store(time.Until(deadline))
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol:       config.Protocol_HTTP,
+		Path:           "/deadline",
+		Timeout:        durationpb.New(10 * time.Second),
+		ClientDeadline: &config.ClientDeadline{Enabled: true, TrustedCidrs: []string{"192.0.2.0/24"}},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	newRequest := func(deadline time.Time, remoteAddr string) *http.Request {
+		req := httptest.NewRequest("GET", "/deadline", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Deadline", strconv.FormatInt(deadline.UnixNano()/int64(time.Millisecond), 10))
+		return req
+	}
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, newRequest(time.Now().Add(-time.Second), "192.0.2.1:1234"))
+	if w.Code != http.StatusGatewayTimeout || atomic.LoadInt32(&calls) != 0 {
+		t.Fatalf("want 504 without calling the upstream, got %d after %d calls", w.Code, calls)
+	}
+
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, newRequest(time.Now().Add(time.Second), "192.0.2.1:1234"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200 but got %d", w.Code)
+	}
+	if got := remaining.Load().(time.Duration); got > time.Second {
+		t.Fatalf("want the timeout shortened by the client deadline, remaining: %v", got)
+	}
+
+	// the deadline of the untrusted client is ignored
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, newRequest(time.Now().Add(-time.Second), "198.51.100.1:1234"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200 but got %d", w.Code)
+	}
+	if got := remaining.Load().(time.Duration); got <= time.Second {
+		t.Fatalf("want the endpoint timeout, remaining: %v", got)
+	}
+}
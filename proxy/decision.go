@@ -0,0 +1,164 @@
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+const (
+	defaultDecisionHeader         = "X-Gateway-Trace"
+	defaultDecisionResponseHeader = "X-Gateway-Decision"
+)
+
+// decisionTracer traces the decision path of the trusted requests asking for it.
+type decisionTracer struct {
+	header         string
+	token          string
+	trusted        []*net.IPNet
+	responseHeader string
+}
+
+func newDecisionTracer(in *config.DecisionTrace) (*decisionTracer, error) {
+	if in == nil {
+		return nil, nil
+	}
+	if in.Token == "" && len(in.TrustedCidrs) == 0 {
+		return nil, errors.New("decision trace requires the token or the trusted cidrs")
+	}
+	trusted, err := middleware.ParseCIDRs(in.TrustedCidrs)
+	if err != nil {
+		return nil, err
+	}
+	t := &decisionTracer{
+		header:         defaultDecisionHeader,
+		token:          in.Token,
+		trusted:        trusted,
+		responseHeader: defaultDecisionResponseHeader,
+	}
+	if in.Header != "" {
+		t.header = in.Header
+	}
+	if in.ResponseHeader != "" {
+		t.responseHeader = in.ResponseHeader
+	}
+	return t, nil
+}
+
+// enabled reports whether the request is traced, the header is removed so that it's not sent to the upstreams.
+func (t *decisionTracer) enabled(req *http.Request) bool {
+	v := req.Header.Get(t.header)
+	if v == "" {
+		return false
+	}
+	req.Header.Del(t.header)
+	want := t.token
+	if want == "" {
+		want = "true"
+	}
+	return subtle.ConstantTimeCompare([]byte(v), []byte(want)) == 1 && middleware.Trusted(t.trusted, req.RemoteAddr, true)
+}
+
+// decisionTrace accumulates the decision events of a request.
+type decisionTrace struct {
+	start  time.Time
+	lock   sync.Mutex
+	events []string
+}
+
+func newDecisionTrace(start time.Time) *decisionTrace {
+	return &decisionTrace{start: start}
+}
+
+func (d *decisionTrace) add(name, value string) {
+	d.lock.Lock()
+	d.events = append(d.events, name+"="+value)
+	d.lock.Unlock()
+}
+
+func (d *decisionTrace) String() string {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	events := append(d.events[:len(d.events):len(d.events)], "duration="+time.Since(d.start).String())
+	return strings.Join(events, "; ")
+}
+
+type decisionContextKey struct{}
+
+func newDecisionContext(ctx context.Context, d *decisionTrace) context.Context {
+	return context.WithValue(ctx, decisionContextKey{}, d)
+}
+
+func decisionFromContext(ctx context.Context) (*decisionTrace, bool) {
+	d, ok := ctx.Value(decisionContextKey{}).(*decisionTrace)
+	return d, ok
+}
+
+// traceMiddleware records the middleware in the decision path of the traced requests once it runs.
+func traceMiddleware(name string, m middleware.Middleware) middleware.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		tripper := m(next)
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if d, ok := decisionFromContext(req.Context()); ok {
+				d.add("middleware", name)
+			}
+			return tripper.RoundTrip(req)
+		})
+	}
+}
+
+// decisionWriter sets the decision path header before the response header is written.
+type decisionWriter struct {
+	http.ResponseWriter
+	trace   *decisionTrace
+	header  string
+	written bool
+}
+
+func (w *decisionWriter) WriteHeader(statusCode int) {
+	if !w.written {
+		w.written = true
+		w.Header().Set(w.header, w.trace.String())
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *decisionWriter) Write(b []byte) (int, error) {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *decisionWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack hijacks the connection of the upgraded responses, which have no decision path header.
+func (w *decisionWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errNotHijackable
+	}
+	w.written = true
+	return hj.Hijack()
+}
+
+func routeDecision(e *config.Endpoint) string {
+	method := e.Method
+	if method == "" {
+		method = "*"
+	}
+	return "[" + e.Protocol.String() + "] " + method + " " + e.Path
+}
@@ -0,0 +1,95 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestDecisionTrace(t *testing.T) {
+	var attempts int
+	clientFactory := func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(defaultDecisionHeader) != "" {
+				t.Fatal("want the trace header removed")
+			}
+			attempts++
+			if reqOpt, ok := middleware.FromRequestContext(req.Context()); ok {
+				reqOpt.Backends = append(reqOpt.Backends, "10.0.0.1:8000")
+			}
+			code := http.StatusOK
+			if attempts%2 == 1 {
+				code = http.StatusServiceUnavailable
+			}
+			return &http.Response{StatusCode: code, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}
+	middlewareFactory := func(c *config.Middleware) (middleware.Middleware, error) {
+		return func(next http.RoundTripper) http.RoundTripper { return next }, nil
+	}
+	p, err := New(clientFactory, middlewareFactory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{DecisionTrace: &config.DecisionTrace{}}); err == nil {
+		t.Fatal("want the decision trace without the token and trusted cidrs rejected")
+	}
+	if err := p.Update(&config.Gateway{
+		DecisionTrace: &config.DecisionTrace{Token: "secret", TrustedCidrs: []string{"192.0.2.0/24"}},
+		Middlewares: []*config.Middleware{
+			{Name: "cors"},
+			{Name: "admin", When: &config.MiddlewareMatch{Path: "^/admin"}},
+			{Name: "logging"},
+		},
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Method:   "GET",
+			Path:     "/users",
+			Retry: &config.Retry{
+				Attempts:   2,
+				Conditions: []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}},
+			},
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	do := func(remoteAddr, token string) string {
+		req := httptest.NewRequest("GET", "/users", nil)
+		req.RemoteAddr = remoteAddr
+		if token != "" {
+			req.Header.Set(defaultDecisionHeader, token)
+		}
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("want 200 but got %d", w.Code)
+		}
+		return w.Header().Get(defaultDecisionResponseHeader)
+	}
+	got := do("192.0.2.1:1234", "secret")
+	for _, want := range []string{
+		"route=[HTTP] GET /users; middleware=cors; middleware=logging; attempt={backend=10.0.0.1:8000 status=503 duration=",
+		"; middleware=cors; middleware=logging; attempt={backend=10.0.0.1:8000 status=200 duration=",
+		"; duration=",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("want the decision path containing %q but got %q", want, got)
+		}
+	}
+	if strings.Contains(got, "admin") {
+		t.Fatalf("want the skipped middleware absent but got %q", got)
+	}
+	if got := do("192.0.2.1:1234", "wrong"); got != "" {
+		t.Fatalf("want no decision path of the wrong token but got %q", got)
+	}
+	if got := do("10.0.0.1:1234", "secret"); got != "" {
+		t.Fatalf("want no decision path of the untrusted client but got %q", got)
+	}
+	if got := do("192.0.2.1:1234", ""); got != "" {
+		t.Fatalf("want no decision path without asking but got %q", got)
+	}
+}
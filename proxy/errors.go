@@ -0,0 +1,115 @@
+package proxy
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+// _maxErrorLength bounds the memory of the recorded error messages.
+const _maxErrorLength = 512
+
+// ErrorEntry is a recent error of an endpoint, either responded to the client or of a failed attempt.
+type ErrorEntry struct {
+	Time time.Time `json:"time"`
+	// Status is the status code responded, it's 0 for the failed attempts.
+	Status int `json:"status,omitempty"`
+	// Attempt is the number of the failed attempt, it's 0 for the responded errors.
+	Attempt int    `json:"attempt,omitempty"`
+	Error   string `json:"error"`
+	Path    string `json:"path"`
+}
+
+// errorRing keeps the last errors of an endpoint, the oldest ones are overwritten.
+type errorRing struct {
+	lock    sync.Mutex
+	entries []ErrorEntry
+	next    int
+	full    bool
+}
+
+func newErrorRing(size int) *errorRing {
+	return &errorRing{entries: make([]ErrorEntry, size)}
+}
+
+// errorMessage returns the message of the error without the upstream URL, which may carry the sensitive query,
+// it's truncated to the max length on a rune boundary.
+func errorMessage(err error) string {
+	msg := unwrapURLError(err).Error()
+	if len(msg) <= _maxErrorLength {
+		return msg
+	}
+	n := _maxErrorLength
+	for n > 0 && !utf8.RuneStart(msg[n]) {
+		n--
+	}
+	return msg[:n]
+}
+
+func (r *errorRing) add(status, attempt int, err error, path string) {
+	if r == nil {
+		return
+	}
+	msg := errorMessage(err)
+	r.lock.Lock()
+	r.entries[r.next] = ErrorEntry{Time: time.Now(), Status: status, Attempt: attempt, Error: msg, Path: path}
+	if r.next++; r.next == len(r.entries) {
+		r.next, r.full = 0, true
+	}
+	r.lock.Unlock()
+}
+
+// list returns the errors from the latest.
+func (r *errorRing) list() []ErrorEntry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	n := r.next
+	if r.full {
+		n = len(r.entries)
+	}
+	list := make([]ErrorEntry, 0, n)
+	for i := 1; i <= n; i++ {
+		list = append(list, r.entries[(r.next-i+len(r.entries))%len(r.entries)])
+	}
+	return list
+}
+
+// endpointErrors is the error ring of an endpoint, the ring of the same route and size is kept across the updates.
+type endpointErrors struct {
+	protocol string
+	method   string
+	path     string
+	ring     *errorRing
+}
+
+func (e *endpointErrors) key() string {
+	return e.protocol + " " + e.method + " " + e.path
+}
+
+// EndpointErrors is the recent errors of an endpoint in the debug handler.
+type EndpointErrors struct {
+	Protocol string       `json:"protocol"`
+	Method   string       `json:"method"`
+	Path     string       `json:"path"`
+	Errors   []ErrorEntry `json:"errors"`
+}
+
+// errorRing returns the error ring of the endpoint, it's nil if the recent errors are disabled.
+func (o *buildOptions) errorRing(e *config.Endpoint) *errorRing {
+	if o.recentErrors <= 0 {
+		return nil
+	}
+	errs := &endpointErrors{protocol: e.Protocol.String(), method: e.Method, path: e.Path}
+	if o.pathLabel != "" {
+		errs.path = o.pathLabel
+	}
+	if prev, ok := o.prevErrors[errs.key()]; ok && len(prev.ring.entries) == o.recentErrors {
+		errs.ring = prev.ring
+	} else {
+		errs.ring = newErrorRing(o.recentErrors)
+	}
+	*o.endpointErrors = append(*o.endpointErrors, errs)
+	return errs.ring
+}
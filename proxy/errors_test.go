@@ -0,0 +1,105 @@
+package proxy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestErrorRing(t *testing.T) {
+	r := newErrorRing(3)
+	if got := r.list(); len(got) != 0 {
+		t.Fatalf("want no errors but got %v", got)
+	}
+	for i := 1; i <= 5; i++ {
+		r.add(502, 0, errors.New(strconv.Itoa(i)), "/")
+	}
+	got := r.list()
+	if len(got) != 3 || got[0].Error != "5" || got[1].Error != "4" || got[2].Error != "3" {
+		t.Fatalf("want the last 3 errors from the latest but got %+v", got)
+	}
+	r.add(502, 0, errors.New(strings.Repeat("x", 1024)), "/")
+	if got := r.list()[0].Error; len(got) != _maxErrorLength {
+		t.Fatalf("want the error truncated to %d but got %d", _maxErrorLength, len(got))
+	}
+	if got := errorMessage(errors.New(strings.Repeat("x", _maxErrorLength-1) + "你")); got != strings.Repeat("x", _maxErrorLength-1) {
+		t.Fatalf("want the error truncated on the rune boundary but got %q", got[len(got)-4:])
+	}
+	urlErr := &url.Error{Op: "Get", URL: "http://10.0.0.1/path?token=secret", Err: errors.New("connection refused")}
+	if got := errorMessage(fmt.Errorf("attempt: %w", urlErr)); got != "connection refused" {
+		t.Fatalf("want the upstream url removed but got %q", got)
+	}
+	// the disabled ring records nothing
+	var disabled *errorRing
+	disabled.add(502, 0, errors.New("ignored"), "/")
+}
+
+func TestRecentErrors(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &config.Gateway{
+		RecentErrors: 10,
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Method:   "GET",
+			Path:     "/users/*",
+			Retry:    &config.Retry{Attempts: 2},
+		}},
+	}
+	if err := p.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/1", nil))
+	list := func() []*EndpointErrors {
+		w := httptest.NewRecorder()
+		p.DebugHandler().ServeHTTP(w, httptest.NewRequest("GET", "/debug/proxy/errors", nil))
+		var out struct {
+			Endpoints []*EndpointErrors `json:"endpoints"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+		return out.Endpoints
+	}
+	endpoints := list()
+	if len(endpoints) != 1 || endpoints[0].Path != "/users/*" || len(endpoints[0].Errors) != 3 {
+		t.Fatalf("want the 2 attempts and the responded error but got %+v", endpoints)
+	}
+	responded, attempt := endpoints[0].Errors[0], endpoints[0].Errors[2]
+	if responded.Status != http.StatusBadGateway || responded.Path != "/users/1" || !strings.Contains(responded.Error, "connection refused") {
+		t.Fatalf("unexpected responded error: %+v", responded)
+	}
+	if attempt.Status != 0 || attempt.Attempt != 1 {
+		t.Fatalf("unexpected attempt error: %+v", attempt)
+	}
+
+	// the errors are kept across the updates of the same endpoint
+	if err := p.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	if endpoints := list(); len(endpoints) != 1 || len(endpoints[0].Errors) != 3 {
+		t.Fatalf("want the errors kept but got %+v", endpoints)
+	}
+	c.RecentErrors = 0
+	if err := p.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	if endpoints := list(); len(endpoints) != 0 {
+		t.Fatalf("want no endpoints but got %+v", endpoints)
+	}
+}
@@ -0,0 +1,165 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/selector"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	_defaultPrimaryPool        = "primary"
+	_defaultUnhealthyThreshold = 3
+	_defaultUnhealthyDuration  = 10 * time.Second
+	// _poolMetadata is the metadata key of the pool which served the last attempt.
+	_poolMetadata = "failover.pool"
+	// _poolAttemptsMetadata is the metadata key of the number of the attempts sent to the pools.
+	_poolAttemptsMetadata = "failover.attempts"
+)
+
+type failoverPool struct {
+	name    string
+	tripper http.RoundTripper
+
+	lock         sync.Mutex
+	failures     uint32
+	ejectedUntil time.Time
+}
+
+func (p *failoverPool) healthy(now time.Time) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return !now.Before(p.ejectedUntil)
+}
+
+// observe counts the consecutive failures of the pool, it's ejected for the duration once they reach the threshold.
+func (p *failoverPool) observe(err error, threshold uint32, duration time.Duration, now time.Time) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	switch {
+	case err == nil:
+		p.failures = 0
+	default:
+		if p.failures++; p.failures >= threshold {
+			p.failures = 0
+			p.ejectedUntil = now.Add(duration)
+		}
+	}
+}
+
+// failoverTripper sends each attempt to the first healthy pool from the starting one of the mode.
+type failoverTripper struct {
+	pools     []*failoverPool
+	mode      config.FailoverMode
+	threshold uint32
+	duration  time.Duration
+	now       func() time.Time
+}
+
+func newFailoverTripper(e *config.Endpoint, factory client.Factory) (http.RoundTripper, error) {
+	c := e.Failover
+	if len(c.Pools) == 0 {
+		return nil, errors.New("failover pools must be specified")
+	}
+	t := &failoverTripper{
+		pools:     make([]*failoverPool, 0, len(c.Pools)+1),
+		mode:      c.Mode,
+		threshold: _defaultUnhealthyThreshold,
+		duration:  _defaultUnhealthyDuration,
+		now:       time.Now,
+	}
+	if c.UnhealthyThreshold > 0 {
+		t.threshold = c.UnhealthyThreshold
+	}
+	if c.UnhealthyDuration != nil {
+		if c.UnhealthyDuration.AsDuration() <= 0 {
+			return nil, fmt.Errorf("invalid failover unhealthy duration: %s", c.UnhealthyDuration.AsDuration())
+		}
+		t.duration = c.UnhealthyDuration.AsDuration()
+	}
+	primary := &config.Failover_Pool{Name: c.PrimaryName, Backends: e.Backends}
+	if primary.Name == "" {
+		primary.Name = _defaultPrimaryPool
+	}
+	pools := append([]*config.Failover_Pool{primary}, c.Pools...)
+	names := make(map[string]struct{}, len(pools))
+	for _, in := range pools {
+		if in.Name == "" {
+			return nil, errors.New("failover pool name must be specified")
+		}
+		if _, ok := names[in.Name]; ok {
+			return nil, fmt.Errorf("duplicate failover pool name: %s", in.Name)
+		}
+		names[in.Name] = struct{}{}
+	}
+	for _, in := range pools {
+		endpoint := proto.Clone(e).(*config.Endpoint)
+		endpoint.Failover = nil
+		endpoint.Backends = in.Backends
+		tripper, err := factory(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		t.pools = append(t.pools, &failoverPool{name: in.Name, tripper: tripper})
+	}
+	return t, nil
+}
+
+// order returns the pools to try of the attempt, the unhealthy ones are not tried unless all of them are.
+func (t *failoverTripper) order(attempt int) []*failoverPool {
+	start := 0
+	if t.mode == config.FailoverMode_PER_ATTEMPT {
+		start = attempt
+		if start >= len(t.pools) {
+			start = len(t.pools) - 1
+		}
+	}
+	now := t.now()
+	pools := make([]*failoverPool, 0, len(t.pools)-start)
+	for _, p := range t.pools[start:] {
+		if p.healthy(now) {
+			pools = append(pools, p)
+		}
+	}
+	if len(pools) == 0 {
+		pools = append(pools, t.pools[0])
+	}
+	return pools
+}
+
+func (t *failoverTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqOpt, ok := middleware.FromRequestContext(req.Context())
+	attempt := 0
+	if ok {
+		attempt, _ = strconv.Atoi(reqOpt.Metadata[_poolAttemptsMetadata])
+		reqOpt.Metadata[_poolAttemptsMetadata] = strconv.Itoa(attempt + 1)
+	}
+	var (
+		resp *http.Response
+		err  error
+	)
+	for _, p := range t.order(attempt) {
+		if ok {
+			reqOpt.Metadata[_poolMetadata] = p.name
+		}
+		resp, err = p.tripper.RoundTrip(req)
+		// the pool without available backends, e.g. all of them are tried by the request, is skipped by the attempt
+		if errors.Is(err, selector.ErrNoAvailable) {
+			continue
+		}
+		if !errors.Is(err, context.Canceled) {
+			p.observe(err, t.threshold, t.duration, t.now())
+		}
+		break
+	}
+	return resp, err
+}
@@ -0,0 +1,94 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestFailover(t *testing.T) {
+	p, err := New(func(e *config.Endpoint) (http.RoundTripper, error) {
+		target := e.Backends[0].Target
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			switch target {
+			case "down":
+				return nil, errors.New("connection refused")
+			case "empty":
+				return nil, selector.ErrNoAvailable
+			}
+			header := http.Header{}
+			header.Set("X-Backend", target)
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pools := []*config.Failover_Pool{{Name: "dr", Backends: []*config.Backend{{Target: "dr"}}}}
+	if err := p.Update(&config.Gateway{
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Path:     "/failover/attempt",
+			Backends: []*config.Backend{{Target: "down"}},
+			Retry:    &config.Retry{Attempts: 2},
+			Failover: &config.Failover{Pools: pools, Mode: config.FailoverMode_PER_ATTEMPT},
+		}, {
+			Protocol: config.Protocol_HTTP,
+			Path:     "/failover/unhealthy",
+			Backends: []*config.Backend{{Target: "down"}},
+			Failover: &config.Failover{Pools: pools, UnhealthyThreshold: 2},
+		}, {
+			Protocol: config.Protocol_HTTP,
+			Path:     "/failover/empty",
+			Backends: []*config.Backend{{Target: "empty"}},
+			Failover: &config.Failover{Pools: pools},
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	do := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	// the retry is sent to the fallback pool
+	before := testutil.ToFloat64(_metricFailoverRequests.WithLabelValues("HTTP", "GET", "/failover/attempt", "dr", "", ""))
+	if w := do("/failover/attempt"); w.Code != http.StatusOK || w.Header().Get("X-Backend") != "dr" {
+		t.Fatalf("want the retry served by dr but got %d %v", w.Code, w.Header())
+	}
+	if got := testutil.ToFloat64(_metricFailoverRequests.WithLabelValues("HTTP", "GET", "/failover/attempt", "dr", "", "")) - before; got != 1 {
+		t.Fatalf("want the pool dr recorded but got %v", got)
+	}
+
+	// the attempts spill over once the primary is unhealthy
+	for i := 0; i < 2; i++ {
+		if w := do("/failover/unhealthy"); w.Code != http.StatusBadGateway {
+			t.Fatalf("want 502 from the primary but got %d", w.Code)
+		}
+	}
+	if w := do("/failover/unhealthy"); w.Code != http.StatusOK || w.Header().Get("X-Backend") != "dr" {
+		t.Fatalf("want the unhealthy primary skipped but got %d %v", w.Code, w.Header())
+	}
+
+	// the pool without available backends is skipped by the same attempt
+	if w := do("/failover/empty"); w.Code != http.StatusOK || w.Header().Get("X-Backend") != "dr" {
+		t.Fatalf("want the empty primary skipped but got %d %v", w.Code, w.Header())
+	}
+
+	for _, e := range []*config.Endpoint{
+		{Path: "/failover", Failover: &config.Failover{}},
+		{Path: "/failover", Failover: &config.Failover{Pools: []*config.Failover_Pool{{Name: "primary"}}}},
+		{Path: "/failover", Failover: &config.Failover{Pools: pools}, Split: &config.Split{}},
+	} {
+		if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{e}}); err == nil {
+			t.Fatalf("want error for the invalid failover %v", e)
+		}
+	}
+}
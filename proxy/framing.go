@@ -0,0 +1,65 @@
+package proxy
+
+import (
+	"bytes"
+	"net"
+)
+
+const (
+	framingUnsupportedTransferEncoding = "unsupported_transfer_encoding"
+	framingMalformed                   = "malformed"
+)
+
+// The replies net/http writes straight to the connection when it fails to parse a request,
+// the handler is never called for them.
+var (
+	_replyUnsupportedTransferEncoding = []byte("HTTP/1.1 501 Not Implemented\r\nContent-Type: text/plain; charset=utf-8\r\nConnection: close\r\n\r\nUnsupported transfer encoding")
+	_replyMalformed                   = []byte("HTTP/1.1 400 Bad Request\r\nContent-Type: text/plain; charset=utf-8\r\nConnection: close\r\n\r\n400 Bad Request")
+)
+
+// FramingListener counts the requests which net/http rejects while parsing,
+// see https://www.rfc-editor.org/rfc/rfc9112#section-6.3.
+//
+// net/http enforces the message framing before the handler runs: it responds 501 to
+// multiple or non-chunked Transfer-Encoding, and 400 to unequal or invalid Content-Length.
+// A request with both Content-Length and Transfer-Encoding is read as chunked with the
+// Content-Length removed, so it's forwarded chunked and never ambiguous to the upstreams.
+// The listener wraps the connections below TLS, so on the TLS listeners it sees the replies
+// encrypted and can't match them, the rejections are counted on the plaintext listeners only.
+func FramingListener(l net.Listener) net.Listener {
+	return &framingListener{Listener: l}
+}
+
+type framingListener struct {
+	net.Listener
+}
+
+func (l *framingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &framingConn{Conn: conn}, nil
+}
+
+type framingConn struct {
+	net.Conn
+}
+
+func (c *framingConn) Write(b []byte) (int, error) {
+	if reason := framingRejected(b); reason != "" {
+		_metricFramingRejected.WithLabelValues(reason).Inc()
+	}
+	return c.Conn.Write(b)
+}
+
+// framingRejected returns the reason if b is the reply of net/http to a request it failed to parse.
+func framingRejected(b []byte) string {
+	switch {
+	case bytes.Equal(b, _replyUnsupportedTransferEncoding):
+		return framingUnsupportedTransferEncoding
+	case bytes.Equal(b, _replyMalformed):
+		return framingMalformed
+	}
+	return ""
+}
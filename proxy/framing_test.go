@@ -0,0 +1,92 @@
+package proxy
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestFramingListener(t *testing.T) {
+	served := make(chan *http.Request, 1)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		served <- req
+	}))
+	srv.Listener = FramingListener(srv.Listener)
+	srv.Start()
+	defer srv.Close()
+
+	tests := []struct {
+		raw      string
+		wantCode int
+		reason   string
+	}{
+		{
+			raw:      "POST / HTTP/1.1\r\nHost: gateway\r\nContent-Length: 5\r\nTransfer-Encoding: chunked\r\n\r\n0\r\n\r\n",
+			wantCode: http.StatusOK,
+		},
+		{
+			raw:      "POST / HTTP/1.1\r\nHost: gateway\r\nTransfer-Encoding: gzip, chunked\r\n\r\n0\r\n\r\n",
+			wantCode: http.StatusNotImplemented,
+			reason:   framingUnsupportedTransferEncoding,
+		},
+		{
+			raw:      "POST / HTTP/1.1\r\nHost: gateway\r\nTransfer-Encoding: chunked\r\nTransfer-Encoding: chunked\r\n\r\n0\r\n\r\n",
+			wantCode: http.StatusNotImplemented,
+			reason:   framingUnsupportedTransferEncoding,
+		},
+		{
+			raw:      "POST / HTTP/1.1\r\nHost: gateway\r\nContent-Length: 5, 6\r\n\r\nhello",
+			wantCode: http.StatusBadRequest,
+			reason:   framingMalformed,
+		},
+		{
+			raw:      "POST / HTTP/1.1\r\nHost: gateway\r\nContent-Length: 5\r\nContent-Length: 6\r\n\r\nhello",
+			wantCode: http.StatusBadRequest,
+			reason:   framingMalformed,
+		},
+	}
+	for _, test := range tests {
+		var before float64
+		if test.reason != "" {
+			before = testutil.ToFloat64(_metricFramingRejected.WithLabelValues(test.reason))
+		}
+		conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = conn.SetDeadline(time.Now().Add(time.Second))
+		if _, err = conn.Write([]byte(test.raw)); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		conn.Close()
+		if resp.StatusCode != test.wantCode {
+			t.Errorf("%q: want %d but got %d", test.raw, test.wantCode, resp.StatusCode)
+		}
+		if test.reason == "" {
+			// the Content-Length is removed in favor of the Transfer-Encoding
+			req := <-served
+			if req.Header.Get("Content-Length") != "" || req.ContentLength != -1 {
+				t.Errorf("%q: want served chunked without Content-Length", test.raw)
+			}
+			continue
+		}
+		select {
+		case <-served:
+			t.Errorf("%q: want rejected before the handler", test.raw)
+		default:
+		}
+		if got := testutil.ToFloat64(_metricFramingRejected.WithLabelValues(test.reason)) - before; got != 1 {
+			t.Errorf("%q: want 1 rejection of %s but got %v", test.raw, test.reason, got)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/kratos/v2/transport/http/status"
+	"google.golang.org/grpc/codes"
+)
+
+// isGRPCStreaming reports whether the request is a native gRPC request of the endpoint without retries,
+// which body is passed through to the upstream as is instead of being buffered, so that the client streaming works.
+// the grpc-web bodies aren't streamed, since the grpc-web-text ones are base64 encoded.
+func isGRPCStreaming(protocol config.Protocol, attempts int, req *http.Request) bool {
+	return protocol == config.Protocol_GRPC && attempts <= 1 && isGRPCNative(req)
+}
+
+// isGRPCNative reports whether the inbound request is a native gRPC request rather than grpc-web.
+func isGRPCNative(req *http.Request) bool {
+	contentType := req.Header.Get("Content-Type")
+	return strings.HasPrefix(contentType, "application/grpc") && !strings.HasPrefix(contentType, "application/grpc-web")
+}
+
+// mapGRPCStatus sets the HTTP status of the response from the Grpc-Status header,
+// which is present in the trailers-only responses, with the standard gRPC to HTTP mapping.
+func mapGRPCStatus(resp *http.Response) {
+	code, err := strconv.Atoi(resp.Header.Get("Grpc-Status"))
+	if err != nil || codes.Code(code) == codes.OK {
+		return
+	}
+	resp.StatusCode = status.FromGRPCCode(codes.Code(code))
+	resp.Status = strconv.Itoa(resp.StatusCode) + " " + http.StatusText(resp.StatusCode)
+}
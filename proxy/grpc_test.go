@@ -0,0 +1,162 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+// grpcMessageHeaderSize is the size of the gRPC length-prefixed message header,
+// 1 byte compressed flag and 4 bytes message length.
+const grpcMessageHeaderSize = 5
+
+func grpcMessage(payload string) []byte {
+	b := make([]byte, grpcMessageHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(b[1:], uint32(len(payload)))
+	copy(b[grpcMessageHeaderSize:], payload)
+	return b
+}
+
+func TestGRPCNativeBody(t *testing.T) {
+	in := append(grpcMessage("hello"), grpcMessage("world")...)
+	retry := &config.Retry{
+		Attempts:           2,
+		RetryNonIdempotent: true,
+		Conditions:         []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}},
+	}
+	// the native body is passed through as is without retries, and buffered to replay with them
+	for _, test := range []struct {
+		retry *config.Retry
+		want  int
+	}{{want: 1}, {retry: retry, want: 2}} {
+		var bodies [][]byte
+		p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+			return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				b, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					return nil, err
+				}
+				bodies = append(bodies, b)
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}, nil
+			}), nil
+		}, middleware.Create)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_GRPC,
+			Method:   "POST",
+			Path:     "/helloworld.Greeter/SayHello",
+			Retry:    test.retry,
+		}}}); err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("POST", "/helloworld.Greeter/SayHello", bytes.NewReader(in))
+		req.ProtoMajor = 2
+		req.Header.Set("Content-Type", "application/grpc")
+		p.ServeHTTP(httptest.NewRecorder(), req)
+		if len(bodies) != test.want {
+			t.Fatalf("%v: want the grpc body relayed %d times but got %q", test.retry, test.want, bodies)
+		}
+		for _, b := range bodies {
+			if !bytes.Equal(b, in) {
+				t.Fatalf("want the grpc body %q but got %q", in, b)
+			}
+		}
+	}
+}
+
+func TestMapGRPCStatus(t *testing.T) {
+	tests := []struct {
+		grpcStatus string
+		want       int
+	}{
+		{grpcStatus: "", want: http.StatusOK},
+		{grpcStatus: "0", want: http.StatusOK},
+		{grpcStatus: "5", want: http.StatusNotFound},
+		{grpcStatus: "14", want: http.StatusServiceUnavailable},
+		{grpcStatus: "invalid", want: http.StatusOK},
+	}
+	for _, test := range tests {
+		resp := &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Header: http.Header{}}
+		if test.grpcStatus != "" {
+			resp.Header.Set("Grpc-Status", test.grpcStatus)
+		}
+		mapGRPCStatus(resp)
+		if resp.StatusCode != test.want {
+			t.Errorf("%q: want %d but got %d", test.grpcStatus, test.want, resp.StatusCode)
+		}
+		if want := strconv.Itoa(test.want) + " " + http.StatusText(test.want); resp.Status != want {
+			t.Errorf("%q: want the status %q but got %q", test.grpcStatus, want, resp.Status)
+		}
+	}
+}
+
+func TestIsGRPCNative(t *testing.T) {
+	tests := map[string]bool{
+		"application/grpc":          true,
+		"application/grpc+proto":    true,
+		"application/grpc-web":      false,
+		"application/grpc-web-text": false,
+		"application/json":          false,
+	}
+	for contentType, want := range tests {
+		req := &http.Request{Header: http.Header{"Content-Type": []string{contentType}}}
+		if got := isGRPCNative(req); got != want {
+			t.Errorf("%s: want %v but got %v", contentType, want, got)
+		}
+	}
+}
+
+func TestGRPCWebTextBody(t *testing.T) {
+	body := base64.StdEncoding.EncodeToString(grpcMessage("hello"))
+	var bodies []string
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			b, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			bodies = append(bodies, string(b))
+			code := http.StatusOK
+			if len(bodies) == 1 {
+				code = http.StatusServiceUnavailable
+			}
+			return &http.Response{StatusCode: code, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol: config.Protocol_GRPC,
+		Method:   "POST",
+		Path:     "/helloworld.Greeter/SayHello",
+		Retry: &config.Retry{
+			Attempts:           2,
+			RetryNonIdempotent: true,
+			Conditions:         []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}},
+		},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("POST", "/helloworld.Greeter/SayHello", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/grpc-web-text")
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200 but got %d", w.Code)
+	}
+	// the base64 body is relayed as is, and replayed by the retry
+	if len(bodies) != 2 || bodies[0] != body || bodies[1] != body {
+		t.Fatalf("want the grpc-web-text body relayed twice but got %q", bodies)
+	}
+}
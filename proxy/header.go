@@ -0,0 +1,128 @@
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+// _hopHeaders are the hop-by-hop headers removed before forwarding,
+// see https://www.rfc-editor.org/rfc/rfc7230#section-6.1
+var _hopHeaders = map[string]struct{}{
+	"Connection":          {},
+	"Proxy-Connection":    {},
+	"Keep-Alive":          {},
+	"Proxy-Authenticate":  {},
+	"Proxy-Authorization": {},
+	"Te":                  {},
+	"Trailer":             {},
+	"Transfer-Encoding":   {},
+	"Upgrade":             {},
+}
+
+type headerFilter struct {
+	allow map[string]struct{}
+	deny  map[string]struct{}
+}
+
+func newHeaderFilter(c *config.HeaderFilter) *headerFilter {
+	if c == nil || (len(c.Allow) == 0 && len(c.Deny) == 0) {
+		return nil
+	}
+	f := &headerFilter{}
+	if len(c.Allow) > 0 {
+		f.allow = make(map[string]struct{}, len(c.Allow))
+		for _, name := range c.Allow {
+			f.allow[http.CanonicalHeaderKey(name)] = struct{}{}
+		}
+	}
+	f.deny = make(map[string]struct{}, len(c.Deny))
+	for _, name := range c.Deny {
+		f.deny[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+	return f
+}
+
+func (f *headerFilter) forwarded(name string) bool {
+	if f == nil {
+		return true
+	}
+	if f.allow != nil {
+		if _, ok := f.allow[name]; !ok {
+			return false
+		}
+	}
+	_, denied := f.deny[name]
+	return !denied
+}
+
+// copyResponseHeader copies the response headers except the hop-by-hop and the filtered ones.
+func copyResponseHeader(dst, src http.Header, filter *headerFilter) {
+	// the headers listed in Connection are hop-by-hop as well
+	var connection map[string]struct{}
+	for _, v := range src["Connection"] {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				if connection == nil {
+					connection = make(map[string]struct{})
+				}
+				connection[http.CanonicalHeaderKey(name)] = struct{}{}
+			}
+		}
+	}
+	for k, v := range src {
+		if _, ok := _hopHeaders[k]; ok {
+			continue
+		}
+		if _, ok := connection[k]; ok {
+			continue
+		}
+		if !filter.forwarded(k) {
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+// headerSize returns the sum of the lengths of the header keys and values.
+func headerSize(h http.Header) int64 {
+	var size int64
+	for k, vv := range h {
+		for _, v := range vv {
+			size += int64(len(k) + len(v))
+		}
+	}
+	return size
+}
+
+const defaultViaPseudonym = "gateway"
+
+// viaPseudonym returns the received-by of the gateway, or empty if the Via is disabled.
+func viaPseudonym(c *config.Via) string {
+	if c == nil || !c.Enabled {
+		return ""
+	}
+	if c.Pseudonym != "" {
+		return c.Pseudonym
+	}
+	return defaultViaPseudonym
+}
+
+// addVia appends the received protocol and the pseudonym to the Via, folding the prior values into one,
+// the protocol is HTTP/1.1 if it's unknown.
+func addVia(header http.Header, major, minor int, pseudonym string) {
+	if major == 0 {
+		major, minor = 1, 1
+	}
+	version := strconv.Itoa(major)
+	if major < 2 {
+		version += "." + strconv.Itoa(minor)
+	}
+	via := version + " " + pseudonym
+	if prior := header.Values("Via"); len(prior) > 0 {
+		via = strings.Join(prior, ", ") + ", " + via
+	}
+	header.Set("Via", via)
+}
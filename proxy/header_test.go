@@ -0,0 +1,109 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestCopyResponseHeader(t *testing.T) {
+	src := http.Header{
+		"Content-Type":      []string{"application/json"},
+		"Server":            []string{"nginx"},
+		"X-Powered-By":      []string{"php"},
+		"X-Request-Id":      []string{"abc"},
+		"Connection":        []string{"keep-alive, X-Internal"},
+		"Keep-Alive":        []string{"timeout=5"},
+		"X-Internal":        []string{"1"},
+		"Transfer-Encoding": []string{"chunked"},
+	}
+	tests := []struct {
+		filter *config.HeaderFilter
+		want   http.Header
+	}{
+		{
+			want: http.Header{
+				"Content-Type": []string{"application/json"},
+				"Server":       []string{"nginx"},
+				"X-Powered-By": []string{"php"},
+				"X-Request-Id": []string{"abc"},
+			},
+		},
+		{
+			filter: &config.HeaderFilter{Deny: []string{"server", "X-Powered-By"}},
+			want: http.Header{
+				"Content-Type": []string{"application/json"},
+				"X-Request-Id": []string{"abc"},
+			},
+		},
+		{
+			filter: &config.HeaderFilter{Allow: []string{"Content-Type", "X-Request-Id", "X-Internal"}, Deny: []string{"X-Request-Id"}},
+			want: http.Header{
+				"Content-Type": []string{"application/json"},
+			},
+		},
+	}
+	for _, test := range tests {
+		dst := http.Header{}
+		copyResponseHeader(dst, src, newHeaderFilter(test.filter))
+		if !reflect.DeepEqual(dst, test.want) {
+			t.Errorf("%v: want %v but got %v", test.filter, test.want, dst)
+		}
+	}
+}
+
+func TestHeaderSize(t *testing.T) {
+	h := http.Header{}
+	h.Add("Accept", "*/*")
+	h.Add("X-Tag", "a")
+	h.Add("X-Tag", "bc")
+	if got := headerSize(h); got != int64(len("Accept*/*")+len("X-Taga")+len("X-Tagbc")) {
+		t.Fatalf("unexpected header size: %d", got)
+	}
+}
+
+func TestVia(t *testing.T) {
+	var forwarded string
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			forwarded = req.Header.Get("Via")
+			header := http.Header{"Via": []string{"1.0 fred", "1.1 p.example.net"}}
+			return &http.Response{StatusCode: http.StatusOK, ProtoMajor: 2, Header: header, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{
+		{Protocol: config.Protocol_HTTP, Path: "/via", Via: &config.Via{Enabled: true, Pseudonym: "edge"}},
+		{Protocol: config.Protocol_HTTP, Path: "/default", Via: &config.Via{Enabled: true}},
+		{Protocol: config.Protocol_HTTP, Path: "/off"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path      string
+		forwarded string
+		responded string
+	}{
+		{path: "/via", forwarded: "1.0 client, 1.1 edge", responded: "1.0 fred, 1.1 p.example.net, 2 edge"},
+		{path: "/default", forwarded: "1.0 client, 1.1 gateway", responded: "1.0 fred, 1.1 p.example.net, 2 gateway"},
+		{path: "/off", forwarded: "1.0 client", responded: "1.0 fred"},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", test.path, nil)
+		req.Header.Set("Via", "1.0 client")
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, req)
+		if forwarded != test.forwarded {
+			t.Fatalf("%s: want the forwarded Via %q but got %q", test.path, test.forwarded, forwarded)
+		}
+		if got := w.Header().Get("Via"); got != test.responded {
+			t.Fatalf("%s: want the responded Via %q but got %q", test.path, test.responded, got)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInboundLabels(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.TLS = nil
+	if scheme, listener := inboundLabels(req); scheme != "http" || listener != "" {
+		t.Fatalf("want http without listener but got %s %s", scheme, listener)
+	}
+	ctx := context.WithValue(req.Context(), http.LocalAddrContextKey, &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8443})
+	req = req.WithContext(ctx)
+	req.TLS = &tls.ConnectionState{}
+	if scheme, listener := inboundLabels(req); scheme != "https" || listener != "8443" {
+		t.Fatalf("want https 8443 but got %s %s", scheme, listener)
+	}
+}
@@ -0,0 +1,43 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type downstreamKey struct{}
+
+// instrumentMiddleware records the self duration of the middleware,
+// the time spent in the next round trippers is excluded.
+func instrumentMiddleware(observer prometheus.Observer, m middleware.Middleware, next http.RoundTripper) http.RoundTripper {
+	current := m(middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		startTime := time.Now()
+		resp, err := next.RoundTrip(req)
+		if downstream, ok := req.Context().Value(downstreamKey{}).(*time.Duration); ok {
+			*downstream += time.Since(startTime)
+		}
+		return resp, err
+	}))
+	return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		var downstream time.Duration
+		startTime := time.Now()
+		resp, err := current.RoundTrip(req.WithContext(context.WithValue(req.Context(), downstreamKey{}, &downstream)))
+		observer.Observe((time.Since(startTime) - downstream).Seconds())
+		return resp, err
+	})
+}
+
+// observeWithTrace observes the value with the exemplar of the trace id if the request is traced.
+func observeWithTrace(observer prometheus.Observer, v float64, req *http.Request) {
+	if traceID := middleware.TraceID(req); traceID != "" {
+		if eo, ok := observer.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(v, prometheus.Labels{"trace_id": traceID})
+			return
+		}
+	}
+	observer.Observe(v)
+}
@@ -0,0 +1,63 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type observerFunc func(float64)
+
+func (f observerFunc) Observe(v float64) { f(v) }
+
+func TestInstrumentMiddleware(t *testing.T) {
+	var observed float64
+	m := func(next http.RoundTripper) http.RoundTripper {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			time.Sleep(10 * time.Millisecond)
+			return next.RoundTrip(req)
+		})
+	}
+	next := middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		time.Sleep(100 * time.Millisecond)
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+	tripper := instrumentMiddleware(observerFunc(func(v float64) { observed = v }), m, next)
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1/", nil)
+	if _, err := tripper.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if observed < 0.01 || observed >= 0.1 {
+		t.Fatalf("want self duration excluding next, got: %v", observed)
+	}
+}
+
+type exemplarObserver struct {
+	observerFunc
+	exemplar prometheus.Labels
+}
+
+func (o *exemplarObserver) ObserveWithExemplar(v float64, exemplar prometheus.Labels) {
+	o.exemplar = exemplar
+	o.Observe(v)
+}
+
+func TestObserveWithTrace(t *testing.T) {
+	var observed float64
+	o := &exemplarObserver{observerFunc: func(v float64) { observed = v }}
+	req, _ := http.NewRequest(http.MethodGet, "http://127.0.0.1/", nil)
+	observeWithTrace(o, 1, req)
+	if observed != 1 || o.exemplar != nil {
+		t.Fatalf("want no exemplar without the trace but got %v", o.exemplar)
+	}
+	reqOpt := middleware.NewRequestOptions(&config.Endpoint{})
+	reqOpt.Metadata[middleware.TraceIDMetadata] = "4bf92f3577b34da6a3ce929d0e0e4736"
+	observeWithTrace(o, 2, req.WithContext(middleware.NewRequestContext(req.Context(), reqOpt)))
+	if observed != 2 || o.exemplar["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("want the exemplar of the trace id but got %v", o.exemplar)
+	}
+}
@@ -0,0 +1,84 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+const (
+	_defaultMaintenanceBody = "503 maintenance"
+	// _maintenancePath is the path label of the maintenance responses in the metrics.
+	_maintenancePath = "/maintenance"
+)
+
+// maintenance responds 503 for all the routes except the exempt paths while it's enabled,
+// the switch of the debug handler overrides the config until it's cleared.
+type maintenance struct {
+	mu       sync.RWMutex
+	config   *config.Maintenance
+	override *bool
+}
+
+func (m *maintenance) update(c *config.Maintenance) {
+	m.mu.Lock()
+	m.config = c
+	m.mu.Unlock()
+}
+
+// setOverride switches the maintenance regardless of the config, it's cleared with nil.
+func (m *maintenance) setOverride(enabled *bool) {
+	m.mu.Lock()
+	m.override = enabled
+	m.mu.Unlock()
+}
+
+// state returns the effective switch and the override of it.
+func (m *maintenance) state() (bool, *bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.override != nil {
+		return *m.override, m.override
+	}
+	return m.config.GetEnabled(), nil
+}
+
+func (m *maintenance) exempt(path string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, p := range m.config.GetExemptPaths() {
+		if strings.HasSuffix(p, "*") {
+			if strings.HasPrefix(path, strings.TrimSuffix(p, "*")) {
+				return true
+			}
+		} else if path == p {
+			return true
+		}
+	}
+	return false
+}
+
+// serve responds the maintenance response, and reports whether the request is responded.
+func (m *maintenance) serve(w http.ResponseWriter, req *http.Request) bool {
+	if enabled, _ := m.state(); !enabled || m.exempt(req.URL.Path) {
+		return false
+	}
+	m.mu.RLock()
+	body := m.config.GetBody()
+	for k, v := range m.config.GetHeaders() {
+		w.Header().Set(k, v)
+	}
+	m.mu.RUnlock()
+	if body == "" {
+		body = _defaultMaintenanceBody
+	}
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.WriteHeader(http.StatusServiceUnavailable)
+	w.Write([]byte(body))
+	_metricRequestsTotal.WithLabelValues("HTTP", req.Method, _maintenancePath, "503", "", "").Inc()
+	return true
+}
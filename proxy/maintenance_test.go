@@ -0,0 +1,70 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestMaintenance(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{
+		Endpoints: []*config.Endpoint{
+			{Protocol: config.Protocol_HTTP, Path: "/users"},
+			{Protocol: config.Protocol_HTTP, Path: "/healthz/*"},
+		},
+		Maintenance: &config.Maintenance{
+			Enabled:     true,
+			Body:        `{"message":"maintenance"}`,
+			Headers:     map[string]string{"Content-Type": "application/json", "Retry-After": "600"},
+			ExemptPaths: []string{"/healthz/*"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	do := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		if strings.HasPrefix(path, "/debug/") {
+			p.DebugHandler().ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		} else {
+			p.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		}
+		return w
+	}
+	w := do("GET", "/users")
+	if w.Code != http.StatusServiceUnavailable || w.Body.String() != `{"message":"maintenance"}` ||
+		w.Header().Get("Content-Type") != "application/json" || w.Header().Get("Retry-After") != "600" {
+		t.Fatalf("want the maintenance response but got %d %v %s", w.Code, w.Header(), w.Body.String())
+	}
+	if w := do("GET", "/healthz/ready"); w.Code != http.StatusOK {
+		t.Fatalf("want the exempt path served but got %d", w.Code)
+	}
+
+	// the debug handler overrides the config until it's cleared
+	if w := do("POST", "/debug/proxy/maintenance?enabled=false"); w.Code != http.StatusOK {
+		t.Fatalf("want the override switched but got %d", w.Code)
+	}
+	if w := do("GET", "/users"); w.Code != http.StatusOK {
+		t.Fatalf("want the maintenance disabled but got %d", w.Code)
+	}
+	if w := do("DELETE", "/debug/proxy/maintenance"); w.Code != http.StatusOK {
+		t.Fatalf("want the override cleared but got %d", w.Code)
+	}
+	if w := do("GET", "/users"); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("want the maintenance of the config but got %d", w.Code)
+	}
+	if w := do("POST", "/debug/proxy/maintenance?enabled=maybe"); w.Code != http.StatusBadRequest {
+		t.Fatalf("want 400 for the invalid switch but got %d", w.Code)
+	}
+}
@@ -0,0 +1,45 @@
+package proxy
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+const (
+	_livezPath  = "/livez"
+	_readyzPath = "/readyz"
+)
+
+// ProbeHandler serves the liveness and readiness probes ahead of the routes,
+// the other requests are passed to the next handler.
+// The /livez always returns 200 once the process is running,
+// the /readyz returns 503 until the first successful Update.
+func (p *Proxy) ProbeHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case _livezPath:
+			writeProbe(w, true)
+		case _readyzPath:
+			writeProbe(w, p.Ready())
+		default:
+			next.ServeHTTP(w, req)
+		}
+	})
+}
+
+// Ready reports whether the config has been loaded successfully.
+func (p *Proxy) Ready() bool {
+	return atomic.LoadInt32(&p.ready) == 1
+}
+
+func writeProbe(w http.ResponseWriter, ok bool) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("not ready\n"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("ok\n"))
+}
@@ -0,0 +1,45 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+)
+
+func TestProbeHandler(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := p.ProbeHandler(p)
+	probe := func(path string) int {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w.Code
+	}
+	if code := probe("/livez"); code != http.StatusOK {
+		t.Fatalf("want livez ok, got: %d", code)
+	}
+	if code := probe("/readyz"); code != http.StatusServiceUnavailable {
+		t.Fatalf("want readyz unavailable before update, got: %d", code)
+	}
+	if code := probe("/notfound"); code != http.StatusServiceUnavailable {
+		t.Fatalf("want the requests unavailable before update, got: %d", code)
+	}
+	if err := p.Update(&config.Gateway{}); err != nil {
+		t.Fatal(err)
+	}
+	if code := probe("/readyz"); code != http.StatusOK {
+		t.Fatalf("want readyz ok after update, got: %d", code)
+	}
+	if code := probe("/notfound"); code != http.StatusNotFound {
+		t.Fatalf("want the other requests routed, got: %d", code)
+	}
+}
@@ -0,0 +1,28 @@
+package proxy
+
+import (
+	"net/http"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+// checkProtocol returns the status code and the reason if the request doesn't speak the protocol of the endpoint,
+// the gRPC endpoints accept the native gRPC over HTTP/2 and the grpc-web, the HTTP endpoints reject the gRPC.
+func checkProtocol(protocol config.Protocol, req *http.Request) (int, string) {
+	grpc := strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc")
+	switch protocol {
+	case config.Protocol_GRPC:
+		if !grpc {
+			return http.StatusUnsupportedMediaType, "grpc endpoint requires the application/grpc content type"
+		}
+		if isGRPCNative(req) && req.ProtoMajor < 2 {
+			return http.StatusBadRequest, "grpc endpoint requires HTTP/2"
+		}
+	case config.Protocol_HTTP:
+		if grpc {
+			return http.StatusUnsupportedMediaType, "http endpoint does not accept grpc"
+		}
+	}
+	return 0, ""
+}
@@ -0,0 +1,33 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+func TestCheckProtocol(t *testing.T) {
+	tests := []struct {
+		protocol    config.Protocol
+		protoMajor  int
+		contentType string
+		want        int
+	}{
+		{protocol: config.Protocol_GRPC, protoMajor: 2, contentType: "application/grpc"},
+		{protocol: config.Protocol_GRPC, protoMajor: 1, contentType: "application/grpc-web+proto"},
+		{protocol: config.Protocol_GRPC, protoMajor: 1, contentType: "application/grpc", want: http.StatusBadRequest},
+		{protocol: config.Protocol_GRPC, protoMajor: 2, contentType: "application/json", want: http.StatusUnsupportedMediaType},
+		{protocol: config.Protocol_HTTP, protoMajor: 1, contentType: "application/json"},
+		{protocol: config.Protocol_HTTP, protoMajor: 2, contentType: "application/grpc", want: http.StatusUnsupportedMediaType},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", "/", nil)
+		req.ProtoMajor = test.protoMajor
+		req.Header.Set("Content-Type", test.contentType)
+		if got, _ := checkProtocol(test.protocol, req); got != test.want {
+			t.Errorf("%s HTTP/%d %s: want %d but got %d", test.protocol, test.protoMajor, test.contentType, test.want, got)
+		}
+	}
+}
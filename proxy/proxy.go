@@ -1,15 +1,16 @@
 package proxy
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
-	"io/ioutil"
 	"net"
 	"net/http"
+	"net/url"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -18,10 +19,12 @@ import (
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
 	"github.com/go-kratos/gateway/client"
 	"github.com/go-kratos/gateway/middleware"
+	"github.com/go-kratos/gateway/proxy/condition"
 	"github.com/go-kratos/gateway/router"
 	"github.com/go-kratos/gateway/router/mux"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/transport/http/status"
+	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -51,6 +54,18 @@ var (
 		Name:      "requests_rx_bytes",
 		Help:      "Total received connection bytes",
 	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricUpstreamSentBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_upstream_tx_bytes",
+		Help:      "Total request header and body bytes sent to the upstreams, including the retries",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricUpstreamReceivedHeaderBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_upstream_rx_header_bytes",
+		Help:      "Total response header bytes received from the upstreams, including the retries",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
 	_metricRetryTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "go",
 		Subsystem: "gateway",
@@ -63,6 +78,109 @@ var (
 		Name:      "requests_retry_success",
 		Help:      "Total request retry successes",
 	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricRetrySkipped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_retry_skipped",
+		Help:      "Total request retries skipped by the concurrent retries limit",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricRetryByCode = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_retry_by_code_total",
+		Help:      "Total request retries by the status code or error class of the attempt triggered them",
+	}, []string{"protocol", "method", "path", "code", "service", "basePath"})
+	_metricRetryExhausted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_retry_exhausted_total",
+		Help:      "Total requests all the attempts of which matched the retry conditions",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricUpstreamProtocolErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_upstream_protocol_errors_total",
+		Help:      "Total upstream attempts failed for the responses violating the HTTP protocol",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricUpstreamHeaderTooLarge = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_upstream_header_too_large_total",
+		Help:      "Total upstream attempts failed for the response headers exceeding the limit",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricTunnelsClosed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_tunnels_closed_total",
+		Help:      "Total upgraded connections closed by either side or the idle timeout",
+	}, []string{"protocol", "method", "path", "reason", "service", "basePath"})
+	_metricSplitRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_split_total",
+		Help:      "Total requests of the split endpoints by the assigned variant",
+	}, []string{"protocol", "method", "path", "variant", "service", "basePath"})
+	_metricFailoverRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_failover_pool_total",
+		Help:      "Total requests of the failover endpoints by the pool which served the last attempt",
+	}, []string{"protocol", "method", "path", "pool", "service", "basePath"})
+	_metricProtocolMismatch = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_protocol_mismatch_total",
+		Help:      "Total requests rejected for not speaking the protocol of the endpoint",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricFramingRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_framing_rejected_total",
+		Help:      "Total requests rejected by net/http while parsing, including the ambiguous message framing which may be used for the request smuggling",
+	}, []string{"reason"})
+	_metricURLRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_url_rejected_total",
+		Help:      "Total requests rejected for the too long URI or the control characters in the path",
+	}, []string{"reason"})
+	_metricEmptyResponse = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_empty_response_total",
+		Help:      "The total number of backend responses without body where a body is expected",
+	}, []string{"protocol", "method", "path", "code", "service", "basePath"})
+	_metricSlowUpstreamAborts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_slow_upstream_aborted_total",
+		Help:      "The total number of responses aborted by the upstream transfer rate floor",
+	}, []string{"protocol", "method", "path", "service", "basePath"})
+	_metricRouteCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "routes",
+		Help:      "The number of routes registered including the aliases",
+	})
+	_metricBufferedBodyBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_buffered_body_bytes",
+		Help:      "The bytes of the request bodies buffered for the in-flight requests, in memory or spilled to the temp files",
+	}, []string{"storage"})
+	_metricInboundRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "requests_inbound_code_total",
+		Help:      "The total number of processed requests by inbound scheme and listener",
+	}, []string{"protocol", "method", "path", "code", "scheme", "listener"})
+	_metricMiddlewareDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "go",
+		Subsystem: "gateway",
+		Name:      "middleware_duration_seconds",
+		Help:      "Middleware self duration(sec) excluding the next round trippers.",
+		Buckets:   []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1},
+	}, []string{"name", "method", "path"})
 )
 
 func init() {
@@ -70,11 +188,48 @@ func init() {
 	prometheus.MustRegister(_metricRequestsDuration)
 	prometheus.MustRegister(_metricRetryTotal)
 	prometheus.MustRegister(_metricRetrySuccess)
+	prometheus.MustRegister(_metricRetrySkipped)
+	prometheus.MustRegister(_metricRetryByCode)
+	prometheus.MustRegister(_metricRetryExhausted)
+	prometheus.MustRegister(_metricFramingRejected)
+	prometheus.MustRegister(_metricURLRejected)
+	prometheus.MustRegister(_metricUpstreamProtocolErrors)
+	prometheus.MustRegister(_metricUpstreamHeaderTooLarge)
+	prometheus.MustRegister(_metricProtocolMismatch)
+	prometheus.MustRegister(_metricTunnelsClosed)
+	prometheus.MustRegister(_metricSplitRequests)
+	prometheus.MustRegister(_metricFailoverRequests)
 	prometheus.MustRegister(_metricSentBytes)
 	prometheus.MustRegister(_metricReceivedBytes)
+	prometheus.MustRegister(_metricUpstreamSentBytes)
+	prometheus.MustRegister(_metricUpstreamReceivedHeaderBytes)
+	prometheus.MustRegister(_metricMiddlewareDuration)
+	prometheus.MustRegister(_metricInboundRequestsTotal)
+	prometheus.MustRegister(_metricRouteCount)
+	prometheus.MustRegister(_metricBufferedBodyBytes)
+	prometheus.MustRegister(_metricEmptyResponse)
+	prometheus.MustRegister(_metricSlowUpstreamAborts)
+}
+
+// setOriginalURIHeader sets the verbatim request URI of the client before any rewriting,
+// the value of the client is overwritten.
+func setOriginalURIHeader(req *http.Request, header string) {
+	uri := req.RequestURI
+	if uri == "" {
+		uri = req.URL.RequestURI()
+	}
+	req.Header.Set(header, uri)
 }
 
-func setXFFHeader(req *http.Request) {
+func setXFFHeader(req *http.Request, policy config.XForwardedFor) {
+	switch policy {
+	case config.XForwardedFor_CLEAR:
+		req.Header.Del("X-Forwarded-For")
+		return
+	case config.XForwardedFor_OMIT:
+		req.Header["X-Forwarded-For"] = nil
+		return
+	}
 	// see https://github.com/golang/go/blob/master/src/net/http/httputil/reverseproxy.go
 	if clientIP, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
 		// If we aren't the first proxy retain prior
@@ -82,7 +237,7 @@ func setXFFHeader(req *http.Request) {
 		// separated list and fold multiple headers into one.
 		prior, ok := req.Header["X-Forwarded-For"]
 		omit := ok && prior == nil // Issue 38079: nil now means don't populate the header
-		if len(prior) > 0 {
+		if len(prior) > 0 && policy == config.XForwardedFor_APPEND {
 			clientIP = strings.Join(prior, ", ") + ", " + clientIP
 		}
 		if !omit {
@@ -91,9 +246,36 @@ func setXFFHeader(req *http.Request) {
 	}
 }
 
-func writeError(w http.ResponseWriter, r *http.Request, err error, protocol config.Protocol, service, basePath string) {
+func setUserAgentHeader(req *http.Request, c *config.UserAgent) {
+	switch policy := c.GetPolicy().(type) {
+	case *config.UserAgent_Set:
+		req.Header.Set("User-Agent", policy.Set)
+	case *config.UserAgent_Suffix:
+		if ua := req.Header.Get("User-Agent"); ua != "" {
+			req.Header.Set("User-Agent", ua+" "+policy.Suffix)
+		} else {
+			req.Header.Set("User-Agent", policy.Suffix)
+		}
+	}
+}
+
+// unwrapURLError returns the cause of the client error, without the upstream URL which may carry the sensitive query.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// writeError responds the error, and returns the status code of it.
+// the gateway-originated 502 and 504 are hinted by the Retry-After if it's positive.
+// the 499 of the canceled requests is not written if the endpoint skips it, since the clients are gone.
+func writeError(w http.ResponseWriter, err error, e *config.Endpoint, retryAfter time.Duration) int {
 	var statusCode int
 	switch {
+	case errors.Is(err, errBodyReadTimeout):
+		statusCode = http.StatusRequestTimeout
 	case errors.Is(err, context.Canceled):
 		statusCode = 499
 	case errors.Is(err, context.DeadlineExceeded):
@@ -101,7 +283,19 @@ func writeError(w http.ResponseWriter, r *http.Request, err error, protocol conf
 	default:
 		statusCode = 502
 	}
-	_metricRequestsTotal.WithLabelValues(protocol.String(), r.Method, r.URL.Path, strconv.Itoa(statusCode), service, basePath).Inc()
+	if statusCode == 499 && e.SkipClientCancel {
+		log.Debugf("The client canceled the request, skip writing the 499: %v", err)
+		if recorder, ok := w.(*statusRecorder); ok {
+			recorder.skipStatus(statusCode)
+		}
+		return statusCode
+	}
+	setRetryAfter(w.Header(), statusCode, retryAfter)
+	writeErrorStatus(w, statusCode, err, e.Protocol)
+	return statusCode
+}
+
+func writeErrorStatus(w http.ResponseWriter, statusCode int, err error, protocol config.Protocol) {
 	if protocol == config.Protocol_GRPC {
 		// see https://github.com/googleapis/googleapis/blob/master/google/rpc/code.proto
 		code := strconv.Itoa(int(status.ToGRPCCode(statusCode)))
@@ -113,46 +307,115 @@ func writeError(w http.ResponseWriter, r *http.Request, err error, protocol conf
 	w.WriteHeader(statusCode)
 }
 
-// notFoundHandler replies to the request with an HTTP 404 not found error.
-func notFoundHandler(w http.ResponseWriter, r *http.Request) {
-	code := http.StatusNotFound
-	message := "404 page not found"
-	http.Error(w, message, code)
-	log.Context(r.Context()).Errorw(
-		"source", "accesslog",
-		"host", r.Host,
-		"method", r.Method,
-		"path", r.URL.Path,
-		"query", r.URL.RawQuery,
-		"user_agent", r.Header.Get("User-Agent"),
-		"code", code,
-		"error", message,
-	)
-	_metricRequestsTotal.WithLabelValues("HTTP", r.Method, "/404", strconv.Itoa(code), "", "").Inc()
+// setRetryAfter sets the Retry-After in seconds of the 502 and 504, see https://www.rfc-editor.org/rfc/rfc7231#section-7.1.3
+func setRetryAfter(header http.Header, statusCode int, retryAfter time.Duration) {
+	if retryAfter <= 0 || statusCode != http.StatusBadGateway && statusCode != http.StatusGatewayTimeout {
+		return
+	}
+	header.Set("Retry-After", strconv.FormatInt(int64((retryAfter+time.Second-1)/time.Second), 10))
+}
+
+// errRetriesExhausted is responded if all the attempts matched the retry conditions.
+var errRetriesExhausted = errors.New("retries exhausted")
+
+// retryCode returns the status code or the error class of the attempt which triggered a retry.
+func retryCode(resp *http.Response, err error) string {
+	switch {
+	case err == nil && resp != nil:
+		return strconv.Itoa(resp.StatusCode)
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "error"
+	}
 }
 
-func methodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
-	code := http.StatusMethodNotAllowed
-	message := http.StatusText(code)
+// setDeadlineRemainingHeader sets the remaining budget of the request timeout in milliseconds.
+func setDeadlineRemainingHeader(ctx context.Context, header http.Header) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+	remaining := time.Until(deadline).Milliseconds()
+	if remaining < 0 {
+		remaining = 0
+	}
+	header.Set("X-Gateway-Deadline-Remaining-Ms", strconv.FormatInt(remaining, 10))
+}
+
+// closeResponse closes the body of the response if any.
+func closeResponse(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		resp.Body.Close()
+	}
+}
+
+// notReadyHandler tells the load balancers that the gateway is starting rather than the route is not found.
+func notReadyHandler(w http.ResponseWriter, r *http.Request) {
+	code := http.StatusServiceUnavailable
+	message := "503 no config loaded yet"
+	w.Header().Set("Retry-After", "1")
 	http.Error(w, message, code)
-	log.Context(r.Context()).Errorw(
-		"source", "accesslog",
-		"host", r.Host,
-		"method", r.Method,
-		"path", r.URL.Path,
-		"query", r.URL.RawQuery,
-		"user_agent", r.Header.Get("User-Agent"),
-		"code", code,
-		"error", message,
-	)
-	_metricRequestsTotal.WithLabelValues("HTTP", r.Method, r.URL.Path, strconv.Itoa(code), "", "").Inc()
+	_metricRequestsTotal.WithLabelValues("HTTP", r.Method, "/503", strconv.Itoa(code), "", "").Inc()
+}
+
+// notFoundHandler replies to the request with an HTTP 404 not found error.
+func notFoundHandler(sanitizer *middleware.Sanitizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code := http.StatusNotFound
+		message := "404 page not found"
+		http.Error(w, message, code)
+		keyvals := []interface{}{
+			"source", "accesslog",
+			"host", r.Host,
+			"method", r.Method,
+			"path", sanitizer.Path(r.URL.Path),
+			"query", sanitizer.Query(r.URL.RawQuery),
+			"user_agent", r.Header.Get("User-Agent"),
+			"code", code,
+			"error", message,
+		}
+		log.Context(r.Context()).Errorw(append(keyvals, middleware.TraceFields(r)...)...)
+		_metricRequestsTotal.WithLabelValues("HTTP", r.Method, "/404", strconv.Itoa(code), "", "").Inc()
+	}
+}
+
+func methodNotAllowedHandler(sanitizer *middleware.Sanitizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		code := http.StatusMethodNotAllowed
+		message := http.StatusText(code)
+		http.Error(w, message, code)
+		path := sanitizer.Path(r.URL.Path)
+		keyvals := []interface{}{
+			"source", "accesslog",
+			"host", r.Host,
+			"method", r.Method,
+			"path", path,
+			"query", sanitizer.Query(r.URL.RawQuery),
+			"user_agent", r.Header.Get("User-Agent"),
+			"code", code,
+			"error", message,
+		}
+		log.Context(r.Context()).Errorw(append(keyvals, middleware.TraceFields(r)...)...)
+		_metricRequestsTotal.WithLabelValues("HTTP", r.Method, path, strconv.Itoa(code), "", "").Inc()
+	}
 }
 
 // Proxy is a gateway proxy.
 type Proxy struct {
+	ready             int32
 	router            atomic.Value
+	retryInspects     atomic.Value
+	endpointErrors    atomic.Value
 	clientFactory     client.Factory
 	middlewareFactory middleware.Factory
+	retries           *retrySemaphore
+	conns             *connLimiter
+	maintenance       *maintenance
+	routeCount        int64
+	maxURLLength      int64
 }
 
 // New is new a gateway proxy.
@@ -160,12 +423,66 @@ func New(clientFactory client.Factory, middlewareFactory middleware.Factory) (*P
 	p := &Proxy{
 		clientFactory:     clientFactory,
 		middlewareFactory: middlewareFactory,
+		retries:           &retrySemaphore{},
+		conns:             newConnLimiter(),
+		maintenance:       &maintenance{},
+		maxURLLength:      _defaultMaxURLLength,
 	}
-	p.router.Store(mux.NewRouter(http.HandlerFunc(notFoundHandler), http.HandlerFunc(methodNotAllowedHandler)))
+	p.router.Store(mux.NewRouter(notFoundHandler(nil), methodNotAllowedHandler(nil)))
 	return p, nil
 }
 
-func (p *Proxy) buildMiddleware(ms []*config.Middleware, next http.RoundTripper) (http.RoundTripper, error) {
+// _defaultEndpointPath is the path label of the requests handled by the default endpoint.
+const _defaultEndpointPath = "/*catchall"
+
+// _aggregatePathLabel is the shared path label of the endpoints aggregating the metrics.
+const _aggregatePathLabel = "other"
+
+// buildOptions is the gateway scoped options of building endpoints.
+type buildOptions struct {
+	sanitizer         *middleware.Sanitizer
+	middlewareMetrics bool
+	inboundMetrics    bool
+	// pathLabel is the path label of the metrics instead of the request path if set.
+	pathLabel string
+	// maxTimeout caps the request timeout of the endpoints if positive.
+	maxTimeout time.Duration
+	// retries collects the prepared retry strategies of the endpoints for the debug inspect.
+	retryInspects *[]*RetryInspect
+	// decisionTracer traces the decision path of the requests if set.
+	decisionTracer *decisionTracer
+	// recentErrors is the number of the recent errors kept for each endpoint if positive.
+	recentErrors int
+	// endpointErrors collects the error rings of the endpoints, prevErrors are the ones of the previous update.
+	endpointErrors *[]*endpointErrors
+	prevErrors     map[string]*endpointErrors
+}
+
+func newBuildOptions(c *config.Gateway) (*buildOptions, error) {
+	sanitizer, err := middleware.NewSanitizer(c.Sanitize)
+	if err != nil {
+		return nil, err
+	}
+	if c.MaxRequestTimeout != nil && c.MaxRequestTimeout.AsDuration() < 0 {
+		return nil, fmt.Errorf("invalid max request timeout: %s", c.MaxRequestTimeout.AsDuration())
+	}
+	decisionTracer, err := newDecisionTracer(c.DecisionTrace)
+	if err != nil {
+		return nil, err
+	}
+	return &buildOptions{
+		sanitizer:         sanitizer,
+		middlewareMetrics: c.MiddlewareMetrics,
+		inboundMetrics:    c.InboundMetrics,
+		maxTimeout:        c.MaxRequestTimeout.AsDuration(),
+		retryInspects:     &[]*RetryInspect{},
+		decisionTracer:    decisionTracer,
+		recentErrors:      int(c.RecentErrors),
+		endpointErrors:    &[]*endpointErrors{},
+	}, nil
+}
+
+func (p *Proxy) buildMiddleware(e *config.Endpoint, ms []*config.Middleware, next http.RoundTripper, opts *buildOptions) (http.RoundTripper, error) {
 	for i := len(ms) - 1; i >= 0; i-- {
 		m, err := p.middlewareFactory(ms[i])
 		if err != nil {
@@ -175,21 +492,58 @@ func (p *Proxy) buildMiddleware(ms []*config.Middleware, next http.RoundTripper)
 			}
 			return nil, err
 		}
+		if opts.decisionTracer != nil {
+			m = traceMiddleware(ms[i].Name, m)
+		}
+		if ms[i].When != nil {
+			match, err := middleware.NewMatcher(ms[i].When)
+			if err != nil {
+				return nil, err
+			}
+			m = middleware.Conditional(match, m)
+		}
+		if opts.middlewareMetrics && e.MetricsMode != config.MetricsMode_OFF {
+			path := e.Path
+			if e.MetricsMode == config.MetricsMode_AGGREGATE {
+				path = _aggregatePathLabel
+			}
+			next = instrumentMiddleware(_metricMiddlewareDuration.WithLabelValues(ms[i].Name, e.Method, path), m, next)
+			continue
+		}
 		next = m(next)
 	}
 	return next, nil
 }
 
-func (p *Proxy) buildEndpoint(e *config.Endpoint, ms []*config.Middleware) (http.Handler, error) {
-	tripper, err := p.clientFactory(e)
+func (p *Proxy) buildEndpoint(e *config.Endpoint, ms []*config.Middleware, opts *buildOptions) (http.Handler, error) {
+	var (
+		tripper http.RoundTripper
+		err     error
+	)
+	switch {
+	case e.Static != nil:
+		tripper, err = newStaticTripper(e)
+	case e.Aggregate != nil && (e.Split != nil || e.Failover != nil || len(e.Backends) > 0):
+		err = errors.New("aggregate is exclusive with split, failover and backends")
+	case e.Aggregate != nil:
+		tripper, err = newAggregateTripper(e, p.clientFactory)
+	case e.Split != nil && e.Failover != nil:
+		err = errors.New("split and failover are exclusive")
+	case e.Split != nil:
+		tripper, err = newSplitTripper(e, p.clientFactory)
+	case e.Failover != nil:
+		tripper, err = newFailoverTripper(e, p.clientFactory)
+	default:
+		tripper, err = p.clientFactory(e)
+	}
 	if err != nil {
 		return nil, err
 	}
-	tripper, err = p.buildMiddleware(e.Middlewares, tripper)
+	tripper, err = p.buildBypass(e, tripper, opts)
 	if err != nil {
 		return nil, err
 	}
-	tripper, err = p.buildMiddleware(ms, tripper)
+	tripper, err = p.buildMiddleware(e, ms, tripper, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -197,73 +551,405 @@ func (p *Proxy) buildEndpoint(e *config.Endpoint, ms []*config.Middleware) (http
 	if err != nil {
 		return nil, err
 	}
+	if opts.maxTimeout > 0 && retryStrategy.timeout > opts.maxTimeout {
+		log.Warnf("The timeout %s of endpoint [%s] %s %s exceeds the max request timeout, it's capped to %s",
+			retryStrategy.timeout, e.Protocol, e.Method, e.Path, opts.maxTimeout)
+		retryStrategy.timeout = opts.maxTimeout
+	}
+	if retryStrategy.attempts > 1 && !retryStrategy.nonIdempotent && e.Method != "" && !isIdempotentMethod(e.Method) {
+		log.Warnf("The retry of endpoint [%s] %s %s only applies to the requests with an Idempotency-Key, set retryNonIdempotent to retry all of them",
+			e.Protocol, e.Method, e.Path)
+	}
+	if retryStrategy.attempts > 1 && e.Protocol == config.Protocol_GRPC {
+		log.Warnf("The retry of gRPC endpoint %s %s buffers the request bodies to replay them, the client streaming RPCs are held until the client ends the stream",
+			e.Method, e.Path)
+	}
+	*opts.retryInspects = append(*opts.retryInspects, inspectRetryStrategy(e, retryStrategy))
+	recentErrors := opts.errorRing(e)
+	deadline, err := newClientDeadline(e.ClientDeadline)
+	if err != nil {
+		return nil, err
+	}
+	sanitizer := opts.sanitizer
+	responseHeaders := newHeaderFilter(e.ResponseHeaders)
+	via := viaPseudonym(e.Via)
 	protocol := e.Protocol.String()
 	service := e.Metadata["service"]
 	basePath := e.Metadata["basePath"]
 	return http.Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		startTime := time.Now()
-		setXFFHeader(req)
+		var timing *serverTiming
+		if e.ServerTiming {
+			timing = newServerTiming(startTime)
+		}
+		if e.OriginalUriHeader != "" {
+			setOriginalURIHeader(req, e.OriginalUriHeader)
+		}
+		setXFFHeader(req, e.XForwardedFor)
+		setUserAgentHeader(req, e.UserAgent)
+		if via != "" {
+			addVia(req.Header, req.ProtoMajor, req.ProtoMinor, via)
+		}
+		path := sanitizer.Path(req.URL.Path)
+		switch {
+		case e.MetricsMode == config.MetricsMode_AGGREGATE:
+			path = _aggregatePathLabel
+		case opts.pathLabel != "":
+			path = opts.pathLabel
+		}
+		// the path-labeled metrics of the endpoint are not recorded if the metrics are off
+		record := e.MetricsMode != config.MetricsMode_OFF
 
-		ctx := middleware.NewRequestContext(req.Context(), middleware.NewRequestOptions(e))
-		ctx, cancel := context.WithTimeout(ctx, retryStrategy.timeout)
+		reqOpt := middleware.NewRequestOptions(e)
+		reqOpt.Sanitizer = sanitizer
+		ctx := middleware.NewRequestContext(req.Context(), reqOpt)
+		var decision *decisionTrace
+		if tracer := opts.decisionTracer; tracer != nil && tracer.enabled(req) {
+			decision = newDecisionTrace(startTime)
+			decision.add("route", routeDecision(e))
+			ctx = newDecisionContext(ctx, decision)
+			w = &decisionWriter{ResponseWriter: w, trace: decision, header: tracer.responseHeader}
+		}
+		if e.Split != nil && record {
+			defer func() {
+				if variant := reqOpt.Metadata[_variantMetadata]; variant != "" {
+					_metricSplitRequests.WithLabelValues(protocol, req.Method, path, variant, service, basePath).Inc()
+				}
+			}()
+		}
+		if e.Failover != nil && record {
+			defer func() {
+				if pool := reqOpt.Metadata[_poolMetadata]; pool != "" {
+					_metricFailoverRequests.WithLabelValues(protocol, req.Method, path, pool, service, basePath).Inc()
+				}
+			}()
+		}
+		timeout, expired := retryStrategy.timeout, false
+		if deadline != nil {
+			if remaining, ok := deadline.remaining(req, startTime); ok {
+				expired = remaining <= 0
+				if remaining < timeout {
+					timeout = remaining
+				}
+			}
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
-		defer func() {
-			_metricRequestsDuration.WithLabelValues(protocol, req.Method, req.URL.Path, service, basePath).Observe(time.Since(startTime).Seconds())
-		}()
-
-		body, err := io.ReadAll(req.Body)
-		if err != nil {
-			writeError(w, req, err, e.Protocol, service, basePath)
+		if record {
+			defer func() {
+				observeWithTrace(_metricRequestsDuration.WithLabelValues(protocol, req.Method, path, service, basePath), time.Since(startTime).Seconds(), req.WithContext(ctx))
+			}()
+		}
+		if opts.inboundMetrics && record {
+			recorder := &statusRecorder{ResponseWriter: w}
+			w = recorder
+			defer func() {
+				scheme, listener := inboundLabels(req)
+				_metricInboundRequestsTotal.WithLabelValues(protocol, req.Method, path, recorder.code(), scheme, listener).Inc()
+			}()
+		}
+		if expired {
+			// the client has given up already, so the upstream is not called
+			code := writeError(w, context.DeadlineExceeded, e, retryStrategy.retryAfterHint(nil))
+			recentErrors.add(code, 0, context.DeadlineExceeded, sanitizer.Path(req.URL.Path))
+			if record {
+				_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, strconv.Itoa(code), service, basePath).Inc()
+			}
 			return
 		}
-		_metricReceivedBytes.WithLabelValues(protocol, req.Method, req.URL.Path, service, basePath).Add(float64(len(body)))
-		req.GetBody = func() (io.ReadCloser, error) {
-			reader := bytes.NewReader(body)
-			return ioutil.NopCloser(reader), nil
+		if e.StrictProtocol {
+			if code, reason := checkProtocol(e.Protocol, req); code != 0 {
+				http.Error(w, reason, code)
+				if record {
+					_metricProtocolMismatch.WithLabelValues(protocol, req.Method, path, service, basePath).Inc()
+					_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, strconv.Itoa(code), service, basePath).Inc()
+				}
+				return
+			}
 		}
 
-		var resp *http.Response
-		for i := 0; i < retryStrategy.attempts; i++ {
+		var (
+			body      *bufferedBody
+			deferred  *deferredBody
+			err       error
+			attempts  = retryStrategy.attempts
+			streaming = isGRPCStreaming(e.Protocol, retryStrategy.attempts, req)
+		)
+		if streaming || !retryStrategy.nonIdempotent && !isIdempotentRequest(req) {
+			// the streaming body can not be replayed, so retries are disabled.
+			attempts = 1
+		}
+		// the body is buffered only if it may be replayed by the retries or the sub requests
+		buffered := attempts > 1 || e.Aggregate != nil
+		if streaming || !buffered {
+			if req.Body != nil && req.Body != http.NoBody && record {
+				received := _metricReceivedBytes.WithLabelValues(protocol, req.Method, path, service, basePath)
+				upstreamSent := _metricUpstreamSentBytes.WithLabelValues(protocol, req.Method, path, service, basePath)
+				req.Body = &countedBody{ReadCloser: req.Body, onRead: func(size int64) {
+					received.Add(float64(size))
+					upstreamSent.Add(float64(size))
+				}}
+			}
+			req.GetBody = nil
+		} else if e.DeferExpectContinue && expectsContinue(req) {
+			inbound := req.Body
+			deferred = &deferredBody{load: func() (*bufferedBody, error) {
+				b, err := readBody(ctx, inbound, e.BodyFileThreshold)
+				if err != nil {
+					return nil, err
+				}
+				// it's read by the attempt sending it to the upstream
+				if record {
+					_metricReceivedBytes.WithLabelValues(protocol, req.Method, path, service, basePath).Add(float64(b.size))
+					_metricUpstreamSentBytes.WithLabelValues(protocol, req.Method, path, service, basePath).Add(float64(b.size))
+				}
+				return b, nil
+			}}
+			defer deferred.Close()
+			req.GetBody = func() (io.ReadCloser, error) {
+				return deferred.newReader(), nil
+			}
+		} else {
+			body, err = readBody(ctx, req.Body, e.BodyFileThreshold)
+			if err != nil {
+				code := writeError(w, err, e, retryStrategy.retryAfterHint(nil))
+				recentErrors.add(code, 0, err, sanitizer.Path(req.URL.Path))
+				if record {
+					_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, strconv.Itoa(code), service, basePath).Inc()
+				}
+				return
+			}
+			defer body.Close()
+			if record {
+				_metricReceivedBytes.WithLabelValues(protocol, req.Method, path, service, basePath).Add(float64(body.size))
+			}
+			req.GetBody = func() (io.ReadCloser, error) {
+				return body.newReader(), nil
+			}
+		}
+
+		var (
+			resp    *http.Response
+			backoff backoff
+			// correlation is the gateway-internal id of the request correlating the attempts.
+			correlation   string
+			correlationID = func() string {
+				if correlation == "" {
+					correlation = uuid.NewString()
+				}
+				return correlation
+			}
+			// exhausted is whether all the attempts matched the retry conditions.
+			exhausted bool
+			// outcomes are logged together if the request failed after the retries.
+			outcomes = make([]attemptOutcome, 0, attempts)
+		)
+		if retryStrategy.newBackoff != nil && attempts > 1 {
+			backoff = retryStrategy.newBackoff()
+		}
+		for i := 0; i < attempts; i++ {
+			if i > 0 && backoff != nil {
+				if sleepErr := sleepContext(ctx, backoff.next()); sleepErr != nil {
+					closeResponse(resp)
+					resp, err = nil, sleepErr
+					break
+				}
+			}
 			if i > 0 {
-				_metricRetryTotal.WithLabelValues(protocol, req.Method, req.URL.Path, service, basePath).Inc()
+				if !p.retries.acquire() {
+					// keep the response or error of the last attempt
+					if record {
+						_metricRetrySkipped.WithLabelValues(protocol, req.Method, path, service, basePath).Inc()
+					}
+					break
+				}
+				if record {
+					_metricRetryTotal.WithLabelValues(protocol, req.Method, path, service, basePath).Inc()
+					_metricRetryByCode.WithLabelValues(protocol, req.Method, path, retryCode(resp, err), service, basePath).Inc()
+				}
 			}
 			// canceled or deadline exceeded
 			if err = ctx.Err(); err != nil {
+				if i > 0 {
+					p.retries.release()
+				}
+				closeResponse(resp)
+				resp = nil
 				break
 			}
 			tryCtx, cancel := context.WithTimeout(ctx, retryStrategy.perTryTimeout)
 			defer cancel()
-			reader := bytes.NewReader(body)
-			req.Body = ioutil.NopCloser(reader)
+			sent := headerSize(req.Header)
+			switch {
+			case deferred != nil:
+				if b := deferred.loaded(); b != nil {
+					sent += b.size
+				}
+				req.Body = deferred.newReader()
+			case body != nil:
+				req.Body = body.newReader()
+				sent += body.size
+			}
+			if record {
+				_metricUpstreamSentBytes.WithLabelValues(protocol, req.Method, path, service, basePath).Add(float64(sent))
+			}
+			if e.CorrelationHeader != "" {
+				req.Header.Set(e.CorrelationHeader, correlationID()+"."+strconv.Itoa(i+1))
+			}
+			if timing != nil {
+				timing.begin(time.Now())
+			}
+			// the response superseded by the retry releases its connection before the next attempt
+			closeResponse(resp)
+			backends, attemptStart := len(reqOpt.Backends), time.Now()
 			resp, err = tripper.RoundTrip(req.Clone(tryCtx))
+			if timing != nil {
+				timing.end(time.Now())
+			}
+			var backend string
+			if len(reqOpt.Backends) > backends {
+				backend = reqOpt.Backends[len(reqOpt.Backends)-1]
+			}
+			outcomes = append(outcomes, newAttemptOutcome(resp, err, time.Since(attemptStart), backend))
+			if decision != nil {
+				decision.add("attempt", outcomes[len(outcomes)-1].String())
+			}
+			if i > 0 {
+				p.retries.release()
+			}
 			if err != nil {
-				log.Errorf("Attempt at [%d/%d], failed to handle request: %s: %+v", i+1, retryStrategy.attempts, req.URL.String(), err)
+				switch {
+				case isResponseHeaderTooLarge(err):
+					log.Errorf("Attempt at [%d/%d], upstream_header_too_large: correlation=%s %s: %+v", i+1, attempts, correlationID(), sanitizer.Path(req.URL.Path), unwrapURLError(err))
+					if record {
+						_metricUpstreamHeaderTooLarge.WithLabelValues(protocol, req.Method, path, service, basePath).Inc()
+					}
+				case isUpstreamProtocolError(err):
+					log.Errorf("Attempt at [%d/%d], upstream_protocol_error: correlation=%s %s: %+v", i+1, attempts, correlationID(), sanitizer.Path(req.URL.Path), unwrapURLError(err))
+					if record {
+						_metricUpstreamProtocolErrors.WithLabelValues(protocol, req.Method, path, service, basePath).Inc()
+					}
+				default:
+					log.Errorf("Attempt at [%d/%d], failed to handle request: correlation=%s %s: %+v", i+1, attempts, correlationID(), sanitizer.Path(req.URL.Path), unwrapURLError(err))
+				}
+				recentErrors.add(0, i+1, err, sanitizer.Path(req.URL.Path))
 				continue
 			}
+			if record {
+				_metricUpstreamReceivedHeaderBytes.WithLabelValues(protocol, req.Method, path, service, basePath).Add(float64(headerSize(resp.Header)))
+			}
 			if !judgeRetryRequired(retryStrategy.conditions, resp) {
-				if i > 0 {
-					_metricRetrySuccess.WithLabelValues(protocol, req.Method, req.URL.Path, service, basePath).Inc()
+				if i > 0 && record {
+					_metricRetrySuccess.WithLabelValues(protocol, req.Method, path, service, basePath).Inc()
 				}
 				break
 			}
+			exhausted = attempts > 1 && i == attempts-1
 			// continue the retry loop
 		}
+		if len(outcomes) > 1 && (err != nil || exhausted) {
+			log.Context(ctx).Errorw(
+				"msg", "all attempts failed",
+				"method", req.Method,
+				"path", sanitizer.Path(req.URL.Path),
+				"correlation", correlationID(),
+				"attempts", outcomes,
+			)
+		}
 		if err != nil {
-			writeError(w, req, err, e.Protocol, service, basePath)
+			code := writeError(w, err, e, retryStrategy.retryAfterHint(backoff))
+			recentErrors.add(code, 0, err, sanitizer.Path(req.URL.Path))
+			if record {
+				_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, strconv.Itoa(code), service, basePath).Inc()
+			}
 			return
 		}
+		if exhausted {
+			if record {
+				_metricRetryExhausted.WithLabelValues(protocol, req.Method, path, service, basePath).Inc()
+			}
+			if retryStrategy.exhaustedHeader != "" {
+				w.Header().Set(retryStrategy.exhaustedHeader, strconv.Itoa(attempts))
+			}
+			if code := retryStrategy.exhaustedStatusCode; code != 0 {
+				resp.Body.Close()
+				setRetryAfter(w.Header(), code, retryStrategy.retryAfterHint(backoff))
+				writeErrorStatus(w, code, errRetriesExhausted, e.Protocol)
+				recentErrors.add(code, 0, errRetriesExhausted, sanitizer.Path(req.URL.Path))
+				if record {
+					_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, strconv.Itoa(code), service, basePath).Inc()
+				}
+				return
+			}
+		}
 
+		if resp.StatusCode == http.StatusSwitchingProtocols {
+			header := http.Header{}
+			copyResponseHeader(header, resp.Header, responseHeaders)
+			reason, err := serveTunnel(w, resp, header, e.TunnelIdleTimeout.AsDuration())
+			if err != nil {
+				resp.Body.Close()
+				log.Errorf("Failed to serve the upgraded connection: %s: %+v", sanitizer.Path(req.URL.Path), unwrapURLError(err))
+				code := writeError(w, err, e, 0)
+				recentErrors.add(code, 0, err, sanitizer.Path(req.URL.Path))
+				if record {
+					_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, strconv.Itoa(code), service, basePath).Inc()
+				}
+				return
+			}
+			if record {
+				_metricTunnelsClosed.WithLabelValues(protocol, req.Method, path, reason, service, basePath).Inc()
+				_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, strconv.Itoa(resp.StatusCode), service, basePath).Inc()
+			}
+			return
+		}
+		if e.GrpcHttpStatus && e.Protocol == config.Protocol_GRPC && !isGRPCNative(req) {
+			mapGRPCStatus(resp)
+		}
 		headers := w.Header()
-		for k, v := range resp.Header {
-			headers[k] = v
+		copyResponseHeader(headers, resp.Header, responseHeaders)
+		if via != "" {
+			addVia(headers, resp.ProtoMajor, resp.ProtoMinor, via)
+		}
+		if e.EchoDeadlineRemaining {
+			setDeadlineRemainingHeader(ctx, headers)
+		}
+		if timing != nil {
+			addServerTiming(headers, timing)
 		}
 		w.WriteHeader(resp.StatusCode)
-		if body := resp.Body; body != nil {
-			sent, err := io.Copy(w, body)
+		// the responses of HEAD have no body, only the headers including Content-Length are forwarded
+		if body := resp.Body; body != nil && req.Method != http.MethodHead {
+			var reader io.Reader = body
+			guard := newRateGuard(body, e.MinResponseRate)
+			if guard != nil {
+				reader = guard
+			}
+			sent, err := io.Copy(w, reader)
+			if guard != nil {
+				guard.stop()
+			}
 			if err != nil {
 				log.Errorf("Failed to copy backend response body to client: [%s] %s %s %+v\n", e.Protocol, e.Method, e.Path, err)
 			}
-			_metricSentBytes.WithLabelValues(protocol, req.Method, req.URL.Path, service, basePath).Add(float64(sent))
+			if record {
+				_metricSentBytes.WithLabelValues(protocol, req.Method, path, service, basePath).Add(float64(sent))
+			}
+			if guard != nil && guard.isAborted() {
+				if record {
+					_metricSlowUpstreamAborts.WithLabelValues(protocol, req.Method, path, service, basePath).Inc()
+					_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, "504", service, basePath).Inc()
+				}
+				body.Close()
+				// the status has been sent, so the response is aborted to let the client see it's truncated
+				panic(http.ErrAbortHandler)
+			}
+			if e.ReportEmptyResponse && sent == 0 && err == nil && condition.BodyAllowed(resp.StatusCode) {
+				log.Warnf("Empty backend response body: [%s] %s %s %d", e.Protocol, e.Method, e.Path, resp.StatusCode)
+				if record {
+					_metricEmptyResponse.WithLabelValues(protocol, req.Method, path, strconv.Itoa(resp.StatusCode), service, basePath).Inc()
+				}
+			}
 		}
 		// see https://pkg.go.dev/net/http#example-ResponseWriter-Trailers
 		for k, v := range resp.Trailer {
@@ -272,36 +958,168 @@ func (p *Proxy) buildEndpoint(e *config.Endpoint, ms []*config.Middleware) (http
 		if resp.Body != nil {
 			resp.Body.Close()
 		}
-		_metricRequestsTotal.WithLabelValues(protocol, req.Method, req.URL.Path, "200", service, basePath).Inc()
+		if record {
+			_metricRequestsTotal.WithLabelValues(protocol, req.Method, path, strconv.Itoa(resp.StatusCode), service, basePath).Inc()
+		}
 	})), nil
 }
 
+func routerOptions(c *config.Gateway) []mux.Option {
+	var opts []mux.Option
+	switch c.TrailingSlash {
+	case config.TrailingSlash_STRICT:
+		opts = append(opts, mux.WithTrailingSlash(mux.TrailingSlashStrict))
+	case config.TrailingSlash_MERGE:
+		opts = append(opts, mux.WithTrailingSlash(mux.TrailingSlashMerge))
+	}
+	if c.HeadAsGet {
+		opts = append(opts, mux.WithHeadAsGet())
+	}
+	switch c.PathCase {
+	case config.PathCase_INSENSITIVE:
+		opts = append(opts, mux.WithPathCase(mux.PathCaseInsensitive))
+	case config.PathCase_CANONICAL:
+		opts = append(opts, mux.WithPathCase(mux.PathCaseCanonical))
+	}
+	return opts
+}
+
+func routeOptions(e *config.Endpoint) []router.RouteOption {
+	opts := make([]router.RouteOption, 0, len(e.Queries))
+	for _, q := range e.Queries {
+		opts = append(opts, router.WithQuery(q.Name, q.GetValue()))
+	}
+	return opts
+}
+
+// endpointRouteOptions returns the route options of the endpoint itself, the aliases don't match the gRPC route.
+func endpointRouteOptions(e *config.Endpoint) []router.RouteOption {
+	opts := routeOptions(e)
+	if grpcRouteLevel(e) > 0 {
+		opts = append(opts, router.WithGRPC(e.Grpc.Service, e.Grpc.Method))
+	}
+	return opts
+}
+
+// sortEndpoints returns the endpoints in matching precedence,
+// the endpoints with more query matchers take precedence,
+// then the gRPC method-level routes take precedence over the service-level ones,
+// otherwise they are matched in the configured order.
+func sortEndpoints(in []*config.Endpoint) []*config.Endpoint {
+	out := make([]*config.Endpoint, len(in))
+	copy(out, in)
+	sort.SliceStable(out, func(i, j int) bool {
+		if len(out[i].Queries) != len(out[j].Queries) {
+			return len(out[i].Queries) > len(out[j].Queries)
+		}
+		return grpcRouteLevel(out[i]) > grpcRouteLevel(out[j])
+	})
+	return out
+}
+
+// grpcRouteLevel returns 2 for the method-level gRPC routes, 1 for the service-level ones, otherwise 0.
+func grpcRouteLevel(e *config.Endpoint) int {
+	switch {
+	case e.Grpc == nil || e.Protocol != config.Protocol_GRPC:
+		return 0
+	case e.Grpc.Method != "":
+		return 2
+	default:
+		return 1
+	}
+}
+
 // Update updates service endpoint.
 func (p *Proxy) Update(c *config.Gateway) error {
-	router := mux.NewRouter(http.HandlerFunc(notFoundHandler), http.HandlerFunc(methodNotAllowedHandler))
-	for _, e := range c.Endpoints {
-		handler, err := p.buildEndpoint(e, c.Middlewares)
+	if c.MaxEndpoints > 0 && len(c.Endpoints) > int(c.MaxEndpoints) {
+		return fmt.Errorf("too many endpoints: %d exceeds the limit %d", len(c.Endpoints), c.MaxEndpoints)
+	}
+	opts, err := newBuildOptions(c)
+	if err != nil {
+		return err
+	}
+	prevErrors, _ := p.endpointErrors.Load().([]*endpointErrors)
+	opts.prevErrors = make(map[string]*endpointErrors, len(prevErrors))
+	for _, errs := range prevErrors {
+		opts.prevErrors[errs.key()] = errs
+	}
+	if err := validateServiceDefaults(c); err != nil {
+		return err
+	}
+	var routeCount int64
+	var notFound http.Handler = notFoundHandler(opts.sanitizer)
+	if c.DefaultEndpoint != nil {
+		defaultOpts := *opts
+		defaultOpts.pathLabel = _defaultEndpointPath
+		if notFound, err = p.buildEndpoint(inheritServiceDefaults(c.DefaultEndpoint, c.ServiceDefaults), c.Middlewares, &defaultOpts); err != nil {
+			return err
+		}
+		log.Infof("build default endpoint: [%s]", c.DefaultEndpoint.Protocol)
+	}
+	router := mux.NewRouter(notFound, methodNotAllowedHandler(opts.sanitizer), routerOptions(c)...)
+	for _, e := range sortEndpoints(c.Endpoints) {
+		e = inheritServiceDefaults(e, c.ServiceDefaults)
+		handler, err := p.buildEndpoint(e, c.Middlewares, opts)
 		if err != nil {
 			return err
 		}
-		if err = router.Handle(e.Path, e.Method, handler); err != nil {
+		if err = router.Handle(e.Path, e.Method, handler, endpointRouteOptions(e)...); err != nil {
 			return err
 		}
+		routeCount++
 		log.Infof("build endpoint: [%s] %s %s", e.Protocol, e.Method, e.Path)
+		for _, alias := range e.Aliases {
+			method := alias.Method
+			if method == "" {
+				method = e.Method
+			}
+			if err = router.Handle(alias.Path, method, handler, routeOptions(e)...); err != nil {
+				return err
+			}
+			routeCount++
+			log.Infof("build endpoint alias: [%s] %s %s -> %s %s", e.Protocol, method, alias.Path, e.Method, e.Path)
+		}
 	}
 	p.router.Store(router)
+	p.retryInspects.Store(*opts.retryInspects)
+	p.endpointErrors.Store(*opts.endpointErrors)
+	atomic.StoreInt64(&p.routeCount, routeCount)
+	_metricRouteCount.Set(float64(routeCount))
+	p.retries.setLimit(c.MaxConcurrentRetries)
+	p.conns.setLimit(c.ConnectionLimit)
+	maxURLLength := int64(_defaultMaxURLLength)
+	if c.MaxUrlLength > 0 {
+		maxURLLength = int64(c.MaxUrlLength)
+	}
+	atomic.StoreInt64(&p.maxURLLength, maxURLLength)
+	p.maintenance.update(c.Maintenance)
+	atomic.StoreInt32(&p.ready, 1)
 	return nil
 }
 
 func (p *Proxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	defer func() {
 		if err := recover(); err != nil {
+			if err == http.ErrAbortHandler {
+				panic(err)
+			}
 			w.WriteHeader(http.StatusBadGateway)
 			buf := make([]byte, 64<<10) //nolint:gomnd
 			n := runtime.Stack(buf, false)
 			log.Errorf("panic recovered: %s", buf[:n])
 		}
 	}()
+	if code, reason := checkURL(req, int(atomic.LoadInt64(&p.maxURLLength))); code != 0 {
+		rejectURL(w, code, reason)
+		return
+	}
+	if !p.Ready() {
+		notReadyHandler(w, req)
+		return
+	}
+	if p.maintenance.serve(w, req) {
+		return
+	}
 	p.router.Load().(router.Router).ServeHTTP(w, req)
 }
 
@@ -313,9 +1131,60 @@ func (p *Proxy) DebugHandler() http.Handler {
 		if !ok {
 			return
 		}
-		inspect := mux.InspectMuxRouter(router)
+		inspect := struct {
+			RouteCount int64                `json:"route_count"`
+			Routes     []*mux.RouterInspect `json:"routes"`
+		}{
+			RouteCount: atomic.LoadInt64(&p.routeCount),
+			Routes:     mux.InspectMuxRouter(router),
+		}
 		rw.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(rw).Encode(inspect)
 	})
+	debugMux.HandleFunc("/debug/proxy/retry/inspect", func(rw http.ResponseWriter, r *http.Request) {
+		inspects, _ := p.retryInspects.Load().([]*RetryInspect)
+		if inspects == nil {
+			inspects = []*RetryInspect{}
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(struct {
+			Endpoints []*RetryInspect `json:"endpoints"`
+		}{Endpoints: inspects})
+	})
+	debugMux.HandleFunc("/debug/proxy/errors", func(rw http.ResponseWriter, r *http.Request) {
+		errs, _ := p.endpointErrors.Load().([]*endpointErrors)
+		endpoints := make([]*EndpointErrors, 0, len(errs))
+		for _, e := range errs {
+			endpoints = append(endpoints, &EndpointErrors{Protocol: e.protocol, Method: e.method, Path: e.path, Errors: e.ring.list()})
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(struct {
+			Endpoints []*EndpointErrors `json:"endpoints"`
+		}{Endpoints: endpoints})
+	})
+	// POST ?enabled=true|false overrides the maintenance of the config, DELETE clears the override.
+	debugMux.HandleFunc("/debug/proxy/maintenance", func(rw http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			enabled, err := strconv.ParseBool(r.URL.Query().Get("enabled"))
+			if err != nil {
+				http.Error(rw, "invalid enabled: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			p.maintenance.setOverride(&enabled)
+		case http.MethodDelete:
+			p.maintenance.setOverride(nil)
+		default:
+			http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		enabled, override := p.maintenance.state()
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(struct {
+			Enabled  bool  `json:"enabled"`
+			Override *bool `json:"override,omitempty"`
+		}{Enabled: enabled, Override: override})
+	})
 	return debugMux
 }
@@ -2,14 +2,23 @@ package proxy
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
 	"github.com/go-kratos/gateway/middleware"
 	"github.com/go-kratos/gateway/middleware/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type responseWriter struct {
@@ -49,7 +58,8 @@ func TestProxy(t *testing.T) {
 			Path:     "/retryable",
 			Method:   "POST",
 			Retry: &config.Retry{
-				Attempts: 3,
+				Attempts:           3,
+				RetryNonIdempotent: true,
 				Conditions: []*config.Condition{{
 					Condition: &config.Condition_ByStatusCode{
 						ByStatusCode: "500-504",
@@ -124,3 +134,614 @@ func TestProxy(t *testing.T) {
 		}
 	}
 }
+
+func TestSetXFFHeader(t *testing.T) {
+	tests := []struct {
+		policy config.XForwardedFor
+		prior  []string
+		want   []string
+	}{
+		{policy: config.XForwardedFor_APPEND, want: []string{"10.0.0.1"}},
+		{policy: config.XForwardedFor_APPEND, prior: []string{"1.1.1.1"}, want: []string{"1.1.1.1, 10.0.0.1"}},
+		{policy: config.XForwardedFor_OVERWRITE, prior: []string{"1.1.1.1"}, want: []string{"10.0.0.1"}},
+		{policy: config.XForwardedFor_CLEAR, prior: []string{"1.1.1.1"}},
+		{policy: config.XForwardedFor_OMIT, prior: []string{"1.1.1.1"}},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = "10.0.0.1:1234"
+		if test.prior != nil {
+			r.Header["X-Forwarded-For"] = test.prior
+		}
+		setXFFHeader(r, test.policy)
+		if got := r.Header["X-Forwarded-For"]; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: want %v but got %v", test.policy, test.want, got)
+		}
+	}
+}
+
+type slowReader struct {
+	done chan struct{}
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	<-r.done
+	return 0, io.EOF
+}
+
+func TestSetUserAgentHeader(t *testing.T) {
+	tests := []struct {
+		ua    *config.UserAgent
+		prior string
+		want  string
+	}{
+		{prior: "curl/7.79", want: "curl/7.79"},
+		{ua: &config.UserAgent{Policy: &config.UserAgent_Set{Set: "gateway"}}, prior: "curl/7.79", want: "gateway"},
+		{ua: &config.UserAgent{Policy: &config.UserAgent_Suffix{Suffix: "gateway/1.0"}}, prior: "curl/7.79", want: "curl/7.79 gateway/1.0"},
+		{ua: &config.UserAgent{Policy: &config.UserAgent_Suffix{Suffix: "gateway/1.0"}}, want: "gateway/1.0"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		if test.prior != "" {
+			r.Header.Set("User-Agent", test.prior)
+		}
+		setUserAgentHeader(r, test.ua)
+		if got := r.Header.Get("User-Agent"); got != test.want {
+			t.Errorf("%v: want %q but got %q", test.ua, test.want, got)
+		}
+	}
+}
+
+func TestRetryCode(t *testing.T) {
+	tests := []struct {
+		resp *http.Response
+		err  error
+		want string
+	}{
+		{resp: &http.Response{StatusCode: 503}, want: "503"},
+		{resp: &http.Response{StatusCode: 503}, err: fmt.Errorf("dial: %w", context.DeadlineExceeded), want: "timeout"},
+		{err: context.Canceled, want: "canceled"},
+		{err: errors.New("connection refused"), want: "error"},
+	}
+	for _, test := range tests {
+		if got := retryCode(test.resp, test.err); got != test.want {
+			t.Errorf("%v: want %q but got %q", test.err, test.want, got)
+		}
+	}
+}
+
+func TestSlowRequestBody(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol: config.Protocol_HTTP,
+		Path:     "/upload",
+		Method:   "POST",
+		Timeout:  durationpb.New(50 * time.Millisecond),
+		// the body is buffered for the retries
+		Retry: &config.Retry{Attempts: 2, RetryNonIdempotent: true},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	body := &slowReader{done: make(chan struct{})}
+	defer close(body.done)
+	w := httptest.NewRecorder()
+	startTime := time.Now()
+	p.ServeHTTP(w, httptest.NewRequest("POST", "/upload", body))
+	if w.Code != http.StatusRequestTimeout {
+		t.Fatalf("want %d but got %d", http.StatusRequestTimeout, w.Code)
+	}
+	if elapsed := time.Since(startTime); elapsed > time.Second {
+		t.Fatalf("want the body read bounded by the timeout, elapsed: %v", elapsed)
+	}
+}
+
+func TestStreamedRequestBody(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.GetBody != nil {
+				return nil, errors.New("want the body streamed")
+			}
+			b, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(b))}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol: config.Protocol_HTTP,
+		Path:     "/upload",
+		Method:   "POST",
+		// the non-idempotent request is not retried
+		Retry: &config.Retry{Attempts: 3},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("POST", "/upload", strings.NewReader("hello")))
+	if w.Code != http.StatusOK {
+		t.Fatalf("want %d but got %d", http.StatusOK, w.Code)
+	}
+	if got := w.Body.String(); got != "hello" {
+		t.Fatalf("want %q but got %q", "hello", got)
+	}
+}
+
+func TestEndpointAliases(t *testing.T) {
+	builds := 0
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		builds++
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol: config.Protocol_HTTP,
+		Path:     "/v2/users",
+		Method:   "GET",
+		Aliases: []*config.Alias{
+			{Path: "/v1/users"},
+			{Path: "/users", Method: "HEAD"},
+		},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	if builds != 1 {
+		t.Fatalf("want the handler built once, got: %d", builds)
+	}
+	tests := []struct {
+		method string
+		path   string
+		want   int
+	}{
+		{method: "GET", path: "/v2/users", want: http.StatusOK},
+		{method: "GET", path: "/v1/users", want: http.StatusOK},
+		{method: "HEAD", path: "/users", want: http.StatusOK},
+		{method: "GET", path: "/users", want: http.StatusMethodNotAllowed},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+		if w.Code != test.want {
+			t.Errorf("%s %s: want %d but got %d", test.method, test.path, test.want, w.Code)
+		}
+	}
+}
+
+func TestMaxEndpoints(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return http.DefaultTransport, nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &config.Gateway{
+		MaxEndpoints: 1,
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Path:     "/v1/users",
+			Aliases:  []*config.Alias{{Path: "/users"}},
+		}},
+	}
+	if err := p.Update(c); err != nil {
+		t.Fatal(err)
+	}
+	if p.routeCount != 2 {
+		t.Fatalf("want 2 routes including the alias, got: %d", p.routeCount)
+	}
+	c.Endpoints = append(c.Endpoints, &config.Endpoint{Protocol: config.Protocol_HTTP, Path: "/v1/orders"})
+	if err := p.Update(c); err == nil {
+		t.Fatal("want the update rejected by the limit")
+	}
+	if p.routeCount != 2 {
+		t.Fatalf("want the routes kept, got: %d", p.routeCount)
+	}
+}
+
+func TestSortGRPCEndpoints(t *testing.T) {
+	in := []*config.Endpoint{
+		{Path: "/*", Protocol: config.Protocol_GRPC},
+		{Protocol: config.Protocol_GRPC, Grpc: &config.GRPCRoute{Service: "helloworld.Greeter"}},
+		{Protocol: config.Protocol_GRPC, Grpc: &config.GRPCRoute{Service: "helloworld.Greeter", Method: "SayHello"}},
+	}
+	out := sortEndpoints(in)
+	if out[0] != in[2] || out[1] != in[1] || out[2] != in[0] {
+		t.Fatalf("want the method-level route first, got: %v", out)
+	}
+}
+
+func TestDefaultEndpoint(t *testing.T) {
+	p, err := New(func(e *config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			header.Set("X-Backend", e.Backends[0].Target)
+			return &http.Response{StatusCode: http.StatusOK, Header: header}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Path:     "/v2/users",
+			Backends: []*config.Backend{{Target: "users"}},
+		}},
+		DefaultEndpoint: &config.Endpoint{
+			Protocol: config.Protocol_HTTP,
+			Backends: []*config.Backend{{Target: "monolith"}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for path, want := range map[string]string{"/v2/users": "users", "/v1/orders": "monolith"} {
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		if got := w.Header().Get("X-Backend"); w.Code != http.StatusOK || got != want {
+			t.Errorf("%s: want backend %q but got %d %q", path, want, w.Code, got)
+		}
+	}
+}
+
+type countingReader struct {
+	io.Reader
+	reads int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.reads++
+	return r.Reader.Read(p)
+}
+
+func TestDeferExpectContinue(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Authorization") == "" {
+				return &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}}, nil
+			}
+			b, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(b))}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol:            config.Protocol_HTTP,
+		Path:                "/upload",
+		Method:              "POST",
+		DeferExpectContinue: true,
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	do := func(authorization string) (*httptest.ResponseRecorder, *countingReader) {
+		body := &countingReader{Reader: bytes.NewReader([]byte("hello"))}
+		req := httptest.NewRequest("POST", "/upload", body)
+		req.Header.Set("Expect", "100-continue")
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, req)
+		return w, body
+	}
+	w, body := do("")
+	if w.Code != http.StatusUnauthorized || body.reads != 0 {
+		t.Fatalf("want 401 without reading the body, got: %d %d", w.Code, body.reads)
+	}
+	w, body = do("Bearer token")
+	if w.Code != http.StatusOK || w.Body.String() != "hello" || body.reads == 0 {
+		t.Fatalf("want the body forwarded, got: %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestSetOriginalURIHeader(t *testing.T) {
+	r := httptest.NewRequest("GET", "/v1/users/a%2Fb?sig=x%20y", nil)
+	r.Header.Set("X-Original-URI", "/spoofed")
+	setOriginalURIHeader(r, "X-Original-URI")
+	if got := r.Header.Get("X-Original-URI"); got != "/v1/users/a%2Fb?sig=x%20y" {
+		t.Fatalf("want the verbatim request URI, got: %q", got)
+	}
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	var calls int32
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&calls, 1)
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retry := &config.Retry{
+		Attempts:   3,
+		Conditions: []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}},
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol: config.Protocol_HTTP,
+		Path:     "/retry",
+		Retry:    retry,
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/retry", nil))
+	if w.Code != http.StatusServiceUnavailable || atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("want the last response forwarded after 3 attempts, got %d after %d", w.Code, calls)
+	}
+
+	retry.ExhaustedStatusCode = http.StatusGatewayTimeout
+	retry.ExhaustedHeader = "X-Retries-Exhausted"
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol: config.Protocol_HTTP,
+		Path:     "/retry",
+		Retry:    retry,
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/retry", nil))
+	if w.Code != http.StatusGatewayTimeout || w.Header().Get("X-Retries-Exhausted") != "3" {
+		t.Fatalf("want 504 with the exhausted header, got %d %v", w.Code, w.Header())
+	}
+
+	retry.ExhaustedStatusCode = 200
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{Path: "/retry", Retry: retry}}}); err == nil {
+		t.Fatal("want error for the invalid exhausted status code")
+	}
+}
+
+type closedBody struct {
+	io.Reader
+	closed int32
+}
+
+func (b *closedBody) Close() error {
+	atomic.StoreInt32(&b.closed, 1)
+	return nil
+}
+
+func TestRetryClosesBody(t *testing.T) {
+	var bodies []*closedBody
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			body := &closedBody{Reader: strings.NewReader("unavailable")}
+			bodies = append(bodies, body)
+			statusCode := http.StatusServiceUnavailable
+			if len(bodies) > 1 {
+				statusCode = http.StatusOK
+			}
+			return &http.Response{StatusCode: statusCode, Header: http.Header{}, Body: body}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conditions := []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol: config.Protocol_HTTP,
+		Path:     "/retry",
+		Retry:    &config.Retry{Attempts: 2, Conditions: conditions},
+	}, {
+		Protocol: config.Protocol_HTTP,
+		Path:     "/backoff",
+		Timeout:  durationpb.New(20 * time.Millisecond),
+		Retry: &config.Retry{Attempts: 2, Conditions: conditions, Backoff: &config.Backoff{
+			Base: durationpb.New(time.Hour),
+			Max:  durationpb.New(time.Hour),
+		}},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the superseded response is closed before the next attempt
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/retry", nil))
+	if len(bodies) != 2 || atomic.LoadInt32(&bodies[0].closed) != 1 {
+		t.Fatalf("want the body of the retried response closed, got %d attempts", len(bodies))
+	}
+
+	// the response is closed if the backoff is cut short
+	bodies = nil
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/backoff", nil))
+	if w.Code != http.StatusGatewayTimeout || len(bodies) != 1 || atomic.LoadInt32(&bodies[0].closed) != 1 {
+		t.Fatalf("want the body closed after the backoff timed out, got %d after %d attempts", w.Code, len(bodies))
+	}
+}
+
+func TestMaxRequestTimeout(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{
+		MaxRequestTimeout: durationpb.New(50 * time.Millisecond),
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Path:     "/slow",
+			Timeout:  durationpb.New(time.Hour),
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	startTime := time.Now()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/slow", nil))
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("want %d but got %d", http.StatusGatewayTimeout, w.Code)
+	}
+	if elapsed := time.Since(startTime); elapsed > time.Second {
+		t.Fatalf("want the timeout capped, elapsed: %v", elapsed)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/canceled" {
+				return nil, context.Canceled
+			}
+			return nil, errors.New("connection refused")
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retry := &config.Retry{RetryAfter: &config.RetryAfter{Fixed: durationpb.New(1500 * time.Millisecond)}}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{
+		{Protocol: config.Protocol_HTTP, Path: "/error", Retry: retry},
+		{Protocol: config.Protocol_HTTP, Path: "/canceled", Retry: retry},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/error", nil))
+	if w.Code != http.StatusBadGateway || w.Header().Get("Retry-After") != "2" {
+		t.Fatalf("want 502 with Retry-After 2, got %d %v", w.Code, w.Header())
+	}
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/canceled", nil))
+	if w.Code != 499 || w.Header().Get("Retry-After") != "" {
+		t.Fatalf("want 499 without Retry-After, got %d %v", w.Code, w.Header())
+	}
+
+	retry.RetryAfter.Fixed = durationpb.New(-time.Second)
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{Path: "/error", Retry: retry}}}); err == nil {
+		t.Fatal("want error for the negative retry after")
+	}
+}
+
+type failingWriter struct {
+	*httptest.ResponseRecorder
+	t *testing.T
+}
+
+func (w failingWriter) WriteHeader(statusCode int) {
+	w.t.Fatalf("want nothing written to the canceled client but got %d", statusCode)
+}
+
+func (w failingWriter) Write([]byte) (int, error) {
+	w.t.Fatal("want nothing written to the canceled client")
+	return 0, nil
+}
+
+func TestClientCancel(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, context.Canceled
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{
+		InboundMetrics: true,
+		Endpoints: []*config.Endpoint{
+			{Protocol: config.Protocol_HTTP, Path: "/cancel/skip", SkipClientCancel: true},
+			{Protocol: config.Protocol_HTTP, Path: "/cancel/respond"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	requests := _metricRequestsTotal.WithLabelValues("HTTP", "GET", "/cancel/skip", "499", "", "")
+	inbound := _metricInboundRequestsTotal.WithLabelValues("HTTP", "GET", "/cancel/skip", "499", "http", "")
+	before, inboundBefore := testutil.ToFloat64(requests), testutil.ToFloat64(inbound)
+	p.ServeHTTP(failingWriter{ResponseRecorder: httptest.NewRecorder(), t: t}, httptest.NewRequest("GET", "/cancel/skip", nil))
+	if got := testutil.ToFloat64(requests) - before; got != 1 {
+		t.Fatalf("want the 499 recorded but got %v", got)
+	}
+	if got := testutil.ToFloat64(inbound) - inboundBefore; got != 1 {
+		t.Fatalf("want the inbound 499 recorded but got %v", got)
+	}
+
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/cancel/respond", nil))
+	if w.Code != 499 {
+		t.Fatalf("want 499 written but got %d", w.Code)
+	}
+}
+
+func TestMetricsMode(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{
+		{Protocol: config.Protocol_HTTP, Path: "/metrics/aggregate/*", MetricsMode: config.MetricsMode_AGGREGATE},
+		{Protocol: config.Protocol_HTTP, Path: "/metrics/off", MetricsMode: config.MetricsMode_OFF},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/metrics/aggregate/a", nil))
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/metrics/aggregate/b", nil))
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/metrics/off", nil))
+	if got := testutil.ToFloat64(_metricRequestsTotal.WithLabelValues("HTTP", "GET", _aggregatePathLabel, "200", "", "")); got != 2 {
+		t.Fatalf("want 2 requests under the aggregated path but got %v", got)
+	}
+	if got := testutil.ToFloat64(_metricRequestsTotal.WithLabelValues("HTTP", "GET", "/metrics/off", "200", "", "")); got != 0 {
+		t.Fatalf("want no requests recorded with the metrics off but got %v", got)
+	}
+}
+
+func TestCorrelationHeader(t *testing.T) {
+	var ids []string
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ids = append(ids, req.Header.Get("X-Gateway-Correlation-Id"))
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol:          config.Protocol_HTTP,
+		Path:              "/retry",
+		CorrelationHeader: "X-Gateway-Correlation-Id",
+		Retry: &config.Retry{
+			Attempts:   2,
+			Conditions: []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}},
+		},
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/retry", nil))
+	p.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/retry", nil))
+	if len(ids) != 4 {
+		t.Fatalf("want 4 attempts but got %v", ids)
+	}
+	id := strings.TrimSuffix(ids[0], ".1")
+	if id == ids[0] || ids[1] != id+".2" {
+		t.Fatalf("want the attempts of a request correlated but got %v", ids[:2])
+	}
+	if strings.HasPrefix(ids[2], id) {
+		t.Fatalf("want the requests correlated by the different ids but got %v", ids)
+	}
+}
@@ -0,0 +1,114 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+const defaultResponseRateWindow = 5 * time.Second
+
+// errSlowUpstream is returned if the upstream body transfer rate drops below the floor.
+var errSlowUpstream = errors.New("upstream response body is too slow")
+
+// rateGuard closes the upstream body if fewer bytes than the floor are read in a window of the time waiting on it,
+// the time blocked in writing to the client isn't counted, so that a slow client isn't taken as a slow upstream.
+// The blocked Read is interrupted by the close.
+type rateGuard struct {
+	io.ReadCloser
+	aborted  int32
+	done     chan struct{}
+	stopOnce sync.Once
+
+	lock sync.Mutex
+	read int64
+	// waited is the time of the completed reads, reading is the start of the one in progress.
+	waited  time.Duration
+	reading time.Time
+}
+
+// newRateGuard returns nil if the rate floor is not configured.
+func newRateGuard(body io.ReadCloser, c *config.ResponseRate) *rateGuard {
+	if c == nil || c.BytesPerSecond <= 0 {
+		return nil
+	}
+	window := defaultResponseRateWindow
+	if c.Window != nil && c.Window.AsDuration() > 0 {
+		window = c.Window.AsDuration()
+	}
+	g := &rateGuard{
+		ReadCloser: body,
+		done:       make(chan struct{}),
+	}
+	go g.watch(float64(c.BytesPerSecond), window)
+	return g
+}
+
+// progress returns the bytes read and the time waited on the upstream so far.
+func (g *rateGuard) progress() (int64, time.Duration) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	waited := g.waited
+	if !g.reading.IsZero() {
+		waited += time.Since(g.reading)
+	}
+	return g.read, waited
+}
+
+// watch checks the rate once the time waited on the upstream reaches the window since the last check.
+func (g *rateGuard) watch(bytesPerSecond float64, window time.Duration) {
+	ticker := time.NewTicker(window / 4)
+	defer ticker.Stop()
+	var (
+		lastRead   int64
+		lastWaited time.Duration
+	)
+	for {
+		select {
+		case <-g.done:
+			return
+		case <-ticker.C:
+			read, waited := g.progress()
+			if waited-lastWaited < window {
+				continue
+			}
+			if float64(read-lastRead) < bytesPerSecond*(waited-lastWaited).Seconds() {
+				atomic.StoreInt32(&g.aborted, 1)
+				g.ReadCloser.Close()
+				return
+			}
+			lastRead, lastWaited = read, waited
+		}
+	}
+}
+
+func (g *rateGuard) Read(p []byte) (int, error) {
+	g.lock.Lock()
+	g.reading = time.Now()
+	g.lock.Unlock()
+	n, err := g.ReadCloser.Read(p)
+	g.lock.Lock()
+	g.waited += time.Since(g.reading)
+	g.reading = time.Time{}
+	g.read += int64(n)
+	g.lock.Unlock()
+	if g.isAborted() {
+		return n, errSlowUpstream
+	}
+	return n, err
+}
+
+func (g *rateGuard) isAborted() bool {
+	return atomic.LoadInt32(&g.aborted) == 1
+}
+
+// stop stops watching the rate, the body is closed by the caller.
+func (g *rateGuard) stop() {
+	g.stopOnce.Do(func() {
+		close(g.done)
+	})
+}
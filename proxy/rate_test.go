@@ -0,0 +1,61 @@
+package proxy
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestRateGuard(t *testing.T) {
+	if newRateGuard(ioutil.NopCloser(strings.NewReader("")), nil) != nil {
+		t.Fatal("want no guard without the config")
+	}
+	c := &config.ResponseRate{BytesPerSecond: 1024, Window: durationpb.New(20 * time.Millisecond)}
+
+	// the stalled upstream is interrupted by the guard
+	pr, pw := io.Pipe()
+	defer pw.Close()
+	guard := newRateGuard(pr, c)
+	if _, err := io.Copy(ioutil.Discard, guard); err != errSlowUpstream {
+		t.Fatalf("want errSlowUpstream, got: %v", err)
+	}
+	if !guard.isAborted() {
+		t.Fatal("want the guard aborted")
+	}
+
+	// the fast upstream is copied before the window
+	guard = newRateGuard(ioutil.NopCloser(strings.NewReader("hello")), c)
+	if _, err := io.Copy(ioutil.Discard, guard); err != nil {
+		t.Fatal(err)
+	}
+	guard.stop()
+	time.Sleep(40 * time.Millisecond)
+	if guard.isAborted() {
+		t.Fatal("want the stopped guard not aborted")
+	}
+
+	// the time blocked on the slow client isn't taken as the upstream being slow
+	guard = newRateGuard(ioutil.NopCloser(iotest.OneByteReader(strings.NewReader("0123456789"))), c)
+	if _, err := io.Copy(slowWriter{delay: 10 * time.Millisecond}, guard); err != nil {
+		t.Fatal(err)
+	}
+	guard.stop()
+	if guard.isAborted() {
+		t.Fatal("want the guard not aborted by the slow client")
+	}
+}
+
+type slowWriter struct {
+	delay time.Duration
+}
+
+func (w slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return len(p), nil
+}
@@ -1,11 +1,16 @@
 package proxy
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
 	"github.com/go-kratos/gateway/proxy/condition"
+	"google.golang.org/protobuf/proto"
 )
 
 type retryStrategy struct {
@@ -13,12 +18,50 @@ type retryStrategy struct {
 	timeout       time.Duration
 	perTryTimeout time.Duration
 	conditions    []condition.Condition
+	newBackoff    func() backoff
+	nonIdempotent bool
+	// exhaustedStatusCode and exhaustedHeader are for the requests all the attempts matched the conditions.
+	exhaustedStatusCode int
+	exhaustedHeader     string
+	// retryAfter and retryAfterBackoff are the Retry-After hint of the gateway-originated errors.
+	retryAfter        time.Duration
+	retryAfterBackoff bool
+}
+
+// retryAfterHint returns the Retry-After hint, it's the next delay of the backoff of the request
+// if it's derived from the backoff, or zero if it's not hinted.
+func (s *retryStrategy) retryAfterHint(b backoff) time.Duration {
+	if s.retryAfterBackoff && s.newBackoff != nil {
+		if b == nil {
+			b = s.newBackoff()
+		}
+		return b.next()
+	}
+	return s.retryAfter
+}
+
+// _metadataTimeout is the metadata key of the timeout, e.g. "500ms", it's the fallback of the timeout field.
+const _metadataTimeout = "timeout"
+
+// metadataTimeout returns the timeout of the metadata, or zero if it's not present.
+func metadataTimeout(endpoint *config.Endpoint) (time.Duration, error) {
+	v, ok := endpoint.Metadata[_metadataTimeout]
+	if !ok {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timeout metadata: %q: %w", v, err)
+	}
+	return timeout, nil
 }
 
 func calcTimeout(endpoint *config.Endpoint) time.Duration {
 	var timeout time.Duration
 	if endpoint.Timeout != nil {
 		timeout = endpoint.Timeout.AsDuration()
+	} else {
+		timeout, _ = metadataTimeout(endpoint)
 	}
 	if timeout <= 0 {
 		timeout = time.Second
@@ -42,6 +85,8 @@ func calcPerTryTimeout(endpoint *config.Endpoint) time.Duration {
 		perTryTimeout = endpoint.Retry.PerTryTimeout.AsDuration()
 	} else if endpoint.Timeout != nil {
 		perTryTimeout = endpoint.Timeout.AsDuration()
+	} else {
+		perTryTimeout, _ = metadataTimeout(endpoint)
 	}
 	if perTryTimeout <= 0 {
 		perTryTimeout = time.Second
@@ -50,6 +95,9 @@ func calcPerTryTimeout(endpoint *config.Endpoint) time.Duration {
 }
 
 func prepareRetryStrategy(e *config.Endpoint) (*retryStrategy, error) {
+	if _, err := metadataTimeout(e); err != nil {
+		return nil, err
+	}
 	strategy := &retryStrategy{
 		attempts:      calcAttempts(e),
 		timeout:       calcTimeout(e),
@@ -60,9 +108,74 @@ func prepareRetryStrategy(e *config.Endpoint) (*retryStrategy, error) {
 		return nil, err
 	}
 	strategy.conditions = conditions
+	strategy.newBackoff = prepareBackoff(e)
+	strategy.nonIdempotent = e.Retry != nil && e.Retry.RetryNonIdempotent
+	if e.Retry != nil {
+		if code := e.Retry.ExhaustedStatusCode; code != 0 && (code < 400 || code > 599) {
+			return nil, fmt.Errorf("invalid retry exhausted status code: %d", code)
+		}
+		strategy.exhaustedStatusCode = int(e.Retry.ExhaustedStatusCode)
+		strategy.exhaustedHeader = e.Retry.ExhaustedHeader
+		if hint := e.Retry.RetryAfter; hint != nil {
+			if hint.Fixed != nil && hint.Fixed.AsDuration() < 0 {
+				return nil, fmt.Errorf("invalid retry after: %s", hint.Fixed.AsDuration())
+			}
+			strategy.retryAfter = hint.Fixed.AsDuration()
+			strategy.retryAfterBackoff = hint.Backoff
+		}
+	}
 	return strategy, nil
 }
 
+// inheritServiceDefaults returns the endpoint inheriting the defaults of its service,
+// or the endpoint itself if there is nothing to inherit.
+func inheritServiceDefaults(e *config.Endpoint, defaults map[string]*config.ServiceDefaults) *config.Endpoint {
+	d, ok := defaults[e.Metadata["service"]]
+	if !ok {
+		return e
+	}
+	_, hasMetadataTimeout := e.Metadata[_metadataTimeout]
+	inheritTimeout := d.Timeout != nil && e.Timeout == nil && !hasMetadataTimeout
+	if !inheritTimeout && d.Retry == nil {
+		return e
+	}
+	e = proto.Clone(e).(*config.Endpoint)
+	if inheritTimeout {
+		e.Timeout = d.Timeout
+	}
+	if d.Retry != nil {
+		retry := proto.Clone(d.Retry).(*config.Retry)
+		if e.Retry != nil {
+			if len(e.Retry.Conditions) > 0 {
+				retry.Conditions = nil
+			}
+			proto.Merge(retry, e.Retry)
+		}
+		e.Retry = retry
+	}
+	return e
+}
+
+// validateServiceDefaults rejects the defaults of the services of no endpoints, which are likely misspelled.
+func validateServiceDefaults(c *config.Gateway) error {
+	if len(c.ServiceDefaults) == 0 {
+		return nil
+	}
+	services := make(map[string]struct{}, len(c.Endpoints)+1)
+	for _, e := range c.Endpoints {
+		services[e.Metadata["service"]] = struct{}{}
+	}
+	if c.DefaultEndpoint != nil {
+		services[c.DefaultEndpoint.Metadata["service"]] = struct{}{}
+	}
+	for name := range c.ServiceDefaults {
+		if _, ok := services[name]; !ok || name == "" {
+			return fmt.Errorf("service defaults of unknown service: %q", name)
+		}
+	}
+	return nil
+}
+
 func parseRetryConditon(endpoint *config.Endpoint) ([]condition.Condition, error) {
 	if endpoint.Retry == nil {
 		return []condition.Condition{}, nil
@@ -70,6 +183,192 @@ func parseRetryConditon(endpoint *config.Endpoint) ([]condition.Condition, error
 	return condition.ParseConditon(endpoint.Retry.Conditions...)
 }
 
+const (
+	defaultBackoffBase = 25 * time.Millisecond
+	defaultBackoffMax  = time.Second
+)
+
+// backoff returns the delay before each retry, it's created per request.
+type backoff interface {
+	next() time.Duration
+}
+
+type exponentialBackoff struct {
+	base, max time.Duration
+	retries   uint
+}
+
+func (b *exponentialBackoff) next() time.Duration {
+	retries := b.retries
+	if retries < 62 {
+		b.retries++
+	}
+	if retries >= 62 || b.base > b.max>>retries {
+		return b.max
+	}
+	return b.base << retries
+}
+
+type decorrelatedJitterBackoff struct {
+	base, max time.Duration
+	prev      time.Duration
+}
+
+func (b *decorrelatedJitterBackoff) next() time.Duration {
+	upper := b.prev * 3
+	if upper <= b.base {
+		upper = b.base + 1
+	}
+	delay := b.base + time.Duration(rand.Int63n(int64(upper-b.base)))
+	if delay > b.max {
+		delay = b.max
+	}
+	b.prev = delay
+	return delay
+}
+
+func prepareBackoff(endpoint *config.Endpoint) func() backoff {
+	if endpoint.Retry == nil || endpoint.Retry.Backoff == nil {
+		return nil
+	}
+	c := endpoint.Retry.Backoff
+	base, max := defaultBackoffBase, defaultBackoffMax
+	if c.Base != nil && c.Base.AsDuration() > 0 {
+		base = c.Base.AsDuration()
+	}
+	if c.Max != nil && c.Max.AsDuration() > 0 {
+		max = c.Max.AsDuration()
+	}
+	if max < base {
+		max = base
+	}
+	switch c.Policy {
+	case config.BackoffPolicy_DECORRELATED_JITTER:
+		return func() backoff {
+			return &decorrelatedJitterBackoff{base: base, max: max, prev: base}
+		}
+	default:
+		return func() backoff {
+			return &exponentialBackoff{base: base, max: max}
+		}
+	}
+}
+
+// sleepContext returns the context error if it's done before the delay.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isIdempotentRequest reports whether the request is safe to be retried,
+// see https://www.rfc-editor.org/rfc/rfc7231#section-4.2.2
+func isIdempotentRequest(req *http.Request) bool {
+	return isIdempotentMethod(req.Method) || req.Header.Get("Idempotency-Key") != ""
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
 func judgeRetryRequired(conditions []condition.Condition, resp *http.Response) bool {
 	return condition.JudgeConditons(conditions, resp, false)
 }
+
+// retrySemaphore caps the in-flight retry attempts across the gateway.
+type retrySemaphore struct {
+	inflight int64
+	limit    int64
+}
+
+func (s *retrySemaphore) setLimit(limit int64) {
+	atomic.StoreInt64(&s.limit, limit)
+}
+
+// acquire returns false if the limit is reached, it's unlimited if the limit is not positive.
+func (s *retrySemaphore) acquire() bool {
+	inflight := atomic.AddInt64(&s.inflight, 1)
+	if limit := atomic.LoadInt64(&s.limit); limit > 0 && inflight > limit {
+		atomic.AddInt64(&s.inflight, -1)
+		return false
+	}
+	return true
+}
+
+func (s *retrySemaphore) release() {
+	atomic.AddInt64(&s.inflight, -1)
+}
+
+// RetryInspect is the prepared retry strategy of an endpoint in the debug inspect output.
+type RetryInspect struct {
+	Protocol      string   `json:"protocol"`
+	Method        string   `json:"method"`
+	Path          string   `json:"path"`
+	Attempts      int      `json:"attempts"`
+	Timeout       string   `json:"timeout"`
+	PerTryTimeout string   `json:"per_try_timeout"`
+	Conditions    []string `json:"conditions"`
+	Backoff       string   `json:"backoff,omitempty"`
+	// RetryNonIdempotent is false if only the idempotent methods are retried.
+	RetryNonIdempotent  bool   `json:"retry_non_idempotent"`
+	ExhaustedStatusCode int    `json:"exhausted_status_code,omitempty"`
+	ExhaustedHeader     string `json:"exhausted_header,omitempty"`
+	RetryAfter          string `json:"retry_after,omitempty"`
+}
+
+func inspectRetryStrategy(e *config.Endpoint, s *retryStrategy) *RetryInspect {
+	inspect := &RetryInspect{
+		Protocol:            e.Protocol.String(),
+		Method:              e.Method,
+		Path:                e.Path,
+		Attempts:            s.attempts,
+		Timeout:             s.timeout.String(),
+		PerTryTimeout:       s.perTryTimeout.String(),
+		Conditions:          []string{},
+		RetryNonIdempotent:  s.nonIdempotent,
+		ExhaustedStatusCode: s.exhaustedStatusCode,
+		ExhaustedHeader:     s.exhaustedHeader,
+	}
+	if e.Retry != nil {
+		for _, c := range e.Retry.Conditions {
+			inspect.Conditions = append(inspect.Conditions, describeCondition(c))
+		}
+		if s.newBackoff != nil {
+			inspect.Backoff = e.Retry.Backoff.Policy.String()
+		}
+	}
+	switch {
+	case s.retryAfterBackoff && s.newBackoff != nil:
+		inspect.RetryAfter = "backoff"
+	case s.retryAfter > 0:
+		inspect.RetryAfter = s.retryAfter.String()
+	}
+	return inspect
+}
+
+// describeCondition returns the condition in the form of "status_code:500-599", "header:grpc-status=14"
+// or "header:name~pattern", and "empty_body".
+func describeCondition(c *config.Condition) string {
+	switch v := c.Condition.(type) {
+	case *config.Condition_ByStatusCode:
+		return "status_code:" + v.ByStatusCode
+	case *config.Condition_ByHeader:
+		if v.ByHeader.Pattern != "" {
+			return "header:" + v.ByHeader.Name + "~" + v.ByHeader.Pattern
+		}
+		return "header:" + v.ByHeader.Name + "=" + v.ByHeader.Value
+	case *config.Condition_ByEmptyBody:
+		return "empty_body"
+	default:
+		return "unknown"
+	}
+}
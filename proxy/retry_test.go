@@ -1,10 +1,14 @@
 package proxy
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
@@ -62,6 +66,19 @@ func TestCalcTimeout(t *testing.T) {
 			},
 			timeout: time.Second * 5,
 		},
+		{
+			endpoint: &config.Endpoint{
+				Metadata: map[string]string{"timeout": "500ms"},
+			},
+			timeout: 500 * time.Millisecond,
+		},
+		{
+			endpoint: &config.Endpoint{
+				Timeout:  &durationpb.Duration{Seconds: 5},
+				Metadata: map[string]string{"timeout": "500ms"},
+			},
+			timeout: time.Second * 5,
+		},
 	}
 
 	for _, testCase := range testCase {
@@ -71,3 +88,234 @@ func TestCalcTimeout(t *testing.T) {
 		}
 	}
 }
+
+func TestMetadataTimeout(t *testing.T) {
+	e := &config.Endpoint{Metadata: map[string]string{"timeout": "500ms"}}
+	strategy, err := prepareRetryStrategy(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strategy.timeout != 500*time.Millisecond || strategy.perTryTimeout != 500*time.Millisecond {
+		t.Fatalf("want the timeouts of the metadata but got %v %v", strategy.timeout, strategy.perTryTimeout)
+	}
+	e.Metadata["timeout"] = "5"
+	if _, err := prepareRetryStrategy(e); err == nil {
+		t.Fatal("want error for the invalid timeout metadata")
+	}
+}
+
+func TestRetrySemaphore(t *testing.T) {
+	s := &retrySemaphore{}
+	for i := 0; i < 3; i++ {
+		if !s.acquire() {
+			t.Fatal("want unlimited retries if the limit is not set")
+		}
+	}
+	s.release()
+	s.release()
+	s.release()
+
+	s.setLimit(2)
+	if !s.acquire() || !s.acquire() {
+		t.Fatal("want the retries acquired under the limit")
+	}
+	if s.acquire() {
+		t.Fatal("want the retry skipped beyond the limit")
+	}
+	s.release()
+	if !s.acquire() {
+		t.Fatal("want the retry acquired after released")
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := &exponentialBackoff{base: 10 * time.Millisecond, max: 50 * time.Millisecond}
+	want := []time.Duration{10, 20, 40, 50, 50}
+	for i, w := range want {
+		if got := b.next(); got != w*time.Millisecond {
+			t.Fatalf("retry %d: want %v, got: %v", i+1, w*time.Millisecond, got)
+		}
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	base, max := 10*time.Millisecond, 200*time.Millisecond
+	newBackoff := prepareBackoff(&config.Endpoint{Retry: &config.Retry{Backoff: &config.Backoff{
+		Policy: config.BackoffPolicy_DECORRELATED_JITTER,
+		Base:   durationpb.New(base),
+		Max:    durationpb.New(max),
+	}}})
+	sequences := make(map[string]struct{})
+	for i := 0; i < 10; i++ {
+		b := newBackoff()
+		var sequence string
+		for j := 0; j < 20; j++ {
+			delay := b.next()
+			if delay < base || delay > max {
+				t.Fatalf("want delay in [%v, %v], got: %v", base, max, delay)
+			}
+			sequence += delay.String() + ","
+		}
+		sequences[sequence] = struct{}{}
+	}
+	if len(sequences) < 2 {
+		t.Fatal("want non-deterministic delay sequences")
+	}
+}
+
+func TestIsIdempotentRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		key    string
+		want   bool
+	}{
+		{method: http.MethodGet, want: true},
+		{method: http.MethodPut, want: true},
+		{method: http.MethodDelete, want: true},
+		{method: http.MethodPost, want: false},
+		{method: http.MethodPatch, want: false},
+		{method: http.MethodPost, key: "a8098c1a", want: true},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "/", nil)
+		if test.key != "" {
+			req.Header.Set("Idempotency-Key", test.key)
+		}
+		if got := isIdempotentRequest(req); got != test.want {
+			t.Errorf("%s: want %v but got %v", test.method, test.want, got)
+		}
+	}
+}
+
+func TestRetryAfterHint(t *testing.T) {
+	s := &retryStrategy{retryAfter: time.Second}
+	if got := s.retryAfterHint(nil); got != time.Second {
+		t.Fatalf("want the fixed hint but got %s", got)
+	}
+	// the fixed one is the fallback without the backoff
+	s.retryAfterBackoff = true
+	if got := s.retryAfterHint(nil); got != time.Second {
+		t.Fatalf("want the fixed hint but got %s", got)
+	}
+	s.newBackoff = func() backoff { return &exponentialBackoff{base: 100 * time.Millisecond, max: time.Minute} }
+	b := s.newBackoff()
+	b.next()
+	if got := s.retryAfterHint(b); got != 200*time.Millisecond {
+		t.Fatalf("want the next delay of the backoff but got %s", got)
+	}
+}
+
+func TestRetryInspect(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{
+		MaxRequestTimeout: durationpb.New(2 * time.Second),
+		Endpoints: []*config.Endpoint{
+			{
+				Protocol: config.Protocol_HTTP,
+				Method:   "GET",
+				Path:     "/users",
+				Timeout:  durationpb.New(5 * time.Second),
+				Retry: &config.Retry{
+					Attempts:      3,
+					PerTryTimeout: durationpb.New(500 * time.Millisecond),
+					Conditions: []*config.Condition{
+						{Condition: &config.Condition_ByStatusCode{ByStatusCode: "502-504"}},
+						{Condition: &config.Condition_ByHeader{ByHeader: &config.ConditionHeader{Name: "grpc-status", Value: "14"}}},
+					},
+					Backoff:    &config.Backoff{Policy: config.BackoffPolicy_EXPONENTIAL},
+					RetryAfter: &config.RetryAfter{Backoff: true},
+				},
+			},
+			{Protocol: config.Protocol_GRPC, Path: "/helloworld.Greeter/SayHello"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	p.DebugHandler().ServeHTTP(w, httptest.NewRequest("GET", "/debug/proxy/retry/inspect", nil))
+	var out struct {
+		Endpoints []*RetryInspect `json:"endpoints"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Endpoints) != 2 {
+		t.Fatalf("want 2 endpoints but got %d", len(out.Endpoints))
+	}
+	var users, greeter *RetryInspect
+	for _, e := range out.Endpoints {
+		if e.Path == "/users" {
+			users = e
+		} else {
+			greeter = e
+		}
+	}
+	// the timeout is capped by the max request timeout
+	if users.Attempts != 3 || users.Timeout != "2s" || users.PerTryTimeout != "500ms" || users.Backoff != "EXPONENTIAL" ||
+		users.RetryAfter != "backoff" || len(users.Conditions) != 2 ||
+		users.Conditions[0] != "status_code:502-504" || users.Conditions[1] != "header:grpc-status=14" {
+		t.Fatalf("unexpected retry strategy: %+v", users)
+	}
+	if greeter.Protocol != "GRPC" || greeter.Attempts != 1 || greeter.Timeout != "1s" || len(greeter.Conditions) != 0 {
+		t.Fatalf("unexpected retry strategy: %+v", greeter)
+	}
+}
+
+func TestInheritServiceDefaults(t *testing.T) {
+	defaults := map[string]*config.ServiceDefaults{
+		"helloworld": {
+			Timeout: durationpb.New(3 * time.Second),
+			Retry: &config.Retry{
+				Attempts:      3,
+				PerTryTimeout: durationpb.New(time.Second),
+				Conditions:    []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "502-504"}}},
+			},
+		},
+	}
+	// the endpoint inherits all of the defaults
+	e := &config.Endpoint{Metadata: map[string]string{"service": "helloworld"}}
+	got := inheritServiceDefaults(e, defaults)
+	if calcTimeout(got) != 3*time.Second || calcAttempts(got) != 3 || calcPerTryTimeout(got) != time.Second || len(got.Retry.Conditions) != 1 {
+		t.Fatalf("want the defaults inherited but got %v", got)
+	}
+	if e.Timeout != nil || e.Retry != nil {
+		t.Fatal("want the endpoint of the config unchanged")
+	}
+	// the fields of the endpoint take precedence
+	e = &config.Endpoint{
+		Metadata: map[string]string{"service": "helloworld", "timeout": "500ms"},
+		Retry: &config.Retry{
+			Attempts:   2,
+			Conditions: []*config.Condition{{Condition: &config.Condition_ByStatusCode{ByStatusCode: "503"}}},
+		},
+	}
+	got = inheritServiceDefaults(e, defaults)
+	if calcTimeout(got) != 500*time.Millisecond || calcAttempts(got) != 2 || calcPerTryTimeout(got) != time.Second ||
+		len(got.Retry.Conditions) != 1 || got.Retry.Conditions[0].GetByStatusCode() != "503" {
+		t.Fatalf("want the endpoint taking precedence but got %v", got)
+	}
+	// the endpoints of the other services inherit nothing
+	e = &config.Endpoint{Metadata: map[string]string{"service": "other"}}
+	if got := inheritServiceDefaults(e, defaults); got != e {
+		t.Fatalf("want the endpoint as it is but got %v", got)
+	}
+
+	c := &config.Gateway{
+		ServiceDefaults: defaults,
+		Endpoints:       []*config.Endpoint{{Path: "/hello", Metadata: map[string]string{"service": "helloworld"}}},
+	}
+	if err := validateServiceDefaults(c); err != nil {
+		t.Fatal(err)
+	}
+	c.Endpoints[0].Metadata["service"] = "hellowrold"
+	if err := validateServiceDefaults(c); err == nil {
+		t.Fatal("want the defaults of the unknown service rejected")
+	}
+}
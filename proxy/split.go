@@ -0,0 +1,150 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"net/http"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/client"
+	"github.com/go-kratos/gateway/middleware"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	_defaultVariantHeader = "X-Variant"
+	// _variantMetadata is the metadata key of the assigned variant, so that the attempts of a request
+	// are sent to the same variant.
+	_variantMetadata = "split.variant"
+)
+
+type splitVariant struct {
+	name    string
+	weight  uint32
+	tripper http.RoundTripper
+}
+
+// splitTripper sends each request to the backends of the assigned variant.
+type splitTripper struct {
+	variants      []*splitVariant
+	byName        map[string]*splitVariant
+	totalWeight   uint32
+	hashHeader    string
+	cookie        string
+	cookieMaxAge  int
+	variantHeader string
+}
+
+func newSplitTripper(e *config.Endpoint, factory client.Factory) (http.RoundTripper, error) {
+	c := e.Split
+	if len(c.Variants) == 0 {
+		return nil, errors.New("split variants must be specified")
+	}
+	t := &splitTripper{
+		variants:      make([]*splitVariant, 0, len(c.Variants)),
+		byName:        make(map[string]*splitVariant, len(c.Variants)),
+		hashHeader:    c.HashHeader,
+		cookie:        c.Cookie,
+		variantHeader: _defaultVariantHeader,
+	}
+	if c.VariantHeader != "" {
+		t.variantHeader = c.VariantHeader
+	}
+	if c.CookieMaxAge != nil {
+		if c.CookieMaxAge.AsDuration() < 0 {
+			return nil, fmt.Errorf("invalid split cookie max age: %s", c.CookieMaxAge.AsDuration())
+		}
+		t.cookieMaxAge = int(c.CookieMaxAge.AsDuration().Seconds())
+	}
+	for _, in := range c.Variants {
+		if in.Name == "" {
+			return nil, errors.New("split variant name must be specified")
+		}
+		if _, ok := t.byName[in.Name]; ok {
+			return nil, fmt.Errorf("duplicate split variant name: %s", in.Name)
+		}
+		endpoint := proto.Clone(e).(*config.Endpoint)
+		endpoint.Split = nil
+		endpoint.Backends = in.Backends
+		tripper, err := factory(endpoint)
+		if err != nil {
+			return nil, err
+		}
+		v := &splitVariant{name: in.Name, weight: in.Weight, tripper: tripper}
+		t.variants = append(t.variants, v)
+		t.byName[in.Name] = v
+		t.totalWeight += in.Weight
+	}
+	if t.totalWeight == 0 {
+		return nil, errors.New("split variant weights must not be all zero")
+	}
+	return t, nil
+}
+
+// pick returns the variant of the bucket in [0, totalWeight).
+func (t *splitTripper) pick(bucket uint32) *splitVariant {
+	for _, v := range t.variants {
+		if bucket < v.weight {
+			return v
+		}
+		bucket -= v.weight
+	}
+	return t.variants[len(t.variants)-1]
+}
+
+// assign returns the variant of the request, and whether the cookie is to be set.
+func (t *splitTripper) assign(req *http.Request) (*splitVariant, bool) {
+	if t.cookie != "" {
+		if c, err := req.Cookie(t.cookie); err == nil {
+			if v, ok := t.byName[c.Value]; ok && v.weight > 0 {
+				return v, false
+			}
+		}
+	}
+	if t.hashHeader != "" {
+		if key := req.Header.Get(t.hashHeader); key != "" {
+			h := fnv.New32a()
+			h.Write([]byte(key))
+			return t.pick(h.Sum32() % t.totalWeight), t.cookie != ""
+		}
+	}
+	return t.pick(uint32(rand.Int63n(int64(t.totalWeight)))), t.cookie != ""
+}
+
+func (t *splitTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var (
+		variant   *splitVariant
+		setCookie bool
+	)
+	reqOpt, ok := middleware.FromRequestContext(req.Context())
+	if ok {
+		variant = t.byName[reqOpt.Metadata[_variantMetadata]]
+	}
+	if variant == nil {
+		variant, setCookie = t.assign(req)
+		if ok {
+			reqOpt.Metadata[_variantMetadata] = variant.name
+		}
+	} else if t.cookie != "" {
+		// the retries set the cookie unless the request carries it already
+		c, err := req.Cookie(t.cookie)
+		setCookie = err != nil || c.Value != variant.name
+	}
+	resp, err := variant.tripper.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header.Set(t.variantHeader, variant.name)
+	if setCookie {
+		resp.Header.Add("Set-Cookie", (&http.Cookie{
+			Name:     t.cookie,
+			Value:    variant.name,
+			Path:     "/",
+			MaxAge:   t.cookieMaxAge,
+			HttpOnly: true,
+		}).String())
+	}
+	return resp, nil
+}
@@ -0,0 +1,117 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSplit(t *testing.T) {
+	p, err := New(func(e *config.Endpoint) (http.RoundTripper, error) {
+		target := e.Backends[0].Target
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			header := http.Header{}
+			header.Set("X-Backend", target)
+			return &http.Response{StatusCode: http.StatusOK, Header: header, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{
+		Endpoints: []*config.Endpoint{{
+			Protocol: config.Protocol_HTTP,
+			Path:     "/split",
+			Split: &config.Split{
+				Variants: []*config.Split_Variant{
+					{Name: "control", Weight: 50, Backends: []*config.Backend{{Target: "control"}}},
+					{Name: "treatment", Weight: 50, Backends: []*config.Backend{{Target: "treatment"}}},
+					{Name: "drained", Weight: 0, Backends: []*config.Backend{{Target: "drained"}}},
+				},
+				HashHeader: "X-User-Id",
+				Cookie:     "gateway-variant",
+			},
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	do := func(userID, cookie string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/split", nil)
+		if userID != "" {
+			req.Header.Set("X-User-Id", userID)
+		}
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "gateway-variant", Value: cookie})
+		}
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, req)
+		return w
+	}
+
+	// the same user is always assigned the same variant
+	variants := map[string]int{}
+	for i := 0; i < 100; i++ {
+		userID := "user-" + strconv.Itoa(i)
+		w := do(userID, "")
+		variant := w.Header().Get("X-Variant")
+		if variant != w.Header().Get("X-Backend") {
+			t.Fatalf("want the backends of variant %s but got %s", variant, w.Header().Get("X-Backend"))
+		}
+		if w.Header().Get("Set-Cookie") == "" {
+			t.Fatalf("want the cookie of variant %s set", variant)
+		}
+		for j := 0; j < 3; j++ {
+			if got := do(userID, "").Header().Get("X-Variant"); got != variant {
+				t.Fatalf("want the sticky variant %s of %s but got %s", variant, userID, got)
+			}
+		}
+		variants[variant]++
+	}
+	if variants["control"] == 0 || variants["treatment"] == 0 || variants["drained"] != 0 {
+		t.Fatalf("want the users split by the weights but got %v", variants)
+	}
+
+	// the cookie takes precedence over the hash key, and it's not set again
+	before := testutil.ToFloat64(_metricSplitRequests.WithLabelValues("HTTP", "GET", "/split", "treatment", "", ""))
+	for _, userID := range []string{"", "user-1", "user-2"} {
+		w := do(userID, "treatment")
+		if w.Header().Get("X-Variant") != "treatment" || w.Header().Get("Set-Cookie") != "" {
+			t.Fatalf("want the variant of the cookie but got %s %s", w.Header().Get("X-Variant"), w.Header().Get("Set-Cookie"))
+		}
+	}
+	if got := testutil.ToFloat64(_metricSplitRequests.WithLabelValues("HTTP", "GET", "/split", "treatment", "", "")) - before; got != 3 {
+		t.Fatalf("want 3 split requests recorded but got %v", got)
+	}
+
+	// the cookie of the drained or unknown variant is reassigned
+	for _, cookie := range []string{"drained", "unknown"} {
+		w := do("", cookie)
+		if v := w.Header().Get("X-Variant"); v != "control" && v != "treatment" {
+			t.Fatalf("want the cookie %s reassigned but got %s", cookie, v)
+		}
+		if w.Header().Get("Set-Cookie") == "" {
+			t.Fatalf("want the cookie %s replaced", cookie)
+		}
+	}
+}
+
+func TestSplitInvalid(t *testing.T) {
+	factory := func(*config.Endpoint) (http.RoundTripper, error) {
+		return http.DefaultTransport, nil
+	}
+	for _, c := range []*config.Split{
+		{},
+		{Variants: []*config.Split_Variant{{Weight: 1}}},
+		{Variants: []*config.Split_Variant{{Name: "a", Weight: 1}, {Name: "a", Weight: 1}}},
+		{Variants: []*config.Split_Variant{{Name: "a"}, {Name: "b"}}},
+	} {
+		if _, err := newSplitTripper(&config.Endpoint{Split: c}, factory); err == nil {
+			t.Fatalf("want the invalid split %v rejected", c)
+		}
+	}
+}
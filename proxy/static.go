@@ -0,0 +1,124 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+// _defaultIndex is the index file served by http.FileServer.
+const _defaultIndex = "index.html"
+
+// staticFileSystem hides the directories without index file unless directory listing is enabled.
+type staticFileSystem struct {
+	http.FileSystem
+	directoryListing bool
+}
+
+func (fs staticFileSystem) Open(name string) (http.File, error) {
+	f, err := fs.FileSystem.Open(name)
+	if err != nil || fs.directoryListing {
+		return f, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if stat.IsDir() && !fs.exists(path.Join(name, _defaultIndex)) {
+		f.Close()
+		return nil, os.ErrNotExist
+	}
+	return f, nil
+}
+
+func (fs staticFileSystem) exists(name string) bool {
+	f, err := fs.FileSystem.Open(name)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+func (fs staticFileSystem) isDir(name string) bool {
+	f, err := fs.FileSystem.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	return err == nil && stat.IsDir()
+}
+
+// staticTripper serves the files with the http.FileServer semantics,
+// including range requests, content type detection and conditional requests.
+type staticTripper struct {
+	fs           staticFileSystem
+	transport    http.RoundTripper
+	prefix       string
+	index        string
+	fallback     string
+	cacheControl string
+}
+
+func newStaticTripper(e *config.Endpoint) (http.RoundTripper, error) {
+	c := e.Static
+	if c.Root == "" {
+		return nil, errors.New("static root must be specified")
+	}
+	fs := staticFileSystem{
+		FileSystem:       http.Dir(c.Root),
+		directoryListing: c.DirectoryListing,
+	}
+	t := &staticTripper{
+		fs:           fs,
+		transport:    http.NewFileTransport(fs),
+		index:        c.Index,
+		cacheControl: c.CacheControl,
+		prefix:       strings.TrimSuffix(strings.TrimRight(e.Path, "*"), "/"),
+	}
+	if c.StripPrefix != nil {
+		t.prefix = c.GetStripPrefix()
+	}
+	if c.Fallback != "" {
+		t.fallback = path.Join("/", c.Fallback)
+		if path.Base(t.fallback) == _defaultIndex {
+			// http.FileServer redirects the requests of index.html to the directory
+			t.fallback = path.Dir(t.fallback) + "/"
+		}
+	}
+	if t.index == _defaultIndex {
+		t.index = ""
+	}
+	return t, nil
+}
+
+func (t *staticTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := path.Join("/", strings.TrimPrefix(req.URL.Path, t.prefix))
+	if strings.HasSuffix(req.URL.Path, "/") && name != "/" {
+		name += "/"
+	}
+	if t.index != "" && t.fs.isDir(name) {
+		if index := path.Join(name, t.index); t.fs.exists(index) {
+			name = index
+		}
+	}
+	if t.fallback != "" && !t.fs.exists(name) {
+		name = t.fallback
+	}
+	req.URL.Path = name
+	req.URL.RawPath = ""
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.cacheControl != "" && resp.StatusCode < http.StatusBadRequest {
+		resp.Header.Set("Cache-Control", t.cacheControl)
+	}
+	return resp, nil
+}
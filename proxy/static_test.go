@@ -0,0 +1,78 @@
+package proxy
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+)
+
+func TestStaticTripper(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "assets"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"index.html":    "<html>index</html>",
+		"assets/app.js": "console.log('hello')",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		static      *config.Static
+		path        string
+		header      http.Header
+		statusCode  int
+		contentType string
+		body        string
+	}{
+		{static: &config.Static{}, path: "/static/assets/app.js", statusCode: 200, body: "console.log('hello')"},
+		{static: &config.Static{}, path: "/static/assets/app.js", header: http.Header{"Range": []string{"bytes=0-6"}}, statusCode: 206, body: "console"},
+		{static: &config.Static{}, path: "/static/assets/", statusCode: 404},
+		{static: &config.Static{DirectoryListing: true}, path: "/static/assets/", statusCode: 200},
+		{static: &config.Static{}, path: "/static/", statusCode: 200, contentType: "text/html; charset=utf-8", body: "<html>index</html>"},
+		{static: &config.Static{}, path: "/static/users/42", statusCode: 404},
+		{static: &config.Static{Fallback: "index.html"}, path: "/static/users/42", statusCode: 200, body: "<html>index</html>"},
+		{static: &config.Static{Index: "app.js"}, path: "/static/assets/", statusCode: 200, body: "console.log('hello')"},
+	}
+	for _, test := range tests {
+		test.static.Root = root
+		tripper, err := newStaticTripper(&config.Endpoint{Path: "/static/*", Static: test.static})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("GET", test.path, nil)
+		for k, v := range test.header {
+			req.Header[k] = v
+		}
+		resp, err := tripper.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != test.statusCode {
+			t.Errorf("%s: want %d but got %d", test.path, test.statusCode, resp.StatusCode)
+		}
+		if test.contentType != "" && resp.Header.Get("Content-Type") != test.contentType {
+			t.Errorf("%s: want content type %s but got %s", test.path, test.contentType, resp.Header.Get("Content-Type"))
+		}
+		if test.body != "" && string(body) != test.body {
+			t.Errorf("%s: want body %q but got %q", test.path, test.body, body)
+		}
+	}
+	tripper, _ := newStaticTripper(&config.Endpoint{Path: "/static/*", Static: &config.Static{Root: root, CacheControl: "max-age=60"}})
+	resp, err := tripper.RoundTrip(httptest.NewRequest("GET", "/static/assets/app.js", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Header.Get("Cache-Control") != "max-age=60" {
+		t.Errorf("want cache control but got %q", resp.Header.Get("Cache-Control"))
+	}
+}
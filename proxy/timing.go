@@ -0,0 +1,58 @@
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// serverTiming is the latency composition of a request, see https://www.w3.org/TR/server-timing/.
+type serverTiming struct {
+	start time.Time
+	// loopStart is the start of the first attempt.
+	loopStart time.Time
+	// attemptStart is the start of the last attempt.
+	attemptStart time.Time
+	upstream     time.Duration
+}
+
+func newServerTiming(start time.Time) *serverTiming {
+	return &serverTiming{start: start}
+}
+
+func (t *serverTiming) begin(now time.Time) {
+	if t.loopStart.IsZero() {
+		t.loopStart = now
+	}
+	t.attemptStart = now
+}
+
+func (t *serverTiming) end(now time.Time) {
+	t.upstream = now.Sub(t.attemptStart)
+}
+
+// header formats the durations of the gateway overhead, the last attempt including the middlewares,
+// and the previous attempts including the backoff.
+func (t *serverTiming) header(now time.Time) string {
+	var retry time.Duration
+	if !t.loopStart.IsZero() {
+		retry = t.attemptStart.Sub(t.loopStart)
+	}
+	gateway := now.Sub(t.start) - t.upstream - retry
+	metrics := []string{
+		formatServerTiming("gateway", gateway),
+		formatServerTiming("upstream", t.upstream),
+		formatServerTiming("retry", retry),
+	}
+	return strings.Join(metrics, ", ")
+}
+
+func formatServerTiming(name string, d time.Duration) string {
+	return name + ";dur=" + strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+}
+
+// addServerTiming appends the timing to the upstream Server-Timing values.
+func addServerTiming(header http.Header, t *serverTiming) {
+	header.Add("Server-Timing", t.header(time.Now()))
+}
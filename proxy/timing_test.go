@@ -0,0 +1,27 @@
+package proxy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestServerTiming(t *testing.T) {
+	start := time.Unix(0, 0)
+	timing := newServerTiming(start)
+	timing.begin(start.Add(time.Millisecond))
+	timing.end(start.Add(11 * time.Millisecond))
+	timing.begin(start.Add(31 * time.Millisecond))
+	timing.end(start.Add(81 * time.Millisecond))
+	want := "gateway;dur=3.000, upstream;dur=50.000, retry;dur=30.000"
+	if got := timing.header(start.Add(83 * time.Millisecond)); got != want {
+		t.Fatalf("want %q but got %q", want, got)
+	}
+
+	header := http.Header{}
+	header.Set("Server-Timing", "db;dur=5")
+	addServerTiming(header, newServerTiming(time.Now()))
+	if values := header.Values("Server-Timing"); len(values) != 2 || values[0] != "db;dur=5" {
+		t.Fatalf("want the upstream value kept, got: %v", values)
+	}
+}
@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	_tunnelClosed = "closed"
+	_tunnelIdle   = "idle"
+)
+
+var errNotHijackable = errors.New("the connection of the upgraded response can't be hijacked")
+
+// serveTunnel hijacks the client connection of the upgraded response, e.g. WebSocket, and copies the bytes
+// in both directions until either side closes, or no bytes are copied within the idle timeout if it's positive.
+// it returns the reason of the closing, or the error if the client connection is not hijacked.
+func serveTunnel(w http.ResponseWriter, resp *http.Response, header http.Header, idleTimeout time.Duration) (string, error) {
+	backConn, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		return "", errors.New("the body of the upgraded response is not writable")
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return "", errNotHijackable
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+	defer backConn.Close()
+	header.Set("Connection", "Upgrade")
+	header.Set("Upgrade", resp.Header.Get("Upgrade"))
+	res := &http.Response{
+		StatusCode: http.StatusSwitchingProtocols,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     header,
+	}
+	if err := res.Write(brw); err != nil {
+		return _tunnelClosed, nil
+	}
+	if err := brw.Flush(); err != nil {
+		return _tunnelClosed, nil
+	}
+
+	var (
+		lastActive int64 = time.Now().UnixNano()
+		idle       int32
+		closeOnce  sync.Once
+	)
+	closeAll := func() {
+		closeOnce.Do(func() {
+			conn.Close()
+			backConn.Close()
+		})
+	}
+	if idleTimeout > 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(idleTimeout, func() {
+			// the deadline is pushed on the activities of either direction
+			if elapsed := time.Since(time.Unix(0, atomic.LoadInt64(&lastActive))); elapsed < idleTimeout {
+				timer.Reset(idleTimeout - elapsed)
+				return
+			}
+			atomic.StoreInt32(&idle, 1)
+			closeAll()
+		})
+		defer timer.Stop()
+	}
+	done := make(chan struct{}, 2)
+	copyActive := func(dst io.Writer, src io.Reader) {
+		copyTunnel(dst, src, func() { atomic.StoreInt64(&lastActive, time.Now().UnixNano()) })
+		closeAll()
+		done <- struct{}{}
+	}
+	// the bytes buffered by the server are read ahead of the connection
+	go copyActive(backConn, brw.Reader)
+	go copyActive(conn, backConn)
+	<-done
+	<-done
+	if atomic.LoadInt32(&idle) == 1 {
+		return _tunnelIdle, nil
+	}
+	return _tunnelClosed, nil
+}
+
+func copyTunnel(dst io.Writer, src io.Reader, onActive func()) {
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			onActive()
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
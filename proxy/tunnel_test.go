@@ -0,0 +1,86 @@
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestTunnelIdleTimeout(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n")
+		brw.Flush()
+		io.Copy(conn, brw)
+	}))
+	defer upstream.Close()
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.URL.Scheme, req.URL.Host = "http", strings.TrimPrefix(upstream.URL, "http://")
+			return http.DefaultTransport.RoundTrip(req)
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{
+		Protocol:          config.Protocol_HTTP,
+		Path:              "/ws",
+		TunnelIdleTimeout: durationpb.New(100 * time.Millisecond),
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	closed := _metricTunnelsClosed.WithLabelValues("HTTP", "GET", "/ws", _tunnelIdle, "", "")
+	before := testutil.ToFloat64(closed)
+	gateway := httptest.NewServer(p)
+	defer gateway.Close()
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(gateway.URL, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.Write([]byte("GET /ws HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: echo\r\n\r\n"))
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get("Upgrade") != "echo" {
+		t.Fatalf("want 101 upgraded to echo but got %d %v", resp.StatusCode, resp.Header)
+	}
+	// the activities push the idle deadline
+	for i := 0; i < 3; i++ {
+		time.Sleep(50 * time.Millisecond)
+		conn.Write([]byte("ping"))
+		b := make([]byte, 4)
+		if _, err := io.ReadFull(br, b); err != nil || string(b) != "ping" {
+			t.Fatalf("want the echo but got %q: %v", b, err)
+		}
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := br.ReadByte(); err != io.EOF {
+		t.Fatalf("want the idle tunnel closed but got %v", err)
+	}
+	// the metric is recorded after the connections are closed
+	for i := 0; i < 100 && testutil.ToFloat64(closed) == before; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if got := testutil.ToFloat64(closed) - before; got != 1 {
+		t.Fatalf("want 1 idle tunnel closed but got %v", got)
+	}
+}
@@ -0,0 +1,40 @@
+package proxy
+
+import (
+	"errors"
+	"net/textproto"
+	"strings"
+
+	"golang.org/x/net/http2"
+)
+
+// isResponseHeaderTooLarge reports whether the upstream response header exceeds the limit of the transport.
+func isResponseHeaderTooLarge(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "server response headers exceeded") ||
+		strings.Contains(msg, "header list larger than advertised limit")
+}
+
+// isUpstreamProtocolError reports whether the error is caused by the upstream response violating HTTP,
+// e.g. the malformed status line or headers, or the HTTP/2 protocol errors.
+func isUpstreamProtocolError(err error) bool {
+	var protocolErr textproto.ProtocolError
+	if errors.As(err, &protocolErr) {
+		return true
+	}
+	var streamErr http2.StreamError
+	if errors.As(err, &streamErr) {
+		return streamErr.Code == http2.ErrCodeProtocol
+	}
+	var connErr http2.ConnectionError
+	if errors.As(err, &connErr) {
+		return http2.ErrCode(connErr) == http2.ErrCodeProtocol
+	}
+	var goAwayErr http2.GoAwayError
+	if errors.As(err, &goAwayErr) {
+		return goAwayErr.ErrCode == http2.ErrCodeProtocol
+	}
+	// the response parsing errors of net/http are not exported
+	msg := err.Error()
+	return strings.Contains(msg, "malformed HTTP") || strings.Contains(msg, "too many 1xx informational responses")
+}
@@ -0,0 +1,80 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"golang.org/x/net/http2"
+)
+
+func TestIsUpstreamProtocolError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: textproto.ProtocolError("malformed MIME header line"), want: true},
+		{err: fmt.Errorf("stream: %w", http2.StreamError{Code: http2.ErrCodeProtocol}), want: true},
+		{err: http2.StreamError{Code: http2.ErrCodeCancel}},
+		{err: http2.ConnectionError(http2.ErrCodeProtocol), want: true},
+		{err: http2.GoAwayError{ErrCode: http2.ErrCodeNo}},
+		{err: context.DeadlineExceeded},
+		{err: errors.New("connection refused")},
+	}
+	for _, test := range tests {
+		if got := isUpstreamProtocolError(test.err); got != test.want {
+			t.Errorf("%v: want %v but got %v", test.err, test.want, got)
+		}
+	}
+}
+
+func TestMalformedUpstreamResponse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, 4096)
+		conn.Read(buf)
+		conn.Write([]byte("HTTP/1.1 abc OK\r\n\r\n"))
+	}()
+	resp, err := (&http.Client{Transport: &http.Transport{}}).Get("http://" + ln.Addr().String())
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("want error for the malformed response")
+	}
+	if !isUpstreamProtocolError(err) {
+		t.Fatalf("want the protocol error but got %v", err)
+	}
+}
+
+func TestResponseHeaderTooLarge(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Large", strings.Repeat("x", 4096))
+	}))
+	defer srv.Close()
+	resp, err := (&http.Client{Transport: &http.Transport{MaxResponseHeaderBytes: 1024}}).Get(srv.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("want error for the oversized response header")
+	}
+	if !isResponseHeaderTooLarge(err) || isResponseHeaderTooLarge(errors.New("connection refused")) {
+		t.Fatalf("want the header too large error classified but got %v", err)
+	}
+	if code := writeError(httptest.NewRecorder(), err, &config.Endpoint{Protocol: config.Protocol_HTTP}, 0); code != http.StatusBadGateway {
+		t.Fatalf("want 502 but got %d", code)
+	}
+}
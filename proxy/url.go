@@ -0,0 +1,36 @@
+package proxy
+
+import (
+	"net/http"
+)
+
+// _defaultMaxURLLength is the default limit of the request URI length, which is the common limit of the servers.
+const _defaultMaxURLLength = 8192
+
+const (
+	urlTooLong          = "url_too_long"
+	urlControlCharacter = "control_character"
+)
+
+// checkURL returns the status code and the reason if the request URI is too long,
+// or the decoded path contains the control characters, e.g. NUL or CRLF, which may be used for the injections.
+func checkURL(req *http.Request, maxLength int) (int, string) {
+	uri := req.RequestURI
+	if uri == "" {
+		uri = req.URL.RequestURI()
+	}
+	if maxLength > 0 && len(uri) > maxLength {
+		return http.StatusRequestURITooLong, urlTooLong
+	}
+	for i := 0; i < len(req.URL.Path); i++ {
+		if c := req.URL.Path[i]; c < 0x20 || c == 0x7f {
+			return http.StatusBadRequest, urlControlCharacter
+		}
+	}
+	return 0, ""
+}
+
+func rejectURL(w http.ResponseWriter, statusCode int, reason string) {
+	_metricURLRejected.WithLabelValues(reason).Inc()
+	http.Error(w, http.StatusText(statusCode), statusCode)
+}
@@ -0,0 +1,81 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	config "github.com/go-kratos/gateway/api/gateway/config/v1"
+	"github.com/go-kratos/gateway/middleware"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		target     string
+		maxLength  int
+		wantCode   int
+		wantReason string
+	}{
+		{target: "/users/1?q=a", maxLength: 16},
+		{target: "/users/1?q=abcdefgh", maxLength: 16, wantCode: http.StatusRequestURITooLong, wantReason: urlTooLong},
+		{target: "/" + strings.Repeat("a", 100)},
+		{target: "/users/%00", wantCode: http.StatusBadRequest, wantReason: urlControlCharacter},
+		{target: "/users/%0d%0aSet-Cookie:a=b", wantCode: http.StatusBadRequest, wantReason: urlControlCharacter},
+		{target: "/users/%7F", wantCode: http.StatusBadRequest, wantReason: urlControlCharacter},
+		{target: "/users/%20%E4%BD%A0"},
+	}
+	for _, test := range tests {
+		code, reason := checkURL(httptest.NewRequest("GET", test.target, nil), test.maxLength)
+		if code != test.wantCode || reason != test.wantReason {
+			t.Errorf("%s: want %d %q but got %d %q", test.target, test.wantCode, test.wantReason, code, reason)
+		}
+	}
+}
+
+func TestRejectURL(t *testing.T) {
+	p, err := New(func(*config.Endpoint) (http.RoundTripper, error) {
+		return middleware.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: http.NoBody}, nil
+		}), nil
+	}, middleware.Create)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Update(&config.Gateway{
+		MaxUrlLength: 64,
+		Endpoints:    []*config.Endpoint{{Protocol: config.Protocol_HTTP, Path: "/users/*"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	before := testutil.ToFloat64(_metricURLRejected.WithLabelValues(urlTooLong))
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/users/"+strings.Repeat("a", 64), nil))
+	if w.Code != http.StatusRequestURITooLong {
+		t.Fatalf("want %d but got %d", http.StatusRequestURITooLong, w.Code)
+	}
+	if got := testutil.ToFloat64(_metricURLRejected.WithLabelValues(urlTooLong)) - before; got != 1 {
+		t.Fatalf("want the rejection recorded but got %v", got)
+	}
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/users/1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200 but got %d", w.Code)
+	}
+
+	// the default limit applies without the config
+	if err := p.Update(&config.Gateway{Endpoints: []*config.Endpoint{{Protocol: config.Protocol_HTTP, Path: "/users/*"}}}); err != nil {
+		t.Fatal(err)
+	}
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/users/"+strings.Repeat("a", 64), nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("want 200 but got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	p.ServeHTTP(w, httptest.NewRequest("GET", "/users/"+strings.Repeat("a", _defaultMaxURLLength), nil))
+	if w.Code != http.StatusRequestURITooLong {
+		t.Fatalf("want %d but got %d", http.StatusRequestURITooLong, w.Code)
+	}
+}
@@ -1,40 +1,328 @@
 package mux
 
 import (
+	"fmt"
 	"net/http"
+	"regexp"
 	"strings"
 
 	"github.com/go-kratos/gateway/router"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var _ = new(router.Router)
 
+// _metricsHandler serves the metrics, the exemplars are exposed if the scraper accepts OpenMetrics.
+var _metricsHandler = promhttp.InstrumentMetricHandler(
+	prometheus.DefaultRegisterer,
+	promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+)
+
+// TrailingSlash is the trailing slash matching behavior.
+type TrailingSlash int
+
+const (
+	// TrailingSlashRedirect redirects with 308 to the registered form, it's the default.
+	TrailingSlashRedirect TrailingSlash = iota
+	// TrailingSlashStrict treats /users and /users/ as distinct routes.
+	TrailingSlashStrict
+	// TrailingSlashMerge serves the request by the route of the registered form.
+	TrailingSlashMerge
+)
+
+// PathCase is the path case matching behavior.
+type PathCase int
+
+const (
+	// PathCaseSensitive matches the path case-sensitively.
+	PathCaseSensitive PathCase = iota
+	// PathCaseInsensitive matches the path case-insensitively, the original path is forwarded.
+	PathCaseInsensitive
+	// PathCaseCanonical matches the path case-insensitively, the path is forwarded in lower case.
+	PathCaseCanonical
+)
+
+// Option is a mux router option.
+type Option func(*muxRouter)
+
+// WithTrailingSlash sets the trailing slash matching behavior,
+// it only applies when the request path has no matched route.
+func WithTrailingSlash(ts TrailingSlash) Option {
+	return func(r *muxRouter) {
+		r.trailingSlash = ts
+	}
+}
+
+// WithPathCase sets the path case matching behavior, the case-insensitive matching
+// only applies when the request path has no case-sensitively matched route.
+func WithPathCase(pc PathCase) Option {
+	return func(r *muxRouter) {
+		r.pathCase = pc
+	}
+}
+
+// WithHeadAsGet routes the HEAD requests to the GET routes.
+func WithHeadAsGet() Option {
+	return func(r *muxRouter) {
+		r.headAsGet = true
+	}
+}
+
 type muxRouter struct {
 	*mux.Router
+	trailingSlash TrailingSlash
+	headAsGet     bool
+	pathCase      PathCase
+	// folded has the routes of the lower case patterns for the case-insensitive matching.
+	folded *mux.Router
+	// patterns are the registered patterns of the routes expanded from optional segments,
+	// or the gRPC services and methods matched.
+	patterns map[*mux.Route]string
+	// prefixes are the routes of the path prefixes, whose trailing slash is never toggled.
+	prefixes map[*mux.Route]struct{}
 }
 
 // NewRouter new a mux router.
-func NewRouter(notFoundHandler, methodNotAllowedHandler http.Handler) router.Router {
+func NewRouter(notFoundHandler, methodNotAllowedHandler http.Handler, opts ...Option) router.Router {
 	r := &muxRouter{
-		Router: mux.NewRouter().StrictSlash(true),
+		Router:   mux.NewRouter(),
+		patterns: make(map[*mux.Route]string),
+		prefixes: make(map[*mux.Route]struct{}),
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	if r.pathCase != PathCaseSensitive {
+		r.folded = mux.NewRouter()
 	}
-	r.Router.Handle("/metrics", promhttp.Handler())
+	r.Router.Handle("/metrics", _metricsHandler)
 	r.Router.NotFoundHandler = notFoundHandler
 	r.Router.MethodNotAllowedHandler = methodNotAllowedHandler
 	return r
 }
 
 func (r *muxRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.trailingSlash != TrailingSlashStrict {
+		if alt, ok := r.matchTrailingSlash(req); ok {
+			if r.trailingSlash == TrailingSlashRedirect {
+				http.Redirect(w, req, alt.URL.String(), http.StatusPermanentRedirect)
+				return
+			}
+			req = alt
+		}
+	}
+	if r.folded != nil {
+		if alt, handler, ok := r.matchFolded(req); ok {
+			if r.pathCase == PathCaseCanonical {
+				r.folded.ServeHTTP(w, alt)
+				return
+			}
+			// the path is only folded for the matching
+			handler.ServeHTTP(w, req)
+			return
+		}
+	}
 	r.Router.ServeHTTP(w, req)
 }
 
-func (r *muxRouter) Handle(pattern, method string, handler http.Handler) error {
-	next := r.Router.NewRoute().Handler(handler)
+// matchFolded returns the request with the lower case path and the handler of its route,
+// if the original path has no matched route but the folded one has.
+func (r *muxRouter) matchFolded(req *http.Request) (*http.Request, http.Handler, bool) {
+	var match mux.RouteMatch
+	if r.Router.Match(req, &match) && match.MatchErr != mux.ErrNotFound {
+		return nil, nil, false
+	}
+	path := foldCase(req.URL.Path)
+	if path == req.URL.Path {
+		return nil, nil, false
+	}
+	u := *req.URL
+	u.Path = path
+	u.RawPath = ""
+	alt := req.WithContext(req.Context())
+	alt.URL = &u
+	match = mux.RouteMatch{}
+	if !r.folded.Match(alt, &match) || match.MatchErr != nil {
+		return nil, nil, false
+	}
+	return alt, match.Handler, true
+}
+
+// foldCase returns the ASCII lower case of the path, so that the length is kept.
+func foldCase(path string) string {
+	return strings.Map(func(c rune) rune {
+		if 'A' <= c && c <= 'Z' {
+			return c + 'a' - 'A'
+		}
+		return c
+	}, path)
+}
+
+// foldVariable returns the variable matching its pattern case-insensitively, e.g. {id:[A-Z]+} to {id:(?i)[A-Z]+}.
+func foldVariable(v string) string {
+	if i := strings.Index(v, ":"); i > 0 {
+		return v[:i+1] + "(?i)" + v[i+1:]
+	}
+	return v
+}
+
+// foldPattern returns the lower case of the pattern except the variable names, and the variable patterns
+// are case-insensitive, e.g. /Users/{ID:[A-Z]+} is folded to /users/{ID:(?i)[A-Z]+}.
+func foldPattern(pattern string) string {
+	var (
+		b     strings.Builder
+		depth int
+		start int
+	)
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			if depth == 0 {
+				b.WriteString(foldCase(pattern[start:i]))
+				start = i
+			}
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				b.WriteString(foldVariable(pattern[start : i+1]))
+				start = i + 1
+			}
+		}
+	}
+	b.WriteString(foldCase(pattern[start:]))
+	return b.String()
+}
+
+// matchTrailingSlash returns the request with the trailing slash toggled,
+// if the original path has no matched route but the toggled one has, and it's not of a path prefix,
+// e.g. /api isn't redirected to /api/ of /api/*.
+func (r *muxRouter) matchTrailingSlash(req *http.Request) (*http.Request, bool) {
+	path := req.URL.Path
+	if path == "/" || path == "" {
+		return nil, false
+	}
+	var match mux.RouteMatch
+	if r.Router.Match(req, &match) && match.MatchErr != mux.ErrNotFound {
+		return nil, false
+	}
+	if strings.HasSuffix(path, "/") {
+		path = strings.TrimRight(path, "/")
+	} else {
+		path += "/"
+	}
+	u := *req.URL
+	u.Path = path
+	u.RawPath = ""
+	alt := req.WithContext(req.Context())
+	alt.URL = &u
+	match = mux.RouteMatch{}
+	if !r.Router.Match(alt, &match) || match.MatchErr != nil {
+		return nil, false
+	}
+	if _, ok := r.prefixes[match.Route]; ok {
+		return nil, false
+	}
+	return alt, true
+}
+
+// _optionalSegment matches the optional segment, e.g. {sub?} or {sub?:[a-z]+}.
+var _optionalSegment = regexp.MustCompile(`^\{([^}:]+)\?(:.*)?\}$`)
+
+// expandOptionalSegments expands the trailing optional segments of the pattern,
+// e.g. /items/{id}/{sub?} is expanded to /items/{id}/{sub} and /items/{id}.
+func expandOptionalSegments(pattern string) ([]string, error) {
+	if strings.HasSuffix(pattern, "*") {
+		return []string{pattern}, nil
+	}
+	var optionals []string
+	base := pattern
+	for {
+		i := strings.LastIndex(base, "/")
+		if i < 0 {
+			break
+		}
+		m := _optionalSegment.FindStringSubmatch(base[i+1:])
+		if m == nil {
+			break
+		}
+		optionals = append([]string{"{" + m[1] + m[2] + "}"}, optionals...)
+		base = base[:i]
+	}
+	if strings.Contains(base, "?}") || strings.Contains(base, "?:") {
+		return nil, fmt.Errorf("optional segments must be trailing: %s", pattern)
+	}
+	if len(optionals) == 0 {
+		return []string{pattern}, nil
+	}
+	out := make([]string, 0, len(optionals)+1)
+	for i := len(optionals); i >= 0; i-- {
+		expanded := base
+		for _, segment := range optionals[:i] {
+			expanded += "/" + segment
+		}
+		if expanded == "" {
+			expanded = "/"
+		}
+		out = append(out, expanded)
+	}
+	return out, nil
+}
+
+func (r *muxRouter) Handle(pattern, method string, handler http.Handler, opts ...router.RouteOption) error {
+	o := &router.RouteOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.GRPC != nil {
+		return r.handleGRPC(o.GRPC, method, handler, o)
+	}
+	patterns, err := expandOptionalSegments(pattern)
+	if err != nil {
+		return err
+	}
+	for _, expanded := range patterns {
+		route, err := r.handle(r.Router, expanded, method, handler, o)
+		if err != nil {
+			return err
+		}
+		if expanded != pattern {
+			r.patterns[route] = pattern
+		}
+		if r.folded != nil {
+			if _, err := r.handle(r.folded, foldPattern(expanded), method, handler, o); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// handleGRPC registers the route of /package.Service/Method, or /package.Service/{method} for all of the methods.
+func (r *muxRouter) handleGRPC(m *router.GRPCMatch, method string, handler http.Handler, o *router.RouteOptions) error {
+	if m.Service == "" || strings.Contains(m.Service, "/") || strings.Contains(m.Method, "/") {
+		return fmt.Errorf("invalid gRPC service or method: %s/%s", m.Service, m.Method)
+	}
+	pattern := "/" + m.Service + "/" + m.Method
+	if m.Method == "" {
+		pattern = "/" + m.Service + "/{method}"
+	}
+	route, err := r.handle(r.Router, pattern, method, handler, o)
+	if err != nil {
+		return err
+	}
+	r.patterns[route] = "grpc:" + m.Service + "/" + m.Method
+	return nil
+}
+
+func (r *muxRouter) handle(root *mux.Router, pattern, method string, handler http.Handler, o *router.RouteOptions) (*mux.Route, error) {
+	next := root.NewRoute().Handler(handler)
 	if strings.HasSuffix(pattern, "*") {
 		// /api/echo/*
 		next = next.PathPrefix(strings.TrimRight(pattern, "*"))
+		r.prefixes[next] = struct{}{}
 	} else {
 		// /api/echo/hello
 		// /api/echo/[a-z]+
@@ -42,12 +330,24 @@ func (r *muxRouter) Handle(pattern, method string, handler http.Handler) error {
 		next = next.Path(pattern)
 	}
 	if method != "" && method != "*" {
-		next = next.Methods(method, http.MethodOptions)
+		methods := []string{method, http.MethodOptions}
+		if r.headAsGet && method == http.MethodGet {
+			methods = append(methods, http.MethodHead)
+		}
+		next = next.Methods(methods...)
+	}
+	for _, q := range o.Queries {
+		// ?action=foo
+		// ?action={action:[a-z]+}
+		next = next.Queries(q.Name, q.Value)
 	}
-	return next.GetError()
+	return next, next.GetError()
 }
 
 type RouterInspect struct {
+	// Pattern is the registered pattern if the route is expanded from optional segments,
+	// or grpc:package.Service/Method if the route matches the gRPC service and method.
+	Pattern          string   `json:"pattern,omitempty"`
 	PathTemplate     string   `json:"path_template"`
 	PathRegexp       string   `json:"path_regexp"`
 	QueriesTemplates []string `json:"queries_templates"`
@@ -68,6 +368,7 @@ func InspectMuxRouter(in interface{}) []*RouterInspect {
 		queriesRegexps, _ := route.GetQueriesRegexp()
 		methods, _ := route.GetMethods()
 		out = append(out, &RouterInspect{
+			Pattern:          r.patterns[route],
 			PathTemplate:     pathTemplate,
 			PathRegexp:       pathRegexp,
 			QueriesTemplates: queriesTemplates,
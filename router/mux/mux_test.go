@@ -0,0 +1,283 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/go-kratos/gateway/router"
+	"github.com/gorilla/mux"
+)
+
+func newTestRouter(t *testing.T, opts ...Option) http.Handler {
+	r := NewRouter(http.NotFoundHandler(), http.NotFoundHandler(), opts...)
+	for _, path := range []string{"/users", "/items/", "/both", "/both/", "/api/*"} {
+		path := path
+		if err := r.Handle(path, "", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Route", path)
+			w.Header().Set("X-Path", req.URL.Path)
+		})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r
+}
+
+func TestTrailingSlash(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		path     string
+		code     int
+		route    string
+		location string
+	}{
+		{name: "strict", opts: []Option{WithTrailingSlash(TrailingSlashStrict)}, path: "/users", code: 200, route: "/users"},
+		{name: "strict", opts: []Option{WithTrailingSlash(TrailingSlashStrict)}, path: "/users/", code: 404},
+		{name: "strict", opts: []Option{WithTrailingSlash(TrailingSlashStrict)}, path: "/items", code: 404},
+		// the redirects are the default
+		{name: "default", path: "/users/", code: 308, location: "/users"},
+		{name: "redirect", opts: []Option{WithTrailingSlash(TrailingSlashRedirect)}, path: "/users/?a=b", code: 308, location: "/users?a=b"},
+		{name: "redirect", opts: []Option{WithTrailingSlash(TrailingSlashRedirect)}, path: "/items", code: 308, location: "/items/"},
+		{name: "redirect", opts: []Option{WithTrailingSlash(TrailingSlashRedirect)}, path: "/users", code: 200, route: "/users"},
+		{name: "merge", opts: []Option{WithTrailingSlash(TrailingSlashMerge)}, path: "/users/", code: 200, route: "/users"},
+		{name: "merge", opts: []Option{WithTrailingSlash(TrailingSlashMerge)}, path: "/items", code: 200, route: "/items/"},
+		{name: "merge", opts: []Option{WithTrailingSlash(TrailingSlashMerge)}, path: "/missing/", code: 404},
+		// the explicitly registered path takes precedence
+		{name: "merge", opts: []Option{WithTrailingSlash(TrailingSlashMerge)}, path: "/both/", code: 200, route: "/both/"},
+		{name: "redirect", opts: []Option{WithTrailingSlash(TrailingSlashRedirect)}, path: "/both", code: 200, route: "/both"},
+		// the path prefix isn't toggled as the previous versions
+		{name: "default", path: "/api", code: 404},
+		{name: "merge", opts: []Option{WithTrailingSlash(TrailingSlashMerge)}, path: "/api", code: 404},
+		{name: "default", path: "/api/users/", code: 200, route: "/api/*"},
+	}
+	for _, test := range tests {
+		r := newTestRouter(t, test.opts...)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", test.path, nil))
+		if w.Code != test.code {
+			t.Fatalf("%s %s: want %d but got %d", test.name, test.path, test.code, w.Code)
+		}
+		if route := w.Header().Get("X-Route"); route != test.route {
+			t.Fatalf("%s %s: want route %q but got %q", test.name, test.path, test.route, route)
+		}
+		if location := w.Header().Get("Location"); location != test.location {
+			t.Fatalf("%s %s: want location %q but got %q", test.name, test.path, test.location, location)
+		}
+	}
+}
+
+func TestPathCase(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		path  string
+		code  int
+		route string
+		want  string
+	}{
+		{name: "sensitive", path: "/Users", code: 404},
+		{name: "insensitive", opts: []Option{WithPathCase(PathCaseInsensitive)}, path: "/USERS", code: 200, route: "/users", want: "/USERS"},
+		{name: "insensitive", opts: []Option{WithPathCase(PathCaseInsensitive)}, path: "/Items/A1", code: 200, route: "/items/{id:[A-Z][0-9]}", want: "/Items/A1"},
+		{name: "insensitive", opts: []Option{WithPathCase(PathCaseInsensitive)}, path: "/Missing", code: 404},
+		{name: "canonical", opts: []Option{WithPathCase(PathCaseCanonical)}, path: "/API/Users", code: 200, route: "/api/users", want: "/api/users"},
+		// the case-sensitive matches take precedence
+		{name: "canonical", opts: []Option{WithPathCase(PathCaseCanonical)}, path: "/Both", code: 200, route: "/Both", want: "/Both"},
+		{name: "canonical", opts: []Option{WithPathCase(PathCaseCanonical)}, path: "/BOTH", code: 200, route: "/both", want: "/both"},
+	}
+	for _, test := range tests {
+		r := NewRouter(http.NotFoundHandler(), http.NotFoundHandler(), test.opts...)
+		for _, path := range []string{"/users", "/items/{id:[A-Z][0-9]}", "/api/users", "/both", "/Both"} {
+			path := path
+			if err := r.Handle(path, "GET", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("X-Route", path)
+				w.Header().Set("X-Path", req.URL.Path)
+			})); err != nil {
+				t.Fatal(err)
+			}
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", test.path, nil))
+		if w.Code != test.code {
+			t.Fatalf("%s %s: want %d but got %d", test.name, test.path, test.code, w.Code)
+		}
+		if route := w.Header().Get("X-Route"); route != test.route {
+			t.Fatalf("%s %s: want route %q but got %q", test.name, test.path, test.route, route)
+		}
+		if path := w.Header().Get("X-Path"); path != test.want {
+			t.Fatalf("%s %s: want forwarded path %q but got %q", test.name, test.path, test.want, path)
+		}
+	}
+	if got, want := foldPattern("/API/{ID:[A-Z]{2}}/{Name}/Items"), "/api/{ID:(?i)[A-Z]{2}}/{Name}/items"; got != want {
+		t.Fatalf("want %s but got %s", want, got)
+	}
+}
+
+func TestQueryMatchers(t *testing.T) {
+	r := NewRouter(http.NotFoundHandler(), http.NotFoundHandler())
+	routes := []struct {
+		name string
+		opts []router.RouteOption
+	}{
+		{name: "foo", opts: []router.RouteOption{router.WithQuery("action", "foo")}},
+		{name: "presence", opts: []router.RouteOption{router.WithQuery("action", "")}},
+		{name: "regexp", opts: []router.RouteOption{router.WithQuery("id", "{id:[0-9]+}")}},
+		{name: "default"},
+	}
+	for _, route := range routes {
+		name := route.name
+		if err := r.Handle("/api", "GET", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Route", name)
+		}), route.opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		path  string
+		route string
+	}{
+		{path: "/api?action=foo", route: "foo"},
+		{path: "/api?action=bar", route: "presence"},
+		{path: "/api?action=", route: "presence"},
+		{path: "/api?id=123", route: "regexp"},
+		{path: "/api?id=abc", route: "default"},
+		{path: "/api", route: "default"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", test.path, nil))
+		if route := w.Header().Get("X-Route"); route != test.route {
+			t.Errorf("%s: want route %q but got %q", test.path, test.route, route)
+		}
+	}
+	var queries []string
+	for _, inspect := range InspectMuxRouter(r) {
+		queries = append(queries, inspect.QueriesTemplates...)
+	}
+	if want := []string{"action=foo", "action=", "id={id:[0-9]+}"}; !reflect.DeepEqual(queries, want) {
+		t.Errorf("want inspect queries %v but got %v", want, queries)
+	}
+}
+
+func TestHeadAsGet(t *testing.T) {
+	for _, headAsGet := range []bool{false, true} {
+		var opts []Option
+		want := http.StatusMethodNotAllowed
+		if headAsGet {
+			opts = append(opts, WithHeadAsGet())
+			want = http.StatusOK
+		}
+		r := NewRouter(http.NotFoundHandler(), http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}), opts...)
+		if err := r.Handle("/api", "GET", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})); err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("HEAD", "/api", nil))
+		if w.Code != want {
+			t.Errorf("head as get %v: want %d but got %d", headAsGet, want, w.Code)
+		}
+	}
+}
+
+func TestOptionalSegments(t *testing.T) {
+	r := NewRouter(http.NotFoundHandler(), http.NotFoundHandler())
+	if err := r.Handle("/items/{id}/{sub?:[a-z]+}", "GET", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		vars := mux.Vars(req)
+		sub, ok := vars["sub"]
+		w.Header().Set("X-Id", vars["id"])
+		if ok {
+			w.Header().Set("X-Sub", sub)
+		}
+	})); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path string
+		code int
+		id   string
+		sub  string
+	}{
+		{path: "/items/1/detail", code: http.StatusOK, id: "1", sub: "detail"},
+		{path: "/items/1", code: http.StatusOK, id: "1"},
+		{path: "/items/1/123", code: http.StatusNotFound},
+		{path: "/items", code: http.StatusNotFound},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", test.path, nil))
+		if w.Code != test.code || w.Header().Get("X-Id") != test.id || w.Header().Get("X-Sub") != test.sub {
+			t.Errorf("%s: want %d %q %q but got %d %q %q", test.path, test.code, test.id, test.sub,
+				w.Code, w.Header().Get("X-Id"), w.Header().Get("X-Sub"))
+		}
+	}
+	var patterns []string
+	for _, inspect := range InspectMuxRouter(r) {
+		if inspect.Pattern != "" {
+			patterns = append(patterns, inspect.Pattern)
+		}
+	}
+	if len(patterns) != 2 {
+		t.Fatalf("want the expanded routes inspected with the pattern, got: %v", patterns)
+	}
+	if err := r.Handle("/items/{id?}/detail", "GET", http.NotFoundHandler()); err == nil {
+		t.Fatal("want error for the optional segment not trailing")
+	}
+}
+
+func TestExpandOptionalSegments(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{pattern: "/items/{id}", want: []string{"/items/{id}"}},
+		{pattern: "/items/{id?}", want: []string{"/items/{id}", "/items"}},
+		{pattern: "/{a?}/{b?}", want: []string{"/{a}/{b}", "/{a}", "/"}},
+		{pattern: "/api/*", want: []string{"/api/*"}},
+	}
+	for _, test := range tests {
+		got, err := expandOptionalSegments(test.pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: want %v but got %v", test.pattern, test.want, got)
+		}
+	}
+}
+
+func TestGRPCMatch(t *testing.T) {
+	r := NewRouter(http.NotFoundHandler(), http.NotFoundHandler())
+	for _, m := range []router.GRPCMatch{
+		{Service: "helloworld.Greeter", Method: "SayHello"},
+		{Service: "helloworld.Greeter"},
+	} {
+		route := m.Service + "/" + m.Method
+		if err := r.Handle("", "POST", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Route", route)
+		}), router.WithGRPC(m.Service, m.Method)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		path  string
+		route string
+	}{
+		{path: "/helloworld.Greeter/SayHello", route: "helloworld.Greeter/SayHello"},
+		{path: "/helloworld.Greeter/SayGoodbye", route: "helloworld.Greeter/"},
+		{path: "/helloworld.Greeter/SayHello/extra"},
+		{path: "/helloworldxGreeter/SayHello"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("POST", test.path, nil))
+		if got := w.Header().Get("X-Route"); got != test.route {
+			t.Errorf("%s: want route %q but got %q", test.path, test.route, got)
+		}
+	}
+	if err := r.Handle("", "POST", http.NotFoundHandler(), router.WithGRPC("", "SayHello")); err == nil {
+		t.Fatal("want error for the empty service")
+	}
+}
@@ -7,5 +7,43 @@ import (
 // Router is a gateway router.
 type Router interface {
 	http.Handler
-	Handle(pattern, method string, handler http.Handler) error
+	Handle(pattern, method string, handler http.Handler, opts ...RouteOption) error
+}
+
+// RouteOption is a route option.
+type RouteOption func(*RouteOptions)
+
+// RouteOptions is the additional matching conditions of a route.
+type RouteOptions struct {
+	Queries []Query
+	// GRPC matches the gRPC service and method instead of the pattern if set.
+	GRPC *GRPCMatch
+}
+
+// GRPCMatch matches the gRPC requests of /package.Service/Method,
+// all of the methods of the service are matched if the method is empty.
+type GRPCMatch struct {
+	Service string
+	Method  string
+}
+
+// Query is a query parameter matcher,
+// an empty value matches any value as long as the parameter is present.
+type Query struct {
+	Name  string
+	Value string
+}
+
+// WithQuery matches the query parameter with the value.
+func WithQuery(name, value string) RouteOption {
+	return func(o *RouteOptions) {
+		o.Queries = append(o.Queries, Query{Name: name, Value: value})
+	}
+}
+
+// WithGRPC matches the gRPC service and method, the method is optional.
+func WithGRPC(service, method string) RouteOption {
+	return func(o *RouteOptions) {
+		o.GRPC = &GRPCMatch{Service: service, Method: method}
+	}
 }
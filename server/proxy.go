@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"math"
+	"net"
 	"net/http"
 	"os"
 	"time"
 
+	"github.com/go-kratos/gateway/proxy"
 	"github.com/go-kratos/kratos/v2/log"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
@@ -49,15 +51,20 @@ type ProxyServer struct {
 	*http.Server
 }
 
+// newHTTP2Server returns the HTTP/2 settings shared by the h2c and the TLS connections.
+func newHTTP2Server() *http2.Server {
+	return &http2.Server{
+		IdleTimeout:          idleTimeout,
+		MaxConcurrentStreams: math.MaxUint32,
+	}
+}
+
 // NewProxy new a gateway server.
 func NewProxy(handler http.Handler, addr string) *ProxyServer {
 	return &ProxyServer{
 		Server: &http.Server{
-			Addr: addr,
-			Handler: h2c.NewHandler(handler, &http2.Server{
-				IdleTimeout:          idleTimeout,
-				MaxConcurrentStreams: math.MaxUint32,
-			}),
+			Addr:              addr,
+			Handler:           h2c.NewHandler(handler, newHTTP2Server()),
 			ReadTimeout:       readTimeout,
 			ReadHeaderTimeout: readHeaderTimeout,
 			WriteTimeout:      writeTimeout,
@@ -66,16 +73,43 @@ func NewProxy(handler http.Handler, addr string) *ProxyServer {
 	}
 }
 
-// Start the server.
+// Start the server, it serves TLS with the certificates of the TLS config if set.
 func (s *ProxyServer) Start(ctx context.Context) error {
 	log.Infof("proxy listening on %s", s.Addr)
-	err := s.ListenAndServe()
+	var err error
+	if s.TLSConfig != nil {
+		// the bundled HTTP/2 of net/http has its own defaults, e.g. 250 max concurrent streams
+		if err = http2.ConfigureServer(s.Server, newHTTP2Server()); err != nil {
+			return err
+		}
+	}
+	err = s.listenAndServe()
 	if errors.Is(err, http.ErrServerClosed) {
 		return nil
 	}
 	return err
 }
 
+// listenAndServe serves the connections of the listener wrapped by proxy.FramingListener,
+// with TLS if the TLS config is set.
+func (s *ProxyServer) listenAndServe() error {
+	addr := s.Addr
+	if addr == "" {
+		addr = ":http"
+		if s.TLSConfig != nil {
+			addr = ":https"
+		}
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	if s.TLSConfig != nil {
+		return s.ServeTLS(proxy.FramingListener(ln), "", "")
+	}
+	return s.Serve(proxy.FramingListener(ln))
+}
+
 // Stop the server.
 func (s *ProxyServer) Stop(ctx context.Context) error {
 	log.Info("proxy stopping")